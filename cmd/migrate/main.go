@@ -0,0 +1,91 @@
+// migrate 는 SQL 저장소 (STORAGE_BACKEND=postgres, sqlite) 의 스키마 마이그레이션 실행 도구
+//
+//	go run ./cmd/migrate [-backend postgres] [-dsn postgres://...] up|down|status
+//
+// -backend, -dsn 을 지정하지 않으면 -env 파일의 STORAGE_BACKEND, DATABASE_URL 사용
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"signupin-api/internal/pkg/sqlstore"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	envFile := flag.String("env", "config/.env", "env file to read STORAGE_BACKEND and DATABASE_URL from")
+	backend := flag.String("backend", "", "sql backend (postgres, sqlite)")
+	dsn := flag.String("dsn", "", "data source name")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: migrate [flags] up|down|status\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	_ = godotenv.Load(*envFile)
+	if len(*backend) == 0 {
+		*backend = os.Getenv("STORAGE_BACKEND")
+	}
+	if len(*dsn) == 0 {
+		*dsn = os.Getenv("DATABASE_URL")
+	}
+
+	db, err := sqlstore.Open(*backend, *dsn)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	switch flag.Arg(0) {
+	case "up":
+		applied, err := db.MigrateUp(ctx)
+		for _, m := range applied {
+			fmt.Printf("applied %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+	case "down":
+		reverted, err := db.MigrateDown(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if reverted == nil {
+			fmt.Println("no applied migrations")
+			return
+		}
+		fmt.Printf("reverted %04d_%s\n", reverted.Version, reverted.Name)
+	case "status":
+		migrations, err := db.Migrations(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, m := range migrations {
+			status := "pending"
+			if m.AppliedAt != nil {
+				status = "applied " + m.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%s\t%s\n", m.Version, m.Name, status)
+		}
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
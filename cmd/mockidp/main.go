@@ -0,0 +1,116 @@
+// mockidp 는 소셜 로그인 (SOCIAL_MOCK_*) 을 로컬에서 확인하기 위한 OAuth2 / OIDC 인증 제공자
+// 로그인 화면 없이 authorization endpoint 호출 즉시 인가 코드를 발급
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+type grant struct {
+	clientID string
+	nonce    string
+}
+
+func main() {
+	addr := flag.String("addr", ":9999", "listen address")
+	issuer := flag.String("issuer", "http://localhost:9999", "issuer (iss claim)")
+	subject := flag.String("sub", "mock-user-1", "subject of the signed-in account")
+	email := flag.String("email", "mock-user@example.com", "email of the signed-in account")
+	emailVerified := flag.Bool("email-verified", true, "whether the email is verified (email_verified claim)")
+	name := flag.String("name", "mock user", "name of the signed-in account")
+	flag.Parse()
+
+	var mu sync.Mutex
+	grants := map[string]grant{}
+
+	claims := func(clientID, nonce string) map[string]interface{} {
+		result := map[string]interface{}{
+			"iss":            *issuer,
+			"sub":            *subject,
+			"aud":            clientID,
+			"email":          *email,
+			"email_verified": *emailVerified,
+			"name":           *name,
+			"iat":            time.Now().Unix(),
+			"exp":            time.Now().Add(time.Hour).Unix(),
+		}
+		if len(nonce) > 0 {
+			result["nonce"] = nonce
+		}
+		return result
+	}
+
+	http.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		redirectURI, err := url.Parse(query.Get("redirect_uri"))
+		if err != nil || len(redirectURI.String()) == 0 {
+			http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+			return
+		}
+
+		code := randomString()
+		mu.Lock()
+		grants[code] = grant{clientID: query.Get("client_id"), nonce: query.Get("nonce")}
+		mu.Unlock()
+
+		values := redirectURI.Query()
+		values.Set("code", code)
+		values.Set("state", query.Get("state"))
+		redirectURI.RawQuery = values.Encode()
+
+		http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+	})
+
+	http.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		found, ok := grants[r.PostFormValue("code")]
+		delete(grants, r.PostFormValue("code"))
+		mu.Unlock()
+
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		// 서명하지 않은 ID 토큰 (alg: none), 로컬 확인 용도로만 사용
+		header, _ := json.Marshal(map[string]string{"alg": "none", "typ": "JWT"})
+		payload, _ := json.Marshal(claims(found.clientID, found.nonce))
+		idToken := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload) + "."
+
+		writeJSON(w, map[string]interface{}{
+			"access_token": randomString(),
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     idToken,
+		})
+	})
+
+	http.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, claims("", ""))
+	})
+
+	log.Printf("mock identity provider listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func randomString() string {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"net/http"
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/app/api/middleware"
+	"signupin-api/internal/pkg/apikey"
+	"signupin-api/internal/pkg/audit"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/kkodecaffeine/go-common/rest"
+)
+
+type APIKeyController struct {
+	v       *validator.Validate
+	usecase apikey.Usecase
+	audit   audit.Usecase
+}
+
+// NewAPIKeyController returns new API key controller instance
+func NewAPIKeyController(e *gin.Engine, v *validator.Validate, uc apikey.Usecase, audits audit.Usecase, authenticate gin.HandlerFunc) APIKeyController {
+	ctrl := APIKeyController{v, uc, audits}
+
+	// API 키 관리는 회원 토큰 전용 (API 키로 API 키를 발급할 수 없음)
+	authorized := e.Group("/v1").Group("/")
+	authorized.Use(authenticate)
+	authorized.POST("/users/me/api-keys", middleware.Scopes(), ctrl.SaveOne)
+	authorized.GET("/users/me/api-keys", middleware.Scopes(), ctrl.GetAll)
+	authorized.DELETE("/users/me/api-keys/:id", middleware.Scopes(), ctrl.Revoke)
+
+	return ctrl
+}
+
+/**
+ * API 키 발급 API
+ * JWT 검증 과정 후 요청받은 이름, scope, 만료 시각으로 API 키 발급
+ * @return : API 키 (발급 시 1회만 노출, 이후에는 앞부분만 조회 가능)
+ */
+func (ctrl *APIKeyController) SaveOne(c *gin.Context) {
+	response := rest.NewApiResponse()
+
+	var req dto.PostAPIKeyRequest
+	if !bindJSON(c, ctrl.v, response, &req) {
+		return
+	}
+
+	result, err := ctrl.usecase.SaveOne(c.Request.Context(), middleware.GetPrincipal(c).Subject, &req)
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	response.Created("", result)
+	c.JSON(http.StatusCreated, response)
+}
+
+/**
+ * API 키 목록 조회 API
+ * JWT 검증 과정 후 로그인한 회원이 발급한 API 키 목록 조회 (폐기된 키 포함)
+ */
+func (ctrl *APIKeyController) GetAll(c *gin.Context) {
+	response := rest.NewApiResponse()
+
+	result, err := ctrl.usecase.GetAll(c.Request.Context(), middleware.GetPrincipal(c).Subject)
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	response.Succeed("", result)
+	c.JSON(http.StatusOK, response)
+}
+
+/**
+ * API 키 폐기 API
+ * JWT 검증 과정 후 로그인한 회원이 발급한 API 키 폐기
+ */
+func (ctrl *APIKeyController) Revoke(c *gin.Context) {
+	response := rest.NewApiResponse()
+	entry := auditEntry(c, audit.ActionTokenRevoke).Target(audit.TargetAPIKey, c.Param("id"))
+	defer auditResponse(c, ctrl.audit, entry, response)
+
+	err := ctrl.usecase.Revoke(c.Request.Context(), middleware.GetPrincipal(c).Subject, c.Param("id"))
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	response.Succeed("", nil)
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,59 @@
+package apitest
+
+import (
+	"context"
+	"sync"
+
+	"signupin-api/internal/pkg/notify"
+	"signupin-api/internal/pkg/outbox"
+)
+
+// Outbox 는 발송된 알림을 기록하는 notify.Notifier
+// 실제 발송 대신 테스트에서 알림 내용 (ex. "본인이 아닙니다" 링크) 을 확인하기 위함
+type Outbox struct {
+	mu       sync.Mutex
+	messages []notify.Message
+}
+
+var _ notify.Notifier = &Outbox{}
+
+func (o *Outbox) Notify(to *notify.Recipient, subject, body string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, address := range []string{to.Phone, to.Email} {
+		if len(address) > 0 {
+			o.messages = append(o.messages, notify.Message{To: address, Subject: subject, Body: body})
+		}
+	}
+}
+
+// Messages 는 address 로 발송된 알림 반환 (발송 순서)
+func (o *Outbox) Messages(address string) []notify.Message {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var result []notify.Message
+	for _, message := range o.messages {
+		if message.To == address {
+			result = append(result, message)
+		}
+	}
+	return result
+}
+
+// eventSink 는 전달받은 회원 이벤트를 기록하는 outbox.Sink
+type eventSink struct {
+	envelopes []*outbox.Envelope
+}
+
+var _ outbox.Sink = &eventSink{}
+
+func (s *eventSink) Name() string {
+	return "test"
+}
+
+func (s *eventSink) Publish(ctx context.Context, message *outbox.Message) error {
+	s.envelopes = append(s.envelopes, message.Envelope())
+	return nil
+}
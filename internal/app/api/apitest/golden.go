@@ -0,0 +1,97 @@
+package apitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+// go test ./internal/app/api/... -update 로 golden 파일 갱신
+var update = flag.Bool("update", false, "update golden files")
+
+// 실행할 때마다 바뀌는 값은 golden 파일에 "<키 이름>" 으로 기록
+var volatileKeys = map[string]bool{
+	"id":           true,
+	"accesstoken":  true,
+	"authnumber":   true,
+	"challenge_id": true,
+	"expires_at":   true,
+}
+
+var objectIDPattern = regexp.MustCompile(`\b[0-9a-f]{24}\b`)
+
+// AssertGolden 은 응답 상태 코드와 본문을 testdata/golden/<name>.json 과 비교
+func (r *Response) AssertGolden(t *testing.T, name string) {
+	t.Helper()
+
+	got := r.normalize(t)
+	path := filepath.Join("testdata", "golden", name+".json")
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("%s does not match (run with -update to accept)\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+// normalize 는 상태 코드와 본문을 정렬된 JSON 으로 만들고 실행마다 바뀌는 값을 가림
+func (r *Response) normalize(t *testing.T) []byte {
+	t.Helper()
+
+	var body interface{}
+	if err := json.Unmarshal(r.Body, &body); err != nil {
+		t.Fatalf("response is not JSON: %v\n%s", err, r.Body)
+	}
+
+	golden := map[string]interface{}{
+		"status": r.Status,
+		"body":   mask(body, ""),
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(golden); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func mask(value interface{}, key string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			v[k] = mask(child, k)
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = mask(child, key)
+		}
+		return v
+	case string:
+		if volatileKeys[key] && len(v) > 0 {
+			return "<" + key + ">"
+		}
+		return objectIDPattern.ReplaceAllString(v, "<objectid>")
+	default:
+		return value
+	}
+}
@@ -0,0 +1,373 @@
+// Package apitest 는 HTTP API 를 블랙박스로 확인하기 위한 테스트 도구
+//
+// New 는 api.CreateAPIApp 에 메모리 / SQLite 저장소를 주입하여 DB 없이 gin engine 을 만들고,
+// 회원 / 인증번호 fixture, 인증 요청 helper, rest.ApiResponse golden 파일 비교를 제공
+//
+//	h := apitest.New(t)
+//	userID := h.CreateUser(apitest.Kim)
+//	res := h.Do(http.MethodGet, "/api/v1/users/"+userID, nil, h.SignIn(apitest.Kim))
+//	res.AssertGolden(t, "get_user")
+package apitest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	api "signupin-api/internal/app/api"
+	"signupin-api/internal/app/config"
+	"signupin-api/internal/pkg/outbox"
+	"signupin-api/internal/pkg/sqlstore"
+	"signupin-api/internal/pkg/storetest"
+	"signupin-api/internal/pkg/user"
+	"signupin-api/internal/pkg/user/usertest"
+	"signupin-api/internal/pkg/webhook"
+
+	apikeymemory "signupin-api/internal/pkg/apikey/memory"
+	auditmemory "signupin-api/internal/pkg/audit/memory"
+	devicememory "signupin-api/internal/pkg/device/memory"
+	oidcmemory "signupin-api/internal/pkg/oidc/memory"
+	riskmemory "signupin-api/internal/pkg/risk/memory"
+	sessionsql "signupin-api/internal/pkg/session/sqlrepo"
+	socialmemory "signupin-api/internal/pkg/social/memory"
+	usermemory "signupin-api/internal/pkg/user/memory"
+	webhookmemory "signupin-api/internal/pkg/webhook/memory"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kkodecaffeine/go-common/rest"
+	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuthNumber 는 IssueAuthNumber 로 저장하는 인증번호
+const AuthNumber = "123456"
+
+// RemoteAddr 는 모든 요청의 접속 주소
+const RemoteAddr = "192.0.2.1:40000"
+
+// Issuer 는 NewConfig 의 OIDC_ISSUER
+const Issuer = "http://localhost/api"
+
+// AdminID 는 관리자 API 를 호출할 수 있는 회원 아이디 (NewConfig 의 ADMIN_SUBJECTS, CreateAdmin)
+const AdminID = "64b000000000000000000001"
+
+// Fixture 는 테스트 회원 정보
+type Fixture struct {
+	Email    string
+	Phone    string
+	Name     string
+	NickName string
+	Password string
+}
+
+// 기본 테스트 회원
+var (
+	Kim   = Fixture{Email: "kim@example.com", Phone: "01012345678", Name: "김회원", NickName: "kim", Password: usertest.Password}
+	Lee   = Fixture{Email: "lee@example.com", Phone: "01087654321", Name: "이회원", NickName: "lee", Password: usertest.Password}
+	Admin = Fixture{Email: "admin@example.com", Phone: "01011112222", Name: "관리자", NickName: "admin", Password: usertest.Password}
+)
+
+// SignUpBody 는 회원 가입 API 요청 본문
+func (f Fixture) SignUpBody(authnumber string) gin.H {
+	return gin.H{
+		"authnumber": authnumber,
+		"email":      f.Email,
+		"nickname":   f.NickName,
+		"name":       f.Name,
+		"password":   f.Password,
+		"phone":      f.Phone,
+	}
+}
+
+// Harness 는 주입한 저장소로 만든 gin engine 과 저장소 / 알림 기록
+type Harness struct {
+	t *testing.T
+
+	Engine *gin.Engine
+	Deps   *api.Dependencies
+	Outbox *Outbox
+}
+
+// NewConfig 는 테스트용 설정
+func NewConfig(t *testing.T) *config.Config {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.Default()
+	cfg.Server.PublicBaseURL = "http://localhost/api"
+	cfg.Mongo.URL = "mongodb://localhost:27017"
+	cfg.CORS.AllowOrigins = []string{"http://localhost:3000"}
+	cfg.JWT.Secret = "apitest-secret"
+	cfg.JWT.OIDCIssuer = Issuer
+	cfg.JWT.OIDCSigningKey = signingKey()
+	cfg.Admin.Subjects = []string{AdminID}
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+// signingKey 는 테스트용 OIDC 서명 키 경로 (testdata/oidc_signing_key.pem, 테스트 외에는 사용하지 않음)
+func signingKey() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "testdata", "oidc_signing_key.pem")
+}
+
+// NewDependencies 는 DB 없이 API 를 구성할 수 있는 비어있는 저장소
+// 회원 (이벤트 포함), 웹훅은 메모리, 세션은 임시 SQLite, 나머지는 메모리 fake, 알림은 Outbox 사용
+func NewDependencies(t *testing.T) *api.Dependencies {
+	t.Helper()
+
+	users, events := usermemory.NewWithOutbox()
+	return &api.Dependencies{
+		Users:      users,
+		Outbox:     events,
+		Sessions:   sessionsql.New(storetest.SQL(t, sqlstore.SQLite)),
+		Devices:    devicememory.New(),
+		Risks:      riskmemory.New(),
+		Clients:    oidcmemory.New(),
+		Identities: socialmemory.New(),
+		APIKeys:    apikeymemory.New(),
+		Audit:      auditmemory.New(),
+		Webhooks:   webhookmemory.New(),
+		Notifier:   &Outbox{},
+	}
+}
+
+// New 는 NewConfig, NewDependencies 로 API 를 구성
+func New(t *testing.T) *Harness {
+	t.Helper()
+
+	return NewWithConfig(t, NewConfig(t))
+}
+
+// NewMemoryBackend 는 저장소를 주입하지 않고 STORAGE_BACKEND=memory, MONGO_URL 없이 API 를 구성
+// 기본 저장소 생성을 확인하기 위함이므로 Deps / Outbox 는 비어 있음 (Do 와 SignIn 만 사용 가능)
+func NewMemoryBackend(t *testing.T) *Harness {
+	t.Helper()
+
+	cfg := NewConfig(t)
+	cfg.Storage.Backend = config.BackendMemory
+	cfg.Mongo.URL = ""
+
+	engine, app, err := api.CreateAPIApp(cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { app.Clean() })
+
+	return &Harness{t: t, Engine: engine}
+}
+
+// NewWithConfig 는 설정을 바꿔서 API 를 구성 (ex. 요청 수 제한)
+func NewWithConfig(t *testing.T, cfg *config.Config) *Harness {
+	t.Helper()
+
+	deps := NewDependencies(t)
+
+	engine, app, err := api.CreateAPIApp(cfg, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { app.Clean() })
+
+	return &Harness{t: t, Engine: engine, Deps: deps, Outbox: deps.Notifier.(*Outbox)}
+}
+
+// Header 는 요청 헤더
+type Header map[string]string
+
+// Bearer 는 Authorization 헤더
+func Bearer(token string) Header {
+	return Header{"Authorization": "Bearer " + token}
+}
+
+// Response 는 API 응답
+type Response struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// Envelope 는 응답 본문을 rest.ApiResponse 로 읽음 (data 는 map / slice)
+func (r *Response) Envelope(t *testing.T) *rest.ApiResponse {
+	t.Helper()
+
+	var envelope rest.ApiResponse
+	if err := json.Unmarshal(r.Body, &envelope); err != nil {
+		t.Fatalf("response is not an ApiResponse: %v\n%s", err, r.Body)
+	}
+	return &envelope
+}
+
+// Data 는 응답의 data 를 target 으로 읽음
+func (r *Response) Data(t *testing.T, target interface{}) {
+	t.Helper()
+
+	envelope := struct {
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(r.Body, &envelope); err != nil {
+		t.Fatalf("response is not an ApiResponse: %v\n%s", err, r.Body)
+	}
+	if err := json.Unmarshal(envelope.Data, target); err != nil {
+		t.Fatalf("unexpected data: %v\n%s", err, r.Body)
+	}
+}
+
+// AssertStatus 는 응답 상태 코드 확인
+func (r *Response) AssertStatus(t *testing.T, want int) {
+	t.Helper()
+
+	if r.Status != want {
+		t.Fatalf("got status %d, want %d\n%s", r.Status, want, r.Body)
+	}
+}
+
+// Do 는 body 를 JSON 으로 보내고 응답 반환
+// body 가 string 이면 그대로 전송 (잘못된 JSON 요청 확인용)
+func (h *Harness) Do(method, path string, body interface{}, headers ...Header) *Response {
+	h.t.Helper()
+
+	var reader *bytes.Reader
+	switch b := body.(type) {
+	case nil:
+		reader = bytes.NewReader(nil)
+	case string:
+		reader = bytes.NewReader([]byte(b))
+	default:
+		raw, err := json.Marshal(b)
+		if err != nil {
+			h.t.Fatal(err)
+		}
+		reader = bytes.NewReader(raw)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.RemoteAddr = RemoteAddr
+	req.Header.Set("User-Agent", "apitest")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for _, header := range headers {
+		for key, value := range header {
+			req.Header.Set(key, value)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	h.Engine.ServeHTTP(rec, req)
+
+	return &Response{Status: rec.Code, Header: rec.Header(), Body: rec.Body.Bytes()}
+}
+
+// IssueAuthNumber 는 인증번호를 AuthNumber 로 저장
+func (h *Harness) IssueAuthNumber() string {
+	h.t.Helper()
+
+	if _, err := h.Deps.Users.UpsertAuthNumber(context.Background(), &user.AuthNumber{AuthNumber: AuthNumber}); err != nil {
+		h.t.Fatal(err)
+	}
+	return AuthNumber
+}
+
+// CurrentAuthNumber 는 저장된 인증번호 (로그인 성공 시 갱신됨)
+func (h *Harness) CurrentAuthNumber() string {
+	h.t.Helper()
+
+	authnumber, err := h.Deps.Users.GetAuthNumber(context.Background())
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	return authnumber
+}
+
+// CreateUser 는 저장소에 회원을 직접 저장하고 회원 아이디 반환
+func (h *Harness) CreateUser(f Fixture) string {
+	h.t.Helper()
+
+	insertedID, err := h.Deps.Users.SaveOne(context.Background(), &user.User{
+		Email:    f.Email,
+		Name:     f.Name,
+		NickName: f.NickName,
+		Password: f.Password,
+		Phone:    f.Phone,
+	})
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	return insertedID
+}
+
+// CreateAdmin 은 Admin 회원을 AdminID 로 저장 (관리자 API 는 SignIn(Admin) 의 토큰으로 호출)
+func (h *Harness) CreateAdmin() string {
+	h.t.Helper()
+
+	objectID, _ := primitive.ObjectIDFromHex(AdminID)
+	model := &user.User{Email: Admin.Email, Name: Admin.Name, NickName: Admin.NickName, Password: Admin.Password, Phone: Admin.Phone}
+	model.ID = objectID
+
+	if _, err := h.Deps.Users.SaveOne(context.Background(), model); err != nil {
+		h.t.Fatal(err)
+	}
+	return AdminID
+}
+
+// RequirePasswordReset 은 회원이 다음 로그인부터 비밀번호를 재설정해야 하도록 표시
+func (h *Harness) RequirePasswordReset(userID string) {
+	h.t.Helper()
+
+	objectID, _ := utils.MapToObjectID(userID)
+	if err := h.Deps.Users.RequirePasswordReset(context.Background(), objectID); err != nil {
+		h.t.Fatal(err)
+	}
+}
+
+// PublishEvents 는 아직 전달하지 않은 회원 이벤트를 Relay 로 한번 전달하고 전달한 이벤트 반환
+func (h *Harness) PublishEvents() []*outbox.Envelope {
+	h.t.Helper()
+
+	sink := &eventSink{}
+	if _, err := outbox.NewRelay(h.Deps.Outbox, []outbox.Sink{sink}, outbox.Options{}).Flush(context.Background()); err != nil {
+		h.t.Fatal(err)
+	}
+	return sink.envelopes
+}
+
+// DeliverWebhooks 는 아직 전달하지 않은 회원 이벤트를 웹훅 전달 대기열에 넣고, 전달할 차례인 웹훅을 한번씩 전달
+// 전달에 성공한 웹훅 수 반환
+func (h *Harness) DeliverWebhooks() int {
+	h.t.Helper()
+
+	ctx := context.Background()
+	if _, err := outbox.NewRelay(h.Deps.Outbox, []outbox.Sink{webhook.NewSink(h.Deps.Webhooks)}, outbox.Options{}).Flush(ctx); err != nil {
+		h.t.Fatal(err)
+	}
+	delivered, err := webhook.NewDispatcher(h.Deps.Webhooks, nil, webhook.Options{Timeout: time.Second, MaxAttempts: 1}).Flush(ctx)
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	return delivered
+}
+
+// SignIn 은 회원 로그인 API 로 토큰을 발급받아 Authorization 헤더 반환
+func (h *Harness) SignIn(f Fixture, headers ...Header) Header {
+	h.t.Helper()
+
+	res := h.Do(http.MethodPost, "/api/v1/auth/sign-in", gin.H{"email": f.Email, "password": f.Password}, headers...)
+	res.AssertStatus(h.t, http.StatusOK)
+
+	var signedIn struct {
+		AccessToken string `json:"accesstoken"`
+	}
+	res.Data(h.t, &signedIn)
+
+	return Bearer(signedIn.AccessToken)
+}
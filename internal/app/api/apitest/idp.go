@@ -0,0 +1,163 @@
+package apitest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"signupin-api/internal/app/config"
+)
+
+// IdPClientID 는 NewIdP 가 등록하는 mock 인증 제공자의 클라이언트 아이디
+const IdPClientID = "apitest"
+
+// IdP 는 소셜 로그인 (mock 인증 제공자) 을 확인하기 위한 OAuth2 / OIDC 인증 제공자 (cmd/mockidp 와 같은 방식)
+// authorization endpoint 호출 즉시 인가 코드를 발급하고, token endpoint 에서 서명하지 않은 ID 토큰 발급
+type IdP struct {
+	Server *httptest.Server
+
+	// 로그인한 계정 (ID 토큰 claims)
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+
+	mu     sync.Mutex
+	grants map[string]string // 인가 코드 → nonce
+	issued int
+}
+
+// NewIdP 는 IdP 를 실행하고 cfg 에 mock 인증 제공자로 등록 (NewWithConfig 전에 호출)
+func NewIdP(t *testing.T, cfg *config.Config) *IdP {
+	t.Helper()
+
+	idp := &IdP{Subject: "mock-1", Email: "mock@example.com", EmailVerified: true, Name: "mock user", grants: map[string]string{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/authorize", idp.authorize)
+	mux.HandleFunc("/token", idp.token)
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Server.Close)
+
+	cfg.Social.Providers = append(cfg.Social.Providers, "mock")
+	cfg.Social.Settings["mock"] = &config.SocialProvider{
+		ClientID:           IdPClientID,
+		ClientSecret:       "secret",
+		AuthURL:            idp.Server.URL + "/authorize",
+		TokenURL:           idp.Server.URL + "/token",
+		RedirectURL:        Issuer + "/v1/auth/social/mock/callback",
+		Issuer:             idp.Server.URL,
+		SubjectClaim:       "sub",
+		EmailClaim:         "email",
+		EmailVerifiedClaim: "email_verified",
+		NameClaim:          "name",
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	return idp
+}
+
+func (idp *IdP) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || len(redirectURI.String()) == 0 {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	idp.mu.Lock()
+	idp.issued++
+	code := "code-" + strconv.Itoa(idp.issued)
+	idp.grants[code] = query.Get("nonce")
+	idp.mu.Unlock()
+
+	values := redirectURI.Query()
+	values.Set("code", code)
+	values.Set("state", query.Get("state"))
+	redirectURI.RawQuery = values.Encode()
+
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (idp *IdP) token(w http.ResponseWriter, r *http.Request) {
+	idp.mu.Lock()
+	nonce, ok := idp.grants[r.PostFormValue("code")]
+	delete(idp.grants, r.PostFormValue("code"))
+	claims := map[string]interface{}{
+		"iss":            idp.Server.URL,
+		"sub":            idp.Subject,
+		"aud":            IdPClientID,
+		"email":          idp.Email,
+		"email_verified": idp.EmailVerified,
+		"name":           idp.Name,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	}
+	if len(nonce) > 0 {
+		claims["nonce"] = nonce
+	}
+	idp.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	header, _ := json.Marshal(map[string]string{"alg": "none", "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token": "mock-access-token",
+		"token_type":   "Bearer",
+		"id_token":     base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload) + ".",
+	})
+}
+
+// SocialSignIn 은 mock 인증 제공자로 소셜 로그인 (계정 연결 요청이면 headers 에 로그인한 회원의 Authorization) 후 callback API 응답 반환
+func (h *Harness) SocialSignIn(headers ...Header) *Response {
+	h.t.Helper()
+
+	var authorizationURL string
+	if len(headers) > 0 {
+		res := h.Do(http.MethodPost, "/api/v1/users/me/identities/mock", nil, headers...)
+		res.AssertStatus(h.t, http.StatusOK)
+
+		var linked struct {
+			AuthorizationURL string `json:"authorization_url"`
+		}
+		res.Data(h.t, &linked)
+		authorizationURL = linked.AuthorizationURL
+	} else {
+		res := h.Do(http.MethodGet, "/api/v1/auth/social/mock", nil)
+		res.AssertStatus(h.t, http.StatusFound)
+		authorizationURL = res.Header.Get("Location")
+	}
+
+	return h.SocialCallback(authorizationURL)
+}
+
+// SocialCallback 은 인증 제공자의 로그인 화면 주소 (authorizationURL) 를 열어 받은 인가 코드로 callback API 호출 (headers 는 callback 요청 헤더)
+func (h *Harness) SocialCallback(authorizationURL string, headers ...Header) *Response {
+	h.t.Helper()
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	res, err := client.Get(authorizationURL)
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	res.Body.Close()
+
+	callback := res.Header.Get("Location")
+	if !strings.HasPrefix(callback, Issuer) {
+		h.t.Fatalf("unexpected callback: %s", callback)
+	}
+	return h.Do(http.MethodGet, "/api"+strings.TrimPrefix(callback, Issuer), nil, headers...)
+}
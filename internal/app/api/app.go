@@ -1,87 +1,509 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
-	"os"
+	"sync"
 	"time"
 
+	"signupin-api/internal/app/api/middleware"
+	"signupin-api/internal/app/config"
+	"signupin-api/internal/pkg/apikey"
+	"signupin-api/internal/pkg/audit"
+	"signupin-api/internal/pkg/device"
+	"signupin-api/internal/pkg/health"
+	"signupin-api/internal/pkg/metrics"
+	"signupin-api/internal/pkg/notify"
+	"signupin-api/internal/pkg/oidc"
+	"signupin-api/internal/pkg/outbox"
+	"signupin-api/internal/pkg/risk"
+	"signupin-api/internal/pkg/session"
+	"signupin-api/internal/pkg/social"
+	"signupin-api/internal/pkg/sqlstore"
 	"signupin-api/internal/pkg/user"
+	"signupin-api/internal/pkg/webhook"
 
+	apikeymemory "signupin-api/internal/pkg/apikey/memory"
+	apikeyrepo "signupin-api/internal/pkg/apikey/persistence"
+	auditmemory "signupin-api/internal/pkg/audit/memory"
+	auditrepo "signupin-api/internal/pkg/audit/persistence"
+	devicememory "signupin-api/internal/pkg/device/memory"
+	devicerepo "signupin-api/internal/pkg/device/persistence"
+	oidcmemory "signupin-api/internal/pkg/oidc/memory"
+	oidcrepo "signupin-api/internal/pkg/oidc/persistence"
+	outboxrepo "signupin-api/internal/pkg/outbox/persistence"
+	outboxsql "signupin-api/internal/pkg/outbox/sqlrepo"
+	riskmemory "signupin-api/internal/pkg/risk/memory"
+	riskrepo "signupin-api/internal/pkg/risk/persistence"
+	sessionmemory "signupin-api/internal/pkg/session/memory"
+	sessionrepo "signupin-api/internal/pkg/session/persistence"
+	sessionsql "signupin-api/internal/pkg/session/sqlrepo"
+	socialmemory "signupin-api/internal/pkg/social/memory"
+	socialrepo "signupin-api/internal/pkg/social/persistence"
+	usermemory "signupin-api/internal/pkg/user/memory"
 	userrepo "signupin-api/internal/pkg/user/persistence"
+	usersql "signupin-api/internal/pkg/user/sqlrepo"
+	webhookmemory "signupin-api/internal/pkg/webhook/memory"
+	webhookrepo "signupin-api/internal/pkg/webhook/persistence"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
-	"github.com/joho/godotenv"
 	"github.com/kamva/mgm/v3"
 
 	kkva "github.com/kkodecaffeine/go-common/validator"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"golang.org/x/exp/slog"
+)
+
+const (
+	disconnectTimeout = 5 * time.Second // 종료 시 DB 연결 해제 대기 시간
+	readyTimeout      = 2 * time.Second // /readyz 의 의존성별 확인 시간
 )
 
 type App interface {
-	Init()
-	RegisterRoute(driver *gin.Engine)
+	Init() error
+	RegisterRoute(driver *gin.Engine) error
+	Run(ctx context.Context) // 백그라운드 작업 (이벤트 전달) 실행, ctx 가 취소될 때까지 대기
+	Drain()                  // 종료 시작, 이후 /readyz 는 503 응답
 	Clean() error
 }
 
+// Dependencies 는 CreateAPIApp 에 주입할 저장소 / 알림 발송
+// nil 인 항목은 STORAGE_BACKEND 설정에 따라 생성 (memory 이면 DB 없이 실행)
+// Users 를 주입하고 Outbox 를 주입하지 않으면 이벤트를 전달하지 않음
+type Dependencies struct {
+	Users      user.Repository
+	Outbox     outbox.Repository // Users 와 같은 DB 에 저장한 회원 이벤트
+	Sessions   session.Repository
+	Devices    device.Repository
+	Risks      risk.Repository
+	Clients    oidc.Repository
+	Identities social.Repository
+	APIKeys    apikey.Repository
+	Audit      audit.Repository
+	Webhooks   webhook.Repository
+	Notifier   notify.Notifier
+}
+
 type apiApp struct {
+	cfg    *config.Config
 	client *mongo.Client
+	db     *sqlstore.DB // STORAGE_BACKEND 가 postgres, sqlite 인 경우에만 사용
+	deps   Dependencies
+	sms    notify.Sender // Notifier 를 주입하지 않은 경우에만 사용 (/readyz)
+	health *health.Checker
+	relay  *outbox.Relay // Outbox 가 없으면 nil
+
+	dispatcher *webhook.Dispatcher // OUTBOX_SINKS 에 webhook 이 없으면 nil
 }
 
-func (app *apiApp) Init() {
-	err := godotenv.Load("../config/.env")
-	if err != nil {
-		log.Fatal("Error loading .env file")
+// Init 은 MongoDB 와 SQL DB (STORAGE_BACKEND 가 postgres, sqlite 인 경우) 에 연결
+// postgres, sqlite 는 회원 / 세션만 SQL DB 에 두는 혼합 구성이므로 MongoDB 에도 항상 연결
+// STORAGE_BACKEND 가 memory 이면 모든 저장소를 메모리에 두므로 연결하지 않음
+func (app *apiApp) Init() error {
+	var err error
+
+	if app.cfg.Storage.Backend == config.BackendMemory {
+		return nil
+	}
+
+	// 쿼리 deadline 은 요청 context 로 전달 (middleware.Timeout)
+	if err := mgm.SetDefaultConfig(nil, app.cfg.Mongo.Database, options.Client().ApplyURI(app.cfg.Mongo.URL)); err != nil {
+		return fmt.Errorf("connecting to MongoDB: %w", err)
 	}
+	_, app.client, _, _ = mgm.DefaultConfigs()
 
-	_ = mgm.SetDefaultConfig(&mgm.Config{CtxTimeout: 12 * time.Second}, "kkodecaffeine", options.Client().ApplyURI(os.Getenv("MONGO_URL")))
+	if app.cfg.Storage.SQL() {
+		backend := app.cfg.Storage.Backend
+		slog.Info("users and sessions are stored in SQL, the other stores in MongoDB",
+			"storage_backend", backend,
+			"mongo_stores", config.MongoStores,
+		)
+
+		app.db, err = sqlstore.Open(backend, app.cfg.Storage.DatabaseURL)
+		if err != nil {
+			return fmt.Errorf("opening database: %w", err)
+		}
+
+		pending, err := app.db.Pending(context.Background())
+		if err != nil {
+			return fmt.Errorf("reading migrations: %w", err)
+		}
+		if pending > 0 {
+			return fmt.Errorf("%d pending migration(s): run `go run ./cmd/migrate up` first", pending)
+		}
+	}
+	return nil
 }
 
-func (app *apiApp) RegisterRoute(driver *gin.Engine) {
+func (app *apiApp) RegisterRoute(driver *gin.Engine) error {
 	v := validator.New()
+	if err := registerValidator(v); err != nil {
+		return fmt.Errorf("registering validation messages: %w", err)
+	}
+
+	keys, err := oidc.LoadKeySet(app.cfg.JWT.OIDCSigningKey)
+	if err != nil {
+		return fmt.Errorf("loading OIDC signing key: %w", err)
+	}
+
+	settings := map[string]social.Provider{}
+	for name, p := range app.cfg.Social.Settings {
+		settings[name] = social.Provider{
+			ClientID:           p.ClientID,
+			ClientSecret:       p.ClientSecret,
+			AuthURL:            p.AuthURL,
+			TokenURL:           p.TokenURL,
+			UserInfoURL:        p.UserInfoURL,
+			RedirectURL:        p.RedirectURL,
+			Issuer:             p.Issuer,
+			Scopes:             p.Scopes,
+			SubjectClaim:       p.SubjectClaim,
+			EmailClaim:         p.EmailClaim,
+			NameClaim:          p.NameClaim,
+			EmailVerifiedClaim: p.EmailVerifiedClaim,
+		}
+	}
+	providers, err := social.LoadProviders(app.cfg.Social.Providers, settings)
+	if err != nil {
+		return fmt.Errorf("loading social providers: %w", err)
+	}
+
+	engine, err := risk.LoadEngine(risk.Settings{
+		Blocklist:        app.cfg.Risk.Blocklist,
+		BlocklistFile:    app.cfg.Risk.BlocklistFile,
+		GeoDB:            app.cfg.Risk.GeoDB,
+		AllowedCountries: app.cfg.Risk.AllowedCountries,
+		UnusualHours:     app.cfg.Risk.UnusualHours,
+		Timezone:         app.cfg.Risk.Timezone,
+		StepUpScore:      app.cfg.Risk.StepUpScore,
+		DenyScore:        app.cfg.Risk.DenyScore,
+	})
+	if err != nil {
+		return fmt.Errorf("loading risk rules: %w", err)
+	}
 
-	user_uc := user.NewUsecase(userrepo.New(app.client))
-	NewController(driver, v, user_uc)
+	if err := app.setDefaults(); err != nil {
+		return err
+	}
+	app.registerChecks(keys)
+
+	// 저장소 호출 시간과 실패를 지표 (/metrics) 로 기록
+	users := user.Instrument(app.deps.Users)
+	sessions := session.Instrument(app.deps.Sessions)
+	devices := device.Instrument(app.deps.Devices)
+	risks := risk.Instrument(app.deps.Risks)
+	audits := audit.Instrument(app.deps.Audit)
+	webhooks := webhook.Instrument(app.deps.Webhooks)
+	app.setRelay(webhooks)
+
+	user_uc := user.Trace(user.NewUsecase(users, app.cfg.JWT.Secret))
+	oidc_uc := oidc.NewUsecase(app.deps.Clients, user_uc, keys, app.cfg.JWT.OIDCIssuer)
+	social_uc := social.NewUsecase(app.deps.Identities, user_uc, providers, nil)
+	apikey_uc := apikey.NewUsecase(app.deps.APIKeys)
+	session_uc := session.NewUsecase(sessions, app.cfg.JWT.SessionTTL, app.cfg.JWT.Secret)
+	device_uc := device.NewUsecase(devices, user_uc, session_uc, app.deps.Notifier, app.cfg.Server.PublicBaseURL)
+	risk_uc := risk.NewUsecase(risks, engine, app.deps.Notifier)
+	audit_uc := audit.NewUsecase(audits)
+	webhook_uc := webhook.NewUsecase(webhooks)
+
+	// 회원 JWT (세션), 클라이언트 토큰 (client_credentials), API 키 모두 허용
+	authenticate := middleware.Authenticate(oidc_uc, apikey_uc, session_uc, user_uc)
+
+	NewController(driver, v, user_uc, session_uc, device_uc, risk_uc, audit_uc, apikey_uc, social_uc, authenticate)
+	NewOIDCController(driver, v, oidc_uc, user_uc, risk_uc, device_uc, audit_uc, authenticate, app.cfg.Admin.Subjects)
+	NewSocialController(driver, v, social_uc, session_uc, risk_uc, device_uc, audit_uc, authenticate)
+	NewAPIKeyController(driver, v, apikey_uc, audit_uc, authenticate)
+	NewSessionController(driver, v, session_uc, audit_uc, authenticate)
+	NewAuditController(driver, audit_uc, authenticate, app.cfg.Admin.Subjects)
+	NewWebhookController(driver, v, webhook_uc, audit_uc, authenticate, app.cfg.Admin.Subjects)
+
+	// 회원 API (NewController) 의 OpenAPI 문서와 Swagger UI
+	if _, err := NewOpenAPIController(driver, userRoutes); err != nil {
+		return fmt.Errorf("generating OpenAPI document: %w", err)
+	}
+	return nil
 }
 
-func (app *apiApp) Clean() error {
+// setDefaults 는 주입되지 않은 의존성을 STORAGE_BACKEND / 환경 변수 설정에 따라 생성
+func (app *apiApp) setDefaults() error {
+	if app.cfg.Storage.Backend == config.BackendMemory {
+		app.setMemoryDefaults()
+	}
+	if app.deps.Users == nil {
+		users, events, err := app.userRepository()
+		if err != nil {
+			return err
+		}
+		app.deps.Users, app.deps.Outbox = users, events
+	}
+	if app.deps.Sessions == nil {
+		app.deps.Sessions = app.sessionRepository()
+	}
+	if app.deps.Devices == nil {
+		app.deps.Devices = devicerepo.New(app.client)
+	}
+	if app.deps.Risks == nil {
+		app.deps.Risks = riskrepo.New(app.client)
+	}
+	if app.deps.Clients == nil {
+		app.deps.Clients = oidcrepo.New(app.client)
+	}
+	if app.deps.Identities == nil {
+		app.deps.Identities = socialrepo.New(app.client)
+	}
+	if app.deps.APIKeys == nil {
+		app.deps.APIKeys = apikeyrepo.New(app.client)
+	}
+	if app.deps.Audit == nil {
+		// 순번 unique index 가 없으면 여러 인스턴스가 같은 순번으로 기록할 수 있음
+		ctx, cancel := context.WithTimeout(context.Background(), app.cfg.Server.RequestTimeout)
+		defer cancel()
+		if err := auditrepo.EnsureIndexes(ctx); err != nil {
+			slog.Warn("creating audit log indexes failed", "error", err)
+		}
+		app.deps.Audit = auditrepo.New(app.client)
+	}
+	if app.deps.Webhooks == nil {
+		// (endpoint_id, event_id) unique index 가 없으면 Relay 가 다시 전달한 이벤트를 두번 보낼 수 있음
+		ctx, cancel := context.WithTimeout(context.Background(), app.cfg.Server.RequestTimeout)
+		defer cancel()
+		if err := webhookrepo.EnsureIndexes(ctx); err != nil {
+			slog.Warn("creating webhook indexes failed", "error", err)
+		}
+		app.deps.Webhooks = webhookrepo.New(app.client)
+	}
+	if app.deps.Notifier == nil {
+		sms, email := app.cfg.SMS, app.cfg.Email
+		app.sms = notify.NewSMSSender(sms.APIURL, sms.APIKey)
+		app.deps.Notifier = notify.NewNotifier(
+			app.sms,
+			notify.NewEmailSender(email.SMTPAddr, email.From, email.Username, email.Password),
+		)
+	}
 	return nil
 }
 
-// CreateAPIApp returns new core.App implementation
-func CreateAPIApp() {
-	router := gin.Default()
+// setMemoryDefaults 는 STORAGE_BACKEND 가 memory 인 경우 회원 외 저장소도 메모리에 둠 (MongoDB 없이 실행)
+func (app *apiApp) setMemoryDefaults() {
+	slog.Warn("all data is kept in memory and lost on restart", "storage_backend", app.cfg.Storage.Backend)
+
+	if app.deps.Sessions == nil {
+		app.deps.Sessions = sessionmemory.New()
+	}
+	if app.deps.Devices == nil {
+		app.deps.Devices = devicememory.New()
+	}
+	if app.deps.Risks == nil {
+		app.deps.Risks = riskmemory.New()
+	}
+	if app.deps.Clients == nil {
+		app.deps.Clients = oidcmemory.New()
+	}
+	if app.deps.Identities == nil {
+		app.deps.Identities = socialmemory.New()
+	}
+	if app.deps.APIKeys == nil {
+		app.deps.APIKeys = apikeymemory.New()
+	}
+	if app.deps.Audit == nil {
+		app.deps.Audit = auditmemory.New()
+	}
+	if app.deps.Webhooks == nil {
+		app.deps.Webhooks = webhookmemory.New()
+	}
+}
+
+// registerChecks 는 /readyz 에서 확인할 의존성 등록 (주입한 저장소는 확인하지 않음)
+func (app *apiApp) registerChecks(keys *oidc.KeySet) {
+	if app.client != nil {
+		app.health.Register("mongo", func(ctx context.Context) error {
+			return app.client.Ping(ctx, readpref.Primary())
+		})
+	}
+	if app.db != nil {
+		app.health.Register("database", app.db.PingContext)
+	}
+	if app.sms != nil {
+		app.health.Register("sms", func(ctx context.Context) error {
+			return notify.Ready(ctx, app.sms)
+		})
+	}
+	app.health.Register("keys", func(ctx context.Context) error {
+		if len(app.cfg.JWT.Secret) == 0 {
+			return errors.New("API_SECRET is not set")
+		}
+		return keys.Ready()
+	})
+}
+
+// setRelay 는 OUTBOX_SINKS 로 회원 이벤트를 전달하는 Relay 와 웹훅을 전달하는 Dispatcher 생성
+func (app *apiApp) setRelay(webhooks webhook.Repository) {
+	if app.deps.Outbox == nil {
+		return
+	}
+
+	var sinks []outbox.Sink
+	for _, name := range app.cfg.Outbox.Sinks {
+		switch name {
+		case config.SinkLog:
+			sinks = append(sinks, outbox.LogSink{})
+		case config.SinkWebhook:
+			sinks = append(sinks, webhook.NewSink(webhooks))
+			app.dispatcher = webhook.NewDispatcher(webhooks, nil, webhook.Options{
+				Interval:      app.cfg.Outbox.Interval,
+				BatchSize:     int64(app.cfg.Outbox.BatchSize),
+				Timeout:       app.cfg.Webhook.Timeout,
+				MaxAttempts:   app.cfg.Webhook.MaxAttempts,
+				RetryInterval: app.cfg.Webhook.RetryInterval,
+				MaxBackoff:    app.cfg.Webhook.MaxBackoff,
+			})
+		}
+	}
+
+	app.relay = outbox.NewRelay(outbox.Instrument(app.deps.Outbox), sinks, outbox.Options{
+		Interval:  app.cfg.Outbox.Interval,
+		BatchSize: int64(app.cfg.Outbox.BatchSize),
+	})
+}
+
+// Run 은 ctx 가 취소될 때까지 outbox 의 회원 이벤트와 웹훅을 전달 (Outbox 가 없으면 바로 반환)
+func (app *apiApp) Run(ctx context.Context) {
+	if app.relay == nil {
+		return
+	}
+
+	var wg sync.WaitGroup
+	if app.dispatcher != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			app.dispatcher.Run(ctx)
+		}()
+	}
+	app.relay.Run(ctx)
+	wg.Wait()
+}
+
+// Drain 은 종료 시작을 기록, 진행 중인 요청과 새 요청은 계속 처리하지만 /readyz 는 503 응답
+func (app *apiApp) Drain() {
+	app.health.Drain()
+}
+
+// userRepository 는 STORAGE_BACKEND 설정에 따라 회원 저장소 선택
+// mongo: 기본값, memory: DB 없이 실행 (setMemoryDefaults), postgres / sqlite: DATABASE_URL 의 SQL DB 사용
+// 회원 이벤트는 회원과 같은 트랜잭션에 저장하므로 outbox 저장소도 같은 DB 사용
+func (app *apiApp) userRepository() (user.Repository, outbox.Repository, error) {
+	switch backend := app.cfg.Storage.Backend; backend {
+	case config.BackendMongo:
+		ctx, cancel := context.WithTimeout(context.Background(), app.cfg.Server.RequestTimeout)
+		defer cancel()
+		if err := outboxrepo.EnsureIndexes(ctx); err != nil {
+			slog.Warn("creating outbox indexes failed", "error", err)
+		}
+		return userrepo.New(app.client), outboxrepo.New(app.client), nil
+	case config.BackendMemory:
+		users, events := usermemory.NewWithOutbox()
+		return users, events, nil
+	case config.BackendPostgres, config.BackendSQLite:
+		return usersql.New(app.db), outboxsql.New(app.db), nil
+	default:
+		return nil, nil, fmt.Errorf("unknown STORAGE_BACKEND %q (expected mongo, memory, postgres or sqlite)", backend)
+	}
+}
+
+// sessionRepository 는 STORAGE_BACKEND 가 postgres, sqlite 인 경우 SQL DB, 나머지는 MongoDB 사용
+func (app *apiApp) sessionRepository() session.Repository {
+	if app.db != nil {
+		return sessionsql.New(app.db)
+	}
+	return sessionrepo.New(app.client)
+}
+
+// Clean 은 SQL DB 와 MongoDB 연결을 종료
+func (app *apiApp) Clean() error {
+	var result error
+
+	if app.db != nil {
+		if err := app.db.Close(); err != nil {
+			result = err
+		}
+	}
+
+	if app.client != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), disconnectTimeout)
+		defer cancel()
+
+		if err := app.client.Disconnect(ctx); err != nil && result == nil {
+			result = err
+		}
+	}
+
+	return result
+}
+
+// CreateAPIApp 은 cfg 로 라우트를 등록한 gin engine 과 App 반환
+// deps 가 nil 이면 DB 에 연결, 주입한 경우 DB 연결 없이 주입한 저장소만 사용
+// 연결 / 설정 파일 읽기에 실패하면 이미 연결한 DB 를 닫고 오류 반환
+func CreateAPIApp(cfg *config.Config, deps *Dependencies) (*gin.Engine, App, error) {
+	// 요청 로그는 middleware.Logger 가 JSON 으로 기록 (gin.Default 의 Logger 는 사용하지 않음)
+	router := gin.New()
+	router.Use(middleware.Recovery())
+
+	app := &apiApp{cfg: cfg, health: health.NewChecker(readyTimeout)}
+	NewHealthController(router, app.health)
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
 	router.RouterGroup = *router.Group("/api")
 
-	app := &apiApp{}
-	app.Init()
+	if deps == nil {
+		if err := app.Init(); err != nil {
+			app.Clean()
+			return nil, nil, err
+		}
+	} else {
+		app.deps = *deps
+	}
 
-	router.Use(gin.Recovery())
+	router.Use(middleware.Metrics())
 
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("customEmail", kkva.RegexEmail())
 		v.RegisterValidation("customPhone", kkva.RegexPhone())
+		if err := registerValidator(v); err != nil {
+			app.Clean()
+			return nil, nil, fmt.Errorf("registering validation messages: %w", err)
+		}
 	}
 
-	frontserver := os.Getenv("FRONT_SERVER_HOST")
-
 	// Enable CORS policy
 	router.Use(cors.New(
 		cors.Config{
-			AllowOrigins:     []string{frontserver},
-			AllowMethods:     []string{"GET, POST"},
-			AllowHeaders:     []string{"Content-Type, Access-Control-Allow-Headers, Authorization, X-Requested-With"},
-			ExposeHeaders:    []string{"Content-Length"},
+			AllowOrigins:     cfg.CORS.AllowOrigins,
+			AllowMethods:     []string{"GET, POST, PUT, DELETE"},
+			AllowHeaders:     []string{"Content-Type, Access-Control-Allow-Headers, Authorization, X-Requested-With, X-API-Key, X-Device-Label, X-Request-ID, traceparent, tracestate"},
+			ExposeHeaders:    []string{"Content-Length, X-Request-ID"},
 			AllowCredentials: true,
 		}))
 
-	app.RegisterRoute(router)
+	// 요청 아이디와 트레이스 span, 요청 로그를 남기고 요청 context 에 기본 deadline 설정, 클라이언트 연결이 끊기면 진행 중인 쿼리도 취소
+	router.Use(middleware.RequestID(), middleware.Tracing(), middleware.Logger(), middleware.Timeout(cfg.Server.RequestTimeout))
+
+	// 요청 본문 크기 제한 (MAX_BODY_BYTES), 요청 JSON 해석 방식 (REJECT_UNKNOWN_FIELDS)
+	router.Use(middleware.Body(int64(cfg.Server.MaxBodyBytes), cfg.Server.RejectUnknownFields))
 
-	router.Run(fmt.Sprintf(":%s", os.Getenv("SERVER_PORT")))
+	// 응답 코드 메시지를 회원 설정 혹은 Accept-Language 언어 (ko, en) 로 번역
+	router.Use(middleware.Localize())
+
+	if err := app.RegisterRoute(router); err != nil {
+		app.Clean()
+		return nil, nil, err
+	}
 
-	app.Clean()
+	return router, app, nil
 }
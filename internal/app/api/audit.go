@@ -0,0 +1,112 @@
+package api
+
+import (
+	"net/http"
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/app/api/middleware"
+	"signupin-api/internal/pkg/audit"
+	"signupin-api/internal/pkg/reqctx"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kkodecaffeine/go-common/rest"
+	"golang.org/x/exp/slog"
+)
+
+type AuditController struct {
+	usecase audit.Usecase
+}
+
+// NewAuditController returns new audit log controller instance
+func NewAuditController(e *gin.Engine, uc audit.Usecase, authenticate gin.HandlerFunc, admins []string) AuditController {
+	ctrl := AuditController{uc}
+
+	admin := e.Group("/v1/admin")
+	admin.Use(authenticate, middleware.Admin(admins), middleware.Scopes("audit:read"))
+	admin.GET("/audit-events", ctrl.Search)
+	admin.GET("/audit-events/verify", ctrl.Verify)
+
+	return ctrl
+}
+
+/**
+ * 감사 로그 조회 API (관리자)
+ * 이벤트 종류, 결과, 요청 주체, 대상, IP, 기간으로 조회
+ * @return : 최신순 감사 이벤트 목록 (page, size, 전체 건수)
+ */
+func (ctrl *AuditController) Search(c *gin.Context) {
+	response := rest.NewApiResponse()
+	entry := auditEntry(c, audit.ActionAuditSearch).Target(audit.TargetAuditLog, "")
+	defer auditResponse(c, ctrl.usecase, entry, response)
+
+	var req dto.GetAuditEventsRequest
+	if !bindQuery(c, response, &req) {
+		return
+	}
+
+	result, err := ctrl.usecase.Search(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	response.Succeed("", result)
+	c.JSON(http.StatusOK, response)
+}
+
+/**
+ * 감사 로그 무결성 확인 API (관리자)
+ * 처음 이벤트부터 순번과 해시 연결을 확인
+ * @return : 확인 결과 (연결이 깨진 순번과 이유), 마지막 순번과 해시
+ */
+func (ctrl *AuditController) Verify(c *gin.Context) {
+	response := rest.NewApiResponse()
+	entry := auditEntry(c, audit.ActionAuditVerify).Target(audit.TargetAuditLog, "")
+	defer auditResponse(c, ctrl.usecase, entry, response)
+
+	result, err := ctrl.usecase.Verify(c.Request.Context())
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	response.Succeed("", result)
+	c.JSON(http.StatusOK, response)
+}
+
+// auditEntry 는 요청의 인증 주체와 접속 정보 (IP, User-Agent, 요청 아이디) 로 감사 이벤트 생성
+func auditEntry(c *gin.Context, action string) *audit.Entry {
+	entry := &audit.Entry{
+		Action:    action,
+		ActorType: audit.ActorAnonymous,
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		RequestID: reqctx.RequestID(c.Request.Context()),
+	}
+	if principal := middleware.GetPrincipal(c); principal != nil {
+		entry.ActorType = principal.Kind
+		entry.ActorID = principal.Subject
+	}
+	return entry
+}
+
+// recordAudit 은 감사 이벤트 기록, 기록에 실패해도 요청은 실패시키지 않고 로그로 남김
+func recordAudit(c *gin.Context, recorder audit.Usecase, entry *audit.Entry) {
+	if err := recorder.Record(c.Request.Context(), entry); err != nil {
+		slog.ErrorCtx(c.Request.Context(), "audit record failed",
+			"action", entry.Action,
+			"outcome", entry.Outcome,
+			"code", err.CodeDesc.Code,
+			"error", err.Message,
+		)
+	}
+}
+
+// auditResponse 는 응답 상태 코드와 응답 코드를 결과로 감사 이벤트 기록 (defer 로 호출, 응답하지 않은 경우 기록하지 않음)
+func auditResponse(c *gin.Context, recorder audit.Usecase, entry *audit.Entry, response *rest.ApiResponse) {
+	if c.Writer.Written() {
+		recordAudit(c, recorder, entry.Result(c.Writer.Status(), response.Code))
+	}
+}
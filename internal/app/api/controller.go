@@ -1,38 +1,77 @@
 package api
 
 import (
-	"fmt"
+	"html/template"
 	"net/http"
 	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/app/api/middleware"
+	"signupin-api/internal/pkg/apikey"
+	"signupin-api/internal/pkg/audit"
+	"signupin-api/internal/pkg/device"
+	"signupin-api/internal/pkg/metrics"
+	"signupin-api/internal/pkg/risk"
+	"signupin-api/internal/pkg/session"
+	"signupin-api/internal/pkg/social"
 	"signupin-api/internal/pkg/user"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/kkodecaffeine/go-common/errorcode"
-	"github.com/kkodecaffeine/go-common/middleware/token"
 
 	"github.com/kkodecaffeine/go-common/rest"
+	"golang.org/x/exp/slog"
 )
 
+// notMeTemplate 은 알림 링크로 열리는 확인 화면, 버튼을 눌러야 신고 API (POST) 호출
+var notMeTemplate = template.Must(template.New("not-me").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>본인 아님 신고</title></head>
+<body>
+	<p>본인이 로그인하지 않았다면 아래 버튼을 눌러 해당 로그인을 종료하고 비밀번호를 재설정하세요.</p>
+	<button id="confirm" type="button">본인이 아닙니다</button>
+	<p id="result"></p>
+	<script>
+		document.getElementById("confirm").onclick = function () {
+			fetch({{.Action}}, {method: "POST", headers: {"Content-Type": "application/json"}, body: JSON.stringify({token: {{.Token}}})})
+				.then(function (res) { return res.json(); })
+				.then(function (body) { document.getElementById("result").textContent = body.message; });
+		};
+	</script>
+</body>
+</html>
+`))
+
 type Controller struct {
-	v       *validator.Validate
-	usecase user.Usecase
+	v          *validator.Validate
+	usecase    user.Usecase
+	sessions   session.Usecase
+	devices    device.Usecase
+	audit      audit.Usecase
+	keys       apikey.Usecase
+	identities social.Usecase
+	checks     signInChecks
 }
 
 // NewController returns new controller instance
-func NewController(e *gin.Engine, v *validator.Validate, uc user.Usecase) Controller {
-	ctrl := Controller{v, uc}
+func NewController(e *gin.Engine, v *validator.Validate, uc user.Usecase, sessions session.Usecase, devices device.Usecase, risks risk.Usecase, audits audit.Usecase, keys apikey.Usecase, identities social.Usecase, authenticate gin.HandlerFunc) Controller {
+	ctrl := Controller{v, uc, sessions, devices, audits, keys, identities, signInChecks{risks, devices, audits}}
 
 	v1 := e.Group("/v1")
 	v1.POST("/auth/sms", ctrl.SendSMS)
 	v1.POST("/auth/sign-up", ctrl.SignUp)
 	v1.POST("/auth/sign-in", ctrl.SignIn)
+	v1.GET("/auth/not-me", ctrl.NotMeForm)
+	v1.POST("/auth/not-me", ctrl.NotMe)
 
 	authorized := v1.Group("/")
-	authorized.Use(token.JwtAuthMiddleware()).GET("/users/:userID", ctrl.GetMe)
-	authorized.Use(token.JwtAuthMiddleware()).PUT("/users/reset-password", ctrl.UpdatePassword)
+	authorized.Use(authenticate)
+	authorized.GET("/users/:userID", middleware.Scopes("users:read"), ctrl.GetMe)
+	authorized.PUT("/users/reset-password", middleware.PasswordReset(), ctrl.UpdatePassword)
+	authorized.PUT("/users/me/locale", middleware.Scopes(), ctrl.UpdateLocale)
+	authorized.DELETE("/users/me", middleware.Scopes(), ctrl.DeleteMe)
 
 	return ctrl
 }
@@ -44,26 +83,20 @@ func NewController(e *gin.Engine, v *validator.Validate, uc user.Usecase) Contro
  */
 func (ctrl *Controller) SendSMS(c *gin.Context) {
 	response := rest.NewApiResponse()
+	entry := auditEntry(c, audit.ActionCodeIssue).Target(audit.TargetAuthNumber, "")
+	defer auditResponse(c, ctrl.audit, entry, response)
 
 	var req dto.PostSMSRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		for _, element := range err.(validator.ValidationErrors) {
-			if element.ActualTag() == "required" {
-				response.Error(&errorcode.MISSING_PARAMETERS, fmt.Sprintf("required: %s", element.Field()), nil)
-				c.JSON(http.StatusBadRequest, response)
-				return
-			} else {
-				response.Error(&errorcode.INVALID_PARAMETERS, fmt.Sprintf("tag: %s", element.Field()), nil)
-				c.JSON(http.StatusBadRequest, response)
-				return
-			}
-		}
+	if !bindJSON(c, ctrl.v, response, &req) {
+		return
 	}
 
+	entry.Identifier = req.Phone
+
 	// 기존에 생성된 인증번호가 있는지 없는지 확인
-	authnumber, _ := ctrl.usecase.GetAuthNumber()
+	authnumber, _ := ctrl.usecase.GetAuthNumber(c.Request.Context())
 	if authnumber == "" {
-		authnumber, _ = ctrl.usecase.UpsertAuthNumber()
+		authnumber, _ = ctrl.usecase.UpsertAuthNumber(c.Request.Context())
 	}
 
 	result := dto.PostSMSResponse{
@@ -82,46 +115,34 @@ func (ctrl *Controller) SendSMS(c *gin.Context) {
  */
 func (ctrl *Controller) SignUp(c *gin.Context) {
 	response := rest.NewApiResponse()
+	entry := auditEntry(c, audit.ActionSignUp)
+	defer auditResponse(c, ctrl.audit, entry, response)
 
 	var req dto.PostSignUpRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		for _, element := range err.(validator.ValidationErrors) {
-			if element.ActualTag() == "required" {
-				response.Error(&errorcode.MISSING_PARAMETERS, fmt.Sprintf("required: %s", element.Field()), nil)
-				c.JSON(http.StatusBadRequest, response)
-				return
-			} else {
-				if len(fmt.Sprintf("%v", element.Value())) == 0 {
-					break
-				}
-				response.Error(&errorcode.INVALID_PARAMETERS, fmt.Sprintf("tag: %s", element.Field()), nil)
-				c.JSON(http.StatusBadRequest, response)
-				return
-			}
-		}
-	}
-
-	if err := ctrl.v.Struct(req); err != nil {
-		response.Error(&errorcode.INVALID_PARAMETERS, err.Error(), nil)
-		c.JSON(http.StatusBadRequest, response)
+	if !bindJSON(c, ctrl.v, response, &req) {
 		return
 	}
 
-	exists, _ := ctrl.usecase.GetOne(req.Email) // 이미 가입한 회원인지 확인
+	entry.Identifier = req.Email
+
+	exists, _ := ctrl.usecase.GetOne(c.Request.Context(), req.Email) // 이미 가입한 회원인지 확인
 	if exists != nil {
 		response.Error(&errorcode.AUTH_EMAIL_ALREADY_EXISTS, req.Email, "")
 		c.JSON(errorcode.AUTH_EMAIL_ALREADY_EXISTS.HttpStatusCode, response)
 		return
 	}
 
-	insertedID, err := ctrl.usecase.SaveOne(&req)
+	insertedID, err := ctrl.usecase.SaveOne(c.Request.Context(), &req)
+	ctrl.auditAuthNumber(c, req.Email, err)
 	if err != nil {
 		response.Error(err.CodeDesc, err.Message, err.Data)
 		c.JSON(err.CodeDesc.HttpStatusCode, response)
 		return
 	}
 
-	found, err := ctrl.usecase.GetOneByID(insertedID)
+	entry.Target(audit.TargetUser, insertedID)
+
+	found, err := ctrl.usecase.GetOneByID(c.Request.Context(), insertedID)
 	if err != nil {
 		response.Error(err.CodeDesc, err.Message, err.Data)
 		c.JSON(err.CodeDesc.HttpStatusCode, response)
@@ -136,32 +157,26 @@ func (ctrl *Controller) SignUp(c *gin.Context) {
  * 회원 로그인 API
  * 요청받은 회원 정보 검증 수행
  * (이메일, 비밀번호) 혹은 (전화번호, 비밀번호) 로 로그인 가능하도록 구현
+ * 로그인 성공 시 세션 (User-Agent, IP, 기기 이름) 을 기록하고 세션에 묶인 JWT 발급
+ * 위험도 평가 결과에 따라 거부하거나 추가 인증 (SMS 코드) 후 로그인 처리
+ * 처음 보는 기기 혹은 IP 로 로그인한 경우 회원에게 알림 ("본인이 아닙니다" 링크 포함)
+ * 비밀번호 재설정이 필요한 회원은 비밀번호 수정 API 만 호출할 수 있는 JWT 발급
  * @return : 가입 시 생성된 회원 정보 (w/ ID, JWT)
  */
 func (ctrl *Controller) SignIn(c *gin.Context) {
 	response := rest.NewApiResponse()
 
-	var req dto.PostSignInRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		for _, element := range err.(validator.ValidationErrors) {
-			if element.ActualTag() == "required" {
-				response.Error(&errorcode.MISSING_PARAMETERS, fmt.Sprintf("required: %s", element.Field()), nil)
-				c.JSON(http.StatusBadRequest, response)
-				return
-			} else {
-				if len(fmt.Sprintf("%v", element.Value())) == 0 {
-					break
-				}
-				response.Error(&errorcode.INVALID_PARAMETERS, fmt.Sprintf("tag: %s", element.Field()), nil)
-				c.JSON(http.StatusBadRequest, response)
-				return
-			}
+	// 응답 코드로 로그인 성공 / 실패 사유 기록
+	defer func() {
+		if c.Writer.Written() {
+			metrics.SignIn(response.Code)
 		}
-	}
+	}()
+	entry := auditEntry(c, audit.ActionSignIn)
+	defer auditResponse(c, ctrl.audit, entry, response)
 
-	if err := ctrl.v.Struct(req); err != nil {
-		response.Error(&errorcode.INVALID_PARAMETERS, err.Error(), nil)
-		c.JSON(http.StatusBadRequest, response)
+	var req dto.PostSignInRequest
+	if !bindJSON(c, ctrl.v, response, &req) {
 		return
 	}
 
@@ -180,12 +195,24 @@ func (ctrl *Controller) SignIn(c *gin.Context) {
 		identifier = req.Email
 	}
 
-	found, err := ctrl.usecase.GetOne(identifier, req.Password)
+	meta := sessionMetadata(c)
+	entry.Identifier = identifier
+
+	found, err := ctrl.usecase.GetOne(c.Request.Context(), identifier, req.Password)
 	if err != nil {
+		ctrl.checks.recordFailure(c, risk.ActionSignIn, identifier, err)
 		response.Error(err.CodeDesc, err.Message, err.Data)
 		c.JSON(err.CodeDesc.HttpStatusCode, response)
 		return
 	}
+	// 비밀번호를 확인한 이후의 결과는 회원 본인의 요청으로 기록
+	entry.Target(audit.TargetUser, found.Id)
+	entry.ActorType, entry.ActorID = middleware.PrincipalUser, found.Id
+
+	signal := &risk.Signal{Action: risk.ActionSignIn, Identifier: identifier, UserID: found.Id, IP: meta.IP, Time: time.Now()}
+	if !ctrl.assessRisk(c, response, signal, meta, found.Phone, req.ChallengeID, req.Code) {
+		return
+	}
 
 	/**
 	 * 로그인 성공 후 인증번호 갱신하는 이유
@@ -193,17 +220,77 @@ func (ctrl *Controller) SignIn(c *gin.Context) {
 	 * 그런데 인증번호가 갱신되지 않은 상태에서 토큰만 갱신한채로 비밀번호 수정 API 가 성공하는 경우의 수가 발생할 여지가 있음
 	 * 즉, 토큰 갱신 후에 기존에 사용했던 인증번호로 본인 전화번호 인증 절차를 누락한채 비밀번호 수정을 진행할 수 있음
 	 */
-	_, err = ctrl.usecase.UpsertAuthNumber()
+	_, err = ctrl.usecase.UpsertAuthNumber(c.Request.Context())
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	meta.PasswordResetRequired = found.PasswordResetRequired
+	meta.Locale = found.Locale
+
+	token, sessionID, err := ctrl.sessions.Start(c.Request.Context(), found.Id, meta)
 	if err != nil {
 		response.Error(err.CodeDesc, err.Message, err.Data)
 		c.JSON(err.CodeDesc.HttpStatusCode, response)
 		return
 	}
+	found.AccessToken = token
+
+	ctrl.checks.checkDevice(c, found, sessionID, meta)
 
 	response.Succeed("", found)
 	c.JSON(http.StatusOK, response)
 }
 
+/**
+ * "본인이 아닙니다" 확인 화면
+ * 새 기기 / 새 IP 로그인 알림에 포함된 링크로 열림
+ * 메일 미리보기 / 링크 검사기가 링크를 열어도 세션이 종료되지 않도록 확인 버튼을 눌러야 신고 API 호출
+ */
+func (ctrl *Controller) NotMeForm(c *gin.Context) {
+	token := c.Query("token")
+	if len(token) == 0 {
+		response := rest.NewApiResponse()
+		response.Error(&errorcode.MISSING_PARAMETERS, "required: token", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	c.Status(http.StatusOK)
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	_ = notMeTemplate.Execute(c.Writer, struct {
+		Action string
+		Token  string
+	}{c.Request.URL.Path, token})
+}
+
+/**
+ * "본인이 아닙니다" API
+ * 확인 화면에서 알림 링크의 토큰으로 호출
+ * 해당 로그인의 세션을 종료하고 다음 로그인부터 비밀번호 재설정을 강제
+ */
+func (ctrl *Controller) NotMe(c *gin.Context) {
+	response := rest.NewApiResponse()
+	entry := auditEntry(c, audit.ActionTokenRevoke).Target(audit.TargetAlert, "")
+	defer auditResponse(c, ctrl.audit, entry, response)
+
+	var req dto.PostNotMeRequest
+	if !bindJSON(c, ctrl.v, response, &req) {
+		return
+	}
+
+	if err := ctrl.devices.NotMe(c.Request.Context(), req.Token); err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	response.Succeed("", nil)
+	c.JSON(http.StatusOK, response)
+}
+
 /**
  * 회원 정보 조회 API
  * JWT 검증 과정 후 회원 정보 조회
@@ -213,7 +300,7 @@ func (ctrl *Controller) GetMe(c *gin.Context) {
 	response := rest.NewApiResponse()
 
 	userID := c.Param("userID")
-	found, err := ctrl.usecase.GetOneByID(userID)
+	found, err := ctrl.usecase.GetOneByID(c.Request.Context(), userID)
 	if err != nil {
 		response.Error(err.CodeDesc, err.Message, err.Data)
 		c.JSON(err.CodeDesc.HttpStatusCode, response)
@@ -231,29 +318,15 @@ func (ctrl *Controller) GetMe(c *gin.Context) {
  * 		- 1) 회원 로그인 API 를 호출하여 신규 토큰 획득
  * 		- 2) 이어서 전화번호 인증 API 호출하여 신규 인증번호 획득
  * 		- 3) 이어서 새로 획득한 인증번호를 요청모델에 담아서 비밀번호 수정 API 호출
- * 기존 비밀번호로 회원 정보 조회에 성공한 후 요청받은 신규 비밀번호로 비밀번호 변경
+ * 기존 비밀번호로 회원 정보 조회에 성공한 후 위험도 평가를 거쳐 요청받은 신규 비밀번호로 비밀번호 변경
  */
 func (ctrl *Controller) UpdatePassword(c *gin.Context) {
 	response := rest.NewApiResponse()
+	entry := auditEntry(c, audit.ActionPasswordChange)
+	defer auditResponse(c, ctrl.audit, entry, response)
 
 	var req dto.PutPasswordRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		for _, element := range err.(validator.ValidationErrors) {
-			if element.ActualTag() == "required" {
-				response.Error(&errorcode.MISSING_PARAMETERS, fmt.Sprintf("required: %s", element.Field()), nil)
-				c.JSON(http.StatusBadRequest, response)
-				return
-			} else {
-				response.Error(&errorcode.INVALID_PARAMETERS, fmt.Sprintf("tag: %s", element.Field()), nil)
-				c.JSON(http.StatusBadRequest, response)
-				return
-			}
-		}
-	}
-
-	if err := ctrl.v.Struct(req); err != nil {
-		response.Error(&errorcode.INVALID_PARAMETERS, err.Error(), nil)
-		c.JSON(http.StatusBadRequest, response)
+	if !bindJSON(c, ctrl.v, response, &req) {
 		return
 	}
 
@@ -271,20 +344,147 @@ func (ctrl *Controller) UpdatePassword(c *gin.Context) {
 		return
 	}
 
-	found, err := ctrl.usecase.GetOne(req.Email, req.Password)
+	meta := sessionMetadata(c)
+	entry.Identifier = req.Email
+
+	found, err := ctrl.usecase.GetOne(c.Request.Context(), req.Email, req.Password)
+	if err != nil {
+		ctrl.checks.recordFailure(c, risk.ActionPasswordEdit, req.Email, err)
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+	entry.Target(audit.TargetUser, found.Id)
+
+	signal := &risk.Signal{Action: risk.ActionPasswordEdit, Identifier: req.Email, UserID: found.Id, IP: meta.IP, Time: time.Now()}
+	if !ctrl.assessRisk(c, response, signal, meta, found.Phone, req.ChallengeID, req.Code) {
+		return
+	}
+
+	_, err = ctrl.usecase.UpdatePassword(c.Request.Context(), req.AuthNumber, found.Id, req.NewPassword)
+	ctrl.auditAuthNumber(c, req.Email, err)
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	response.Succeed("", nil)
+	c.JSON(http.StatusOK, response)
+}
+
+/**
+ * 응답 메시지 언어 설정 API
+ * 회원 JWT 로만 호출 가능 (클라이언트 토큰, API 키 불가)
+ * 설정한 언어는 다음 로그인에서 발급한 토큰부터 Accept-Language 보다 우선 적용
+ * @return : 변경된 회원 정보
+ */
+func (ctrl *Controller) UpdateLocale(c *gin.Context) {
+	response := rest.NewApiResponse()
+
+	var req dto.PutLocaleRequest
+	if !bindJSON(c, ctrl.v, response, &req) {
+		return
+	}
+
+	updated, err := ctrl.usecase.UpdateLocale(c.Request.Context(), middleware.GetPrincipal(c).Subject, req.Locale)
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	response.Succeed("", updated)
+	c.JSON(http.StatusOK, response)
+}
+
+/**
+ * 회원 탈퇴 API
+ * 회원 JWT 로만 호출 가능 (클라이언트 토큰, API 키 불가)
+ * 전화번호 인증 API 로 획득한 인증번호와 비밀번호를 확인한 후 회원 삭제
+ * 탈퇴 이벤트 (user.deleted) 는 회원 삭제와 함께 저장되어 outbox relay 가 전달
+ * 회원 삭제 후 세션 / API 키를 폐기하고 기기 기록 / 외부 계정 연결을 삭제
+ */
+func (ctrl *Controller) DeleteMe(c *gin.Context) {
+	response := rest.NewApiResponse()
+	entry := auditEntry(c, audit.ActionUserDelete)
+	defer auditResponse(c, ctrl.audit, entry, response)
+
+	var req dto.DeleteUserRequest
+	if !bindJSON(c, ctrl.v, response, &req) {
+		return
+	}
+
+	userID := middleware.GetPrincipal(c).Subject
+	entry.Target(audit.TargetUser, userID)
+
+	me, err := ctrl.usecase.GetOneByID(c.Request.Context(), userID)
 	if err != nil {
 		response.Error(err.CodeDesc, err.Message, err.Data)
 		c.JSON(err.CodeDesc.HttpStatusCode, response)
 		return
 	}
+	entry.Identifier = me.Email
 
-	_, err = ctrl.usecase.UpdatePassword(req.AuthNumber, found.Id, req.NewPassword)
+	// 비밀번호 확인
+	if _, err := ctrl.usecase.GetOne(c.Request.Context(), me.Email, req.Password); err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	err = ctrl.usecase.DeleteOne(c.Request.Context(), req.AuthNumber, userID)
+	ctrl.auditAuthNumber(c, me.Email, err)
 	if err != nil {
 		response.Error(err.CodeDesc, err.Message, err.Data)
 		c.JSON(err.CodeDesc.HttpStatusCode, response)
 		return
 	}
+	ctrl.cleanUp(c, userID)
 
 	response.Succeed("", nil)
 	c.JSON(http.StatusOK, response)
 }
+
+// cleanUp 은 탈퇴한 회원의 세션 / API 키를 폐기하고 기기 기록 / 외부 계정 연결을 삭제
+// 회원은 이미 삭제되었으므로 실패해도 탈퇴는 성공으로 응답 (남은 토큰 / 키는 인증 시 회원이 없어 거부, 남은 외부 계정은 다음 로그인 시 삭제)
+func (ctrl *Controller) cleanUp(c *gin.Context, userID string) {
+	ctx := c.Request.Context()
+	for _, step := range []struct {
+		target string
+		err    *rest.CustomError
+	}{
+		{"sessions", ctrl.sessions.RevokeAll(ctx, userID)},
+		{"api_keys", ctrl.keys.RevokeAll(ctx, userID)},
+		{"devices", ctrl.devices.DeleteAll(ctx, userID)},
+		{"identities", ctrl.identities.DeleteAll(ctx, userID)},
+	} {
+		if step.err != nil {
+			slog.ErrorCtx(ctx, "deleted user clean up failed", "target", step.target, "code", step.err.CodeDesc.Code, "error", step.err.Message)
+		}
+	}
+}
+
+// assessRisk 는 위험도 평가 결과에 따라 요청을 계속 진행할지 결정 (signInChecks.assess)
+// 거부되거나 추가 인증이 필요한 경우 응답을 작성하고 false 반환
+func (ctrl *Controller) assessRisk(c *gin.Context, response *rest.ApiResponse, signal *risk.Signal, meta *session.Metadata, phone, challengeID, code string) bool {
+	if err := ctrl.checks.assess(c, signal, meta, phone, challengeID, code); err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return false
+	}
+	return true
+}
+
+// auditAuthNumber 는 회원 가입 / 비밀번호 수정 / 회원 탈퇴 시 인증번호 확인 결과 기록
+// 인증번호 확인 이후의 실패 (ex. 저장 실패) 는 인증번호 확인 성공으로 기록
+func (ctrl *Controller) auditAuthNumber(c *gin.Context, identifier string, err *rest.CustomError) {
+	entry := auditEntry(c, audit.ActionCodeVerify).Target(audit.TargetAuthNumber, "")
+	entry.Identifier = identifier
+	if err != nil && err.Message == user.MessageAuthNumberMismatch {
+		entry.Result(err.CodeDesc.HttpStatusCode, err.CodeDesc.Code)
+	} else {
+		entry.Result(http.StatusOK, "")
+	}
+	recordAudit(c, ctrl.audit, entry)
+}
@@ -0,0 +1,1673 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"signupin-api/internal/app/api"
+	"signupin-api/internal/app/api/apitest"
+	"signupin-api/internal/pkg/logging"
+	"signupin-api/internal/pkg/openapi"
+	"signupin-api/internal/pkg/social"
+	"signupin-api/internal/pkg/tracing"
+	"signupin-api/internal/pkg/webhook"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"golang.org/x/exp/slog"
+)
+
+func TestSendSMS(t *testing.T) {
+	t.Run("issues auth number", func(t *testing.T) {
+		h := apitest.New(t)
+
+		res := h.Do(http.MethodPost, "/api/v1/auth/sms", gin.H{"phone": apitest.Kim.Phone})
+		res.AssertGolden(t, "sms_ok")
+
+		var issued struct {
+			AuthNumber string `json:"authnumber"`
+		}
+		res.Data(t, &issued)
+		if issued.AuthNumber != h.CurrentAuthNumber() {
+			t.Fatalf("got %s, want stored auth number %s", issued.AuthNumber, h.CurrentAuthNumber())
+		}
+	})
+
+	t.Run("returns existing auth number", func(t *testing.T) {
+		h := apitest.New(t)
+		h.IssueAuthNumber()
+
+		res := h.Do(http.MethodPost, "/api/v1/auth/sms", gin.H{"phone": apitest.Kim.Phone})
+		res.AssertStatus(t, http.StatusOK)
+
+		var issued struct {
+			AuthNumber string `json:"authnumber"`
+		}
+		res.Data(t, &issued)
+		if issued.AuthNumber != apitest.AuthNumber {
+			t.Fatalf("got %s, want %s", issued.AuthNumber, apitest.AuthNumber)
+		}
+	})
+
+	t.Run("requires phone", func(t *testing.T) {
+		h := apitest.New(t)
+
+		h.Do(http.MethodPost, "/api/v1/auth/sms", gin.H{}).AssertGolden(t, "sms_missing_phone")
+	})
+
+	t.Run("rejects invalid phone", func(t *testing.T) {
+		h := apitest.New(t)
+
+		h.Do(http.MethodPost, "/api/v1/auth/sms", gin.H{"phone": "not-a-phone"}).AssertGolden(t, "sms_invalid_phone")
+	})
+}
+
+func TestSignUp(t *testing.T) {
+	t.Run("creates user", func(t *testing.T) {
+		h := apitest.New(t)
+
+		h.Do(http.MethodPost, "/api/v1/auth/sign-up", apitest.Kim.SignUpBody(h.IssueAuthNumber())).AssertGolden(t, "sign_up_ok")
+	})
+
+	t.Run("rejects duplicated email", func(t *testing.T) {
+		h := apitest.New(t)
+		h.CreateUser(apitest.Kim)
+
+		h.Do(http.MethodPost, "/api/v1/auth/sign-up", apitest.Kim.SignUpBody(h.IssueAuthNumber())).AssertGolden(t, "sign_up_duplicated_email")
+	})
+
+	t.Run("rejects auth number mismatch", func(t *testing.T) {
+		h := apitest.New(t)
+		h.IssueAuthNumber()
+
+		h.Do(http.MethodPost, "/api/v1/auth/sign-up", apitest.Kim.SignUpBody("654321")).AssertGolden(t, "sign_up_auth_number_mismatch")
+	})
+
+	t.Run("requires fields", func(t *testing.T) {
+		h := apitest.New(t)
+
+		body := apitest.Kim.SignUpBody(h.IssueAuthNumber())
+		delete(body, "email")
+
+		h.Do(http.MethodPost, "/api/v1/auth/sign-up", body).AssertGolden(t, "sign_up_missing_email")
+	})
+
+	t.Run("validates password length", func(t *testing.T) {
+		h := apitest.New(t)
+
+		body := apitest.Kim.SignUpBody(h.IssueAuthNumber())
+		body["password"] = "short"
+
+		h.Do(http.MethodPost, "/api/v1/auth/sign-up", body).AssertGolden(t, "sign_up_short_password")
+	})
+
+	t.Run("reports every invalid field", func(t *testing.T) {
+		h := apitest.New(t)
+
+		body := apitest.Kim.SignUpBody(h.IssueAuthNumber())
+		delete(body, "email")
+		body["authnumber"] = "123"
+		body["password"] = "short"
+		body["phone"] = "02-123-4567"
+
+		h.Do(http.MethodPost, "/api/v1/auth/sign-up", body).AssertGolden(t, "sign_up_invalid_fields")
+	})
+}
+
+func TestSignIn(t *testing.T) {
+	t.Run("signs in with email", func(t *testing.T) {
+		h := apitest.New(t)
+		h.CreateUser(apitest.Kim)
+
+		h.Do(http.MethodPost, "/api/v1/auth/sign-in", gin.H{"email": apitest.Kim.Email, "password": apitest.Kim.Password}).AssertGolden(t, "sign_in_ok")
+	})
+
+	t.Run("signs in with phone", func(t *testing.T) {
+		h := apitest.New(t)
+		h.CreateUser(apitest.Kim)
+
+		res := h.Do(http.MethodPost, "/api/v1/auth/sign-in", gin.H{"phone": apitest.Kim.Phone, "password": apitest.Kim.Password})
+		res.AssertStatus(t, http.StatusOK)
+	})
+
+	t.Run("renews auth number", func(t *testing.T) {
+		h := apitest.New(t)
+		h.CreateUser(apitest.Kim)
+		h.IssueAuthNumber()
+
+		h.SignIn(apitest.Kim)
+		if h.CurrentAuthNumber() == apitest.AuthNumber {
+			t.Fatal("auth number was not renewed after sign in")
+		}
+	})
+
+	t.Run("rejects wrong password", func(t *testing.T) {
+		h := apitest.New(t)
+		h.CreateUser(apitest.Kim)
+
+		h.Do(http.MethodPost, "/api/v1/auth/sign-in", gin.H{"email": apitest.Kim.Email, "password": "wrong-password"}).AssertGolden(t, "sign_in_wrong_password")
+	})
+
+	t.Run("requires email or phone", func(t *testing.T) {
+		h := apitest.New(t)
+
+		h.Do(http.MethodPost, "/api/v1/auth/sign-in", gin.H{"password": apitest.Kim.Password}).AssertGolden(t, "sign_in_missing_identifier")
+	})
+
+	t.Run("notifies sign in from new device", func(t *testing.T) {
+		h := apitest.New(t)
+		h.CreateUser(apitest.Kim)
+
+		h.SignIn(apitest.Kim)
+		if messages := h.Outbox.Messages(apitest.Kim.Email); len(messages) != 0 {
+			t.Fatalf("first device should not be notified: %+v", messages)
+		}
+
+		h.SignIn(apitest.Kim, apitest.Header{"User-Agent": "another-browser"})
+		if messages := h.Outbox.Messages(apitest.Kim.Email); len(messages) != 1 {
+			t.Fatalf("got %d notifications, want 1", len(messages))
+		}
+	})
+}
+
+func TestNotMe(t *testing.T) {
+	t.Run("revokes session and requires password reset", func(t *testing.T) {
+		h := apitest.New(t)
+		userID := h.CreateUser(apitest.Kim)
+
+		h.SignIn(apitest.Kim)
+		intruder := h.SignIn(apitest.Kim, apitest.Header{"User-Agent": "another-browser"})
+
+		messages := h.Outbox.Messages(apitest.Kim.Email)
+		if len(messages) != 1 {
+			t.Fatalf("got %d notifications, want 1", len(messages))
+		}
+
+		h.Do(http.MethodPost, "/api/v1/auth/not-me", gin.H{"token": notMeToken(t, messages[0].Body)}).AssertGolden(t, "not_me_ok")
+
+		h.Do(http.MethodGet, "/api/v1/users/"+userID, nil, intruder).AssertStatus(t, http.StatusUnauthorized)
+
+		res := h.Do(http.MethodPost, "/api/v1/auth/sign-in", gin.H{"email": apitest.Kim.Email, "password": apitest.Kim.Password})
+		res.AssertStatus(t, http.StatusOK)
+
+		var signedIn struct {
+			PasswordResetRequired bool `json:"password_reset_required"`
+		}
+		res.Data(t, &signedIn)
+		if !signedIn.PasswordResetRequired {
+			t.Fatal("password reset is not required after not-me")
+		}
+	})
+
+	t.Run("only revokes session of passwordless user", func(t *testing.T) {
+		cfg := apitest.NewConfig(t)
+		idp := apitest.NewIdP(t, cfg)
+		h := apitest.NewWithConfig(t, cfg)
+
+		h.SocialSignIn().AssertStatus(t, http.StatusOK)
+		start := h.Do(http.MethodGet, "/api/v1/auth/social/mock", nil)
+		res := h.SocialCallback(start.Header.Get("Location"), apitest.Header{"User-Agent": "another-browser"})
+		res.AssertStatus(t, http.StatusOK)
+		var intruder struct {
+			User struct {
+				Id          string `json:"id"`
+				AccessToken string `json:"accesstoken"`
+			} `json:"user"`
+		}
+		res.Data(t, &intruder)
+
+		messages := h.Outbox.Messages(idp.Email)
+		if len(messages) != 1 {
+			t.Fatalf("got %d notifications, want 1", len(messages))
+		}
+		h.Do(http.MethodPost, "/api/v1/auth/not-me", gin.H{"token": notMeToken(t, messages[0].Body)}).AssertStatus(t, http.StatusOK)
+		h.Do(http.MethodGet, "/api/v1/users/"+intruder.User.Id, nil, apitest.Bearer(intruder.User.AccessToken)).AssertStatus(t, http.StatusUnauthorized)
+
+		// 비밀번호가 없으므로 비밀번호 재설정 없이 다시 로그인
+		res = h.SocialSignIn()
+		res.AssertStatus(t, http.StatusOK)
+		var signedIn struct {
+			User struct {
+				AccessToken           string `json:"accesstoken"`
+				PasswordResetRequired bool   `json:"password_reset_required"`
+			} `json:"user"`
+		}
+		res.Data(t, &signedIn)
+		if signedIn.User.PasswordResetRequired {
+			t.Fatal("passwordless user is locked out by password reset")
+		}
+		h.Do(http.MethodGet, "/api/v1/users/"+intruder.User.Id, nil, apitest.Bearer(signedIn.User.AccessToken)).AssertStatus(t, http.StatusOK)
+	})
+
+	t.Run("link only shows confirmation", func(t *testing.T) {
+		h := apitest.New(t)
+		userID := h.CreateUser(apitest.Kim)
+
+		h.SignIn(apitest.Kim)
+		intruder := h.SignIn(apitest.Kim, apitest.Header{"User-Agent": "another-browser"})
+		token := notMeToken(t, h.Outbox.Messages(apitest.Kim.Email)[0].Body)
+
+		// 메일 미리보기 / 링크 검사기처럼 링크만 여러 번 열어도 세션은 유지
+		for i := 0; i < 2; i++ {
+			res := h.Do(http.MethodGet, "/api/v1/auth/not-me?token="+url.QueryEscape(token), nil)
+			res.AssertStatus(t, http.StatusOK)
+			if !strings.HasPrefix(res.Header.Get("Content-Type"), "text/html") || !strings.Contains(string(res.Body), `method: "POST"`) {
+				t.Fatalf("unexpected confirmation page: %s\n%s", res.Header.Get("Content-Type"), res.Body)
+			}
+		}
+		h.Do(http.MethodGet, "/api/v1/users/"+userID, nil, intruder).AssertStatus(t, http.StatusOK)
+
+		h.Do(http.MethodPost, "/api/v1/auth/not-me", gin.H{"token": token}).AssertStatus(t, http.StatusOK)
+		h.Do(http.MethodGet, "/api/v1/users/"+userID, nil, intruder).AssertStatus(t, http.StatusUnauthorized)
+	})
+
+	t.Run("requires token", func(t *testing.T) {
+		h := apitest.New(t)
+
+		h.Do(http.MethodGet, "/api/v1/auth/not-me", nil).AssertStatus(t, http.StatusBadRequest)
+		h.Do(http.MethodPost, "/api/v1/auth/not-me", gin.H{}).AssertGolden(t, "not_me_missing_token")
+	})
+
+	t.Run("rejects unknown token", func(t *testing.T) {
+		h := apitest.New(t)
+
+		h.Do(http.MethodPost, "/api/v1/auth/not-me", gin.H{"token": "unknown"}).AssertGolden(t, "not_me_unknown_token")
+	})
+}
+
+func TestGetMe(t *testing.T) {
+	t.Run("returns user", func(t *testing.T) {
+		h := apitest.New(t)
+		userID := h.CreateUser(apitest.Kim)
+
+		h.Do(http.MethodGet, "/api/v1/users/"+userID, nil, h.SignIn(apitest.Kim)).AssertGolden(t, "get_user_ok")
+	})
+
+	t.Run("requires token", func(t *testing.T) {
+		h := apitest.New(t)
+		userID := h.CreateUser(apitest.Kim)
+
+		h.Do(http.MethodGet, "/api/v1/users/"+userID, nil).AssertGolden(t, "get_user_unauthorized")
+	})
+
+	t.Run("rejects forged token", func(t *testing.T) {
+		h := apitest.New(t)
+		userID := h.CreateUser(apitest.Kim)
+
+		h.Do(http.MethodGet, "/api/v1/users/"+userID, nil, apitest.Bearer("forged")).AssertStatus(t, http.StatusUnauthorized)
+	})
+
+	t.Run("blocks pending password reset", func(t *testing.T) {
+		h := apitest.New(t)
+		userID := h.CreateUser(apitest.Kim)
+		h.RequirePasswordReset(userID)
+
+		h.Do(http.MethodGet, "/api/v1/users/"+userID, nil, h.SignIn(apitest.Kim)).AssertGolden(t, "get_user_password_reset_required")
+	})
+
+	t.Run("blocks api key of user pending password reset", func(t *testing.T) {
+		h := apitest.New(t)
+		userID := h.CreateUser(apitest.Kim)
+
+		res := h.Do(http.MethodPost, "/api/v1/users/me/api-keys", gin.H{"name": "cli", "scopes": []string{"users:read"}}, h.SignIn(apitest.Kim))
+		res.AssertStatus(t, http.StatusCreated)
+		var created struct {
+			Key string `json:"key"`
+		}
+		res.Data(t, &created)
+		key := apitest.Header{"X-API-Key": created.Key}
+
+		h.Do(http.MethodGet, "/api/v1/users/"+userID, nil, key).AssertStatus(t, http.StatusOK)
+
+		h.RequirePasswordReset(userID)
+		h.Do(http.MethodGet, "/api/v1/users/"+userID, nil, key).AssertGolden(t, "get_user_password_reset_required")
+	})
+
+	t.Run("returns not found for unknown user", func(t *testing.T) {
+		h := apitest.New(t)
+		h.CreateUser(apitest.Kim)
+
+		h.Do(http.MethodGet, "/api/v1/users/"+strings.Repeat("0", 24), nil, h.SignIn(apitest.Kim)).AssertGolden(t, "get_user_not_found")
+	})
+}
+
+func TestAPIKeys(t *testing.T) {
+	h := apitest.New(t)
+	userID := h.CreateUser(apitest.Kim)
+	token := h.SignIn(apitest.Kim)
+
+	type apiKey struct {
+		Id         string     `json:"id"`
+		Key        string     `json:"key"`
+		Prefix     string     `json:"prefix"`
+		Scopes     []string   `json:"scopes"`
+		LastUsedAt *time.Time `json:"last_used_at"`
+		RevokedAt  *time.Time `json:"revoked_at"`
+	}
+	create := func(t *testing.T, body gin.H) apiKey {
+		t.Helper()
+		res := h.Do(http.MethodPost, "/api/v1/users/me/api-keys", body, token)
+		res.AssertStatus(t, http.StatusCreated)
+
+		var created apiKey
+		res.Data(t, &created)
+		return created
+	}
+	list := func(t *testing.T) map[string]apiKey {
+		t.Helper()
+		res := h.Do(http.MethodGet, "/api/v1/users/me/api-keys", nil, token)
+		res.AssertStatus(t, http.StatusOK)
+
+		var found []apiKey
+		res.Data(t, &found)
+		byID := map[string]apiKey{}
+		for _, key := range found {
+			byID[key.Id] = key
+		}
+		return byID
+	}
+
+	t.Run("creates, lists and revokes key", func(t *testing.T) {
+		created := create(t, gin.H{"name": "cli", "scopes": []string{"users:read"}})
+
+		// sk_<prefix>_<secret> 형식의 키는 발급 응답에서만 노출
+		parts := strings.SplitN(created.Key, "_", 3)
+		if len(parts) != 3 || parts[0] != "sk" || len(parts[2]) == 0 || created.Prefix != "sk_"+parts[1] {
+			t.Fatalf("key %q does not match sk_<prefix>_<secret> (prefix %q)", created.Key, created.Prefix)
+		}
+
+		listed, ok := list(t)[created.Id]
+		if !ok {
+			t.Fatalf("key %s is not listed", created.Id)
+		}
+		if listed.Key != "" || listed.Prefix != created.Prefix || len(listed.Scopes) != 1 || listed.Scopes[0] != "users:read" {
+			t.Fatalf("unexpected listed key: %+v", listed)
+		}
+
+		key := apitest.Header{"X-API-Key": created.Key}
+		h.Do(http.MethodGet, "/api/v1/users/"+userID, nil, key).AssertStatus(t, http.StatusOK)
+
+		h.Do(http.MethodDelete, "/api/v1/users/me/api-keys/"+created.Id, nil, token).AssertStatus(t, http.StatusOK)
+		if revoked := list(t)[created.Id]; revoked.RevokedAt == nil {
+			t.Fatalf("key %s is not revoked: %+v", created.Id, revoked)
+		}
+		h.Do(http.MethodGet, "/api/v1/users/"+userID, nil, key).AssertStatus(t, http.StatusUnauthorized)
+	})
+
+	t.Run("updates last used at", func(t *testing.T) {
+		created := create(t, gin.H{"name": "cli", "scopes": []string{"users:read"}})
+		if created.LastUsedAt != nil {
+			t.Fatalf("new key has last_used_at %s", created.LastUsedAt)
+		}
+
+		before := time.Now().Add(-time.Second)
+		h.Do(http.MethodGet, "/api/v1/users/"+userID, nil, apitest.Header{"X-API-Key": created.Key}).AssertStatus(t, http.StatusOK)
+
+		used := list(t)[created.Id]
+		if used.LastUsedAt == nil || used.LastUsedAt.Before(before) {
+			t.Fatalf("last_used_at is not updated: %+v", used)
+		}
+	})
+
+	t.Run("rejects request outside scopes", func(t *testing.T) {
+		audit := apitest.Header{"X-API-Key": create(t, gin.H{"name": "audit", "scopes": []string{"audit:read"}}).Key}
+		h.Do(http.MethodGet, "/api/v1/users/"+userID, nil, audit).AssertStatus(t, http.StatusForbidden)
+
+		// 회원 토큰 전용 라우트는 scope 와 상관없이 거부
+		users := apitest.Header{"X-API-Key": create(t, gin.H{"name": "cli", "scopes": []string{"users:read"}}).Key}
+		h.Do(http.MethodGet, "/api/v1/users/me/sessions", nil, users).AssertStatus(t, http.StatusForbidden)
+		h.Do(http.MethodPost, "/api/v1/users/me/api-keys", gin.H{"name": "nested"}, users).AssertStatus(t, http.StatusForbidden)
+	})
+
+	t.Run("rejects expired key", func(t *testing.T) {
+		past := time.Now().Add(-time.Minute)
+		h.Do(http.MethodPost, "/api/v1/users/me/api-keys", gin.H{"name": "cli", "expires_at": past}, token).AssertStatus(t, http.StatusBadRequest)
+
+		expiresAt := time.Now().Add(time.Second)
+		key := apitest.Header{"X-API-Key": create(t, gin.H{"name": "cli", "scopes": []string{"users:read"}, "expires_at": expiresAt}).Key}
+		h.Do(http.MethodGet, "/api/v1/users/"+userID, nil, key).AssertStatus(t, http.StatusOK)
+
+		time.Sleep(time.Until(expiresAt) + 10*time.Millisecond)
+		h.Do(http.MethodGet, "/api/v1/users/"+userID, nil, key).AssertStatus(t, http.StatusUnauthorized)
+	})
+}
+
+func TestMemoryBackend(t *testing.T) {
+	h := apitest.NewMemoryBackend(t)
+
+	res := h.Do(http.MethodPost, "/api/v1/auth/sms", gin.H{"phone": apitest.Kim.Phone})
+	res.AssertStatus(t, http.StatusOK)
+	var issued struct {
+		AuthNumber string `json:"authnumber"`
+	}
+	res.Data(t, &issued)
+
+	res = h.Do(http.MethodPost, "/api/v1/auth/sign-up", apitest.Kim.SignUpBody(issued.AuthNumber))
+	res.AssertStatus(t, http.StatusOK)
+	var signedUp struct {
+		Id string `json:"id"`
+	}
+	res.Data(t, &signedUp)
+	token := h.SignIn(apitest.Kim)
+
+	// 세션, 기기, 위험도, 감사 로그, API 키도 MongoDB 없이 저장
+	res = h.Do(http.MethodPost, "/api/v1/users/me/api-keys", gin.H{"name": "cli", "scopes": []string{"users:read"}}, token)
+	res.AssertStatus(t, http.StatusCreated)
+	var created struct {
+		Key string `json:"key"`
+	}
+	res.Data(t, &created)
+
+	h.Do(http.MethodGet, "/api/v1/users/"+signedUp.Id, nil, apitest.Header{"X-API-Key": created.Key}).AssertStatus(t, http.StatusOK)
+	for _, path := range []string{"/api/v1/users/me/sessions", "/api/v1/users/me/api-keys"} {
+		h.Do(http.MethodGet, path, nil, token).AssertStatus(t, http.StatusOK)
+	}
+
+	res = h.Do(http.MethodGet, "/readyz", nil)
+	res.AssertStatus(t, http.StatusOK)
+	if strings.Contains(string(res.Body), "mongo") {
+		t.Fatalf("readiness should not check MongoDB: %s", res.Body)
+	}
+}
+
+func TestSessionTTL(t *testing.T) {
+	cfg := apitest.NewConfig(t)
+	cfg.JWT.SessionTTL = 2 * time.Hour
+	h := apitest.NewWithConfig(t, cfg)
+	h.CreateUser(apitest.Kim)
+
+	res := h.Do(http.MethodGet, "/api/v1/users/me/sessions", nil, h.SignIn(apitest.Kim))
+	res.AssertStatus(t, http.StatusOK)
+
+	var found []struct {
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	res.Data(t, &found)
+	if len(found) != 1 {
+		t.Fatalf("got %d sessions, want 1", len(found))
+	}
+	if remaining := time.Until(found[0].ExpiresAt); remaining < time.Hour || remaining > 2*time.Hour {
+		t.Fatalf("session expires in %s, want SESSION_TTL (2h)", remaining)
+	}
+}
+
+func TestSessions(t *testing.T) {
+	h := apitest.New(t)
+	userID := h.CreateUser(apitest.Kim)
+	h.CreateUser(apitest.Lee)
+	laptop := h.SignIn(apitest.Kim, apitest.Header{"X-Device-Label": "laptop"})
+	phone := h.SignIn(apitest.Kim, apitest.Header{"X-Device-Label": "phone"})
+
+	type sessionInfo struct {
+		Id          string `json:"id"`
+		DeviceLabel string `json:"device_label"`
+		IP          string `json:"ip"`
+		Current     bool   `json:"current"`
+	}
+	list := func(t *testing.T, auth apitest.Header) []sessionInfo {
+		t.Helper()
+		res := h.Do(http.MethodGet, "/api/v1/users/me/sessions", nil, auth)
+		res.AssertStatus(t, http.StatusOK)
+
+		var found []sessionInfo
+		res.Data(t, &found)
+		return found
+	}
+	sessionOf := func(t *testing.T, found []sessionInfo, label string) sessionInfo {
+		t.Helper()
+		for _, s := range found {
+			if s.DeviceLabel == label {
+				return s
+			}
+		}
+		t.Fatalf("no session for %s: %+v", label, found)
+		return sessionInfo{}
+	}
+
+	t.Run("lists sessions of user", func(t *testing.T) {
+		found := list(t, laptop)
+		if len(found) != 2 {
+			t.Fatalf("got %d sessions, want 2", len(found))
+		}
+		if current := sessionOf(t, found, "laptop"); !current.Current || !strings.HasPrefix(apitest.RemoteAddr, current.IP+":") {
+			t.Fatalf("unexpected current session: %+v", current)
+		}
+		if other := sessionOf(t, found, "phone"); other.Current {
+			t.Fatalf("other session is marked current: %+v", other)
+		}
+	})
+
+	t.Run("does not end session of another user", func(t *testing.T) {
+		lee := h.SignIn(apitest.Lee)
+		other := sessionOf(t, list(t, laptop), "phone")
+
+		h.Do(http.MethodDelete, "/api/v1/users/me/sessions/"+other.Id, nil, lee).AssertStatus(t, http.StatusNotFound)
+		h.Do(http.MethodGet, "/api/v1/users/"+userID, nil, phone).AssertStatus(t, http.StatusOK)
+	})
+
+	t.Run("ends other session and rejects its token", func(t *testing.T) {
+		other := sessionOf(t, list(t, laptop), "phone")
+
+		h.Do(http.MethodDelete, "/api/v1/users/me/sessions/"+other.Id, nil, laptop).AssertStatus(t, http.StatusOK)
+
+		h.Do(http.MethodGet, "/api/v1/users/"+userID, nil, phone).AssertStatus(t, http.StatusUnauthorized)
+		h.Do(http.MethodGet, "/api/v1/users/me/sessions", nil, phone).AssertStatus(t, http.StatusUnauthorized)
+
+		// 현재 세션은 계속 사용 가능
+		found := list(t, laptop)
+		if len(found) != 1 || found[0].DeviceLabel != "laptop" {
+			t.Fatalf("unexpected sessions after revoke: %+v", found)
+		}
+	})
+}
+
+func TestUpdatePassword(t *testing.T) {
+	body := func(authnumber string) gin.H {
+		return gin.H{
+			"authnumber":   authnumber,
+			"email":        apitest.Kim.Email,
+			"password":     apitest.Kim.Password,
+			"newpassword":  "new-password",
+			"confirmation": "new-password",
+		}
+	}
+
+	t.Run("updates password", func(t *testing.T) {
+		h := apitest.New(t)
+		h.CreateUser(apitest.Kim)
+		auth := h.SignIn(apitest.Kim)
+
+		h.Do(http.MethodPut, "/api/v1/users/reset-password", body(h.CurrentAuthNumber()), auth).AssertGolden(t, "update_password_ok")
+
+		h.Do(http.MethodPost, "/api/v1/auth/sign-in", gin.H{"email": apitest.Kim.Email, "password": apitest.Kim.Password}).AssertStatus(t, http.StatusNotFound)
+		h.Do(http.MethodPost, "/api/v1/auth/sign-in", gin.H{"email": apitest.Kim.Email, "password": "new-password"}).AssertStatus(t, http.StatusOK)
+	})
+
+	t.Run("allows pending password reset", func(t *testing.T) {
+		h := apitest.New(t)
+		h.RequirePasswordReset(h.CreateUser(apitest.Kim))
+		auth := h.SignIn(apitest.Kim)
+
+		h.Do(http.MethodPut, "/api/v1/users/reset-password", body(h.CurrentAuthNumber()), auth).AssertStatus(t, http.StatusOK)
+	})
+
+	t.Run("rejects auth number mismatch", func(t *testing.T) {
+		h := apitest.New(t)
+		h.CreateUser(apitest.Kim)
+		auth := h.SignIn(apitest.Kim)
+
+		h.Do(http.MethodPut, "/api/v1/users/reset-password", body("000000"), auth).AssertGolden(t, "update_password_auth_number_mismatch")
+	})
+
+	t.Run("rejects confirmation mismatch", func(t *testing.T) {
+		h := apitest.New(t)
+		h.CreateUser(apitest.Kim)
+		auth := h.SignIn(apitest.Kim)
+
+		req := body(h.CurrentAuthNumber())
+		req["confirmation"] = "other-password"
+
+		h.Do(http.MethodPut, "/api/v1/users/reset-password", req, auth).AssertGolden(t, "update_password_confirmation_mismatch")
+	})
+
+	t.Run("rejects same password", func(t *testing.T) {
+		h := apitest.New(t)
+		h.CreateUser(apitest.Kim)
+		auth := h.SignIn(apitest.Kim)
+
+		req := body(h.CurrentAuthNumber())
+		req["newpassword"], req["confirmation"] = apitest.Kim.Password, apitest.Kim.Password
+
+		h.Do(http.MethodPut, "/api/v1/users/reset-password", req, auth).AssertGolden(t, "update_password_same_password")
+	})
+
+	t.Run("requires token", func(t *testing.T) {
+		h := apitest.New(t)
+		h.CreateUser(apitest.Kim)
+
+		h.Do(http.MethodPut, "/api/v1/users/reset-password", body(apitest.AuthNumber)).AssertStatus(t, http.StatusUnauthorized)
+	})
+}
+
+func TestDeleteMe(t *testing.T) {
+	body := func(authnumber, password string) gin.H {
+		return gin.H{"authnumber": authnumber, "password": password}
+	}
+
+	t.Run("deletes user", func(t *testing.T) {
+		h := apitest.New(t)
+		userID := h.CreateUser(apitest.Kim)
+		auth := h.SignIn(apitest.Kim)
+
+		h.Do(http.MethodDelete, "/api/v1/users/me", body(h.CurrentAuthNumber(), apitest.Kim.Password), auth).AssertStatus(t, http.StatusOK)
+
+		h.Do(http.MethodPost, "/api/v1/auth/sign-in", gin.H{"email": apitest.Kim.Email, "password": apitest.Kim.Password}).AssertStatus(t, http.StatusNotFound)
+
+		events := h.PublishEvents()
+		if len(events) != 1 || events[0].Type != "user.deleted" || events[0].AggregateID != userID {
+			t.Fatalf("unexpected events: %+v", events)
+		}
+	})
+
+	t.Run("revokes tokens and api keys and removes devices and identities", func(t *testing.T) {
+		h := apitest.New(t)
+		ctx := context.Background()
+		userID := h.CreateUser(apitest.Kim)
+		other := h.SignIn(apitest.Kim)
+		auth := h.SignIn(apitest.Kim)
+
+		res := h.Do(http.MethodPost, "/api/v1/users/me/api-keys", gin.H{"name": "cli", "scopes": []string{"users:read"}}, auth)
+		res.AssertStatus(t, http.StatusCreated)
+		var created struct {
+			Key string `json:"key"`
+		}
+		res.Data(t, &created)
+		key := apitest.Header{"X-API-Key": created.Key}
+		if _, err := h.Deps.Identities.SaveIdentity(ctx, &social.Identity{UserID: userID, Provider: "google", Subject: "g-1"}); err != nil {
+			t.Fatal(err)
+		}
+
+		h.Do(http.MethodDelete, "/api/v1/users/me", body(h.CurrentAuthNumber(), apitest.Kim.Password), auth).AssertStatus(t, http.StatusOK)
+
+		// 탈퇴 전에 발급된 토큰 / 키는 거부
+		h.Do(http.MethodGet, "/api/v1/users/me/sessions", nil, auth).AssertStatus(t, http.StatusUnauthorized)
+		h.Do(http.MethodGet, "/api/v1/users/me/sessions", nil, other).AssertStatus(t, http.StatusUnauthorized)
+		h.Do(http.MethodGet, "/api/v1/users/"+userID, nil, key).AssertStatus(t, http.StatusUnauthorized)
+
+		if sessions, err := h.Deps.Sessions.GetAll(ctx, userID, time.Now()); err != nil || len(sessions) != 0 {
+			t.Fatalf("active sessions: %+v, %v", sessions, err)
+		}
+		keys, err := h.Deps.APIKeys.GetAll(ctx, userID)
+		if err != nil || len(keys) != 1 || keys[0].RevokedAt == nil {
+			t.Fatalf("api keys: %+v, %v", keys, err)
+		}
+		if count, err := h.Deps.Devices.CountDevices(ctx, userID); err != nil || count != 0 {
+			t.Fatalf("devices: %d, %v", count, err)
+		}
+		if identities, err := h.Deps.Identities.GetIdentities(ctx, userID); err != nil || len(identities) != 0 {
+			t.Fatalf("identities: %+v, %v", identities, err)
+		}
+	})
+
+	t.Run("rejects wrong password", func(t *testing.T) {
+		h := apitest.New(t)
+		h.CreateUser(apitest.Kim)
+		auth := h.SignIn(apitest.Kim)
+
+		h.Do(http.MethodDelete, "/api/v1/users/me", body(h.CurrentAuthNumber(), "wrong-password"), auth).AssertStatus(t, http.StatusNotFound)
+		if events := h.PublishEvents(); len(events) != 0 {
+			t.Fatalf("unexpected events: %+v", events)
+		}
+	})
+
+	t.Run("rejects auth number mismatch", func(t *testing.T) {
+		h := apitest.New(t)
+		h.CreateUser(apitest.Kim)
+		auth := h.SignIn(apitest.Kim)
+
+		h.Do(http.MethodDelete, "/api/v1/users/me", body("000000", apitest.Kim.Password), auth).AssertStatus(t, http.StatusBadRequest)
+		h.Do(http.MethodPost, "/api/v1/auth/sign-in", gin.H{"email": apitest.Kim.Email, "password": apitest.Kim.Password}).AssertStatus(t, http.StatusOK)
+	})
+
+	t.Run("requires token", func(t *testing.T) {
+		h := apitest.New(t)
+		h.CreateUser(apitest.Kim)
+
+		h.Do(http.MethodDelete, "/api/v1/users/me", body(apitest.AuthNumber, apitest.Kim.Password)).AssertStatus(t, http.StatusUnauthorized)
+	})
+}
+
+func TestUserEvents(t *testing.T) {
+	h := apitest.New(t)
+
+	h.Do(http.MethodPost, "/api/v1/auth/sign-up", apitest.Kim.SignUpBody(h.IssueAuthNumber())).AssertStatus(t, http.StatusOK)
+	auth := h.SignIn(apitest.Kim)
+	h.Do(http.MethodPut, "/api/v1/users/reset-password", gin.H{
+		"authnumber":   h.CurrentAuthNumber(),
+		"email":        apitest.Kim.Email,
+		"password":     apitest.Kim.Password,
+		"newpassword":  "new-password",
+		"confirmation": "new-password",
+	}, auth).AssertStatus(t, http.StatusOK)
+
+	events := h.PublishEvents()
+	var types []string
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	if strings.Join(types, ",") != "user.registered,user.password_changed" {
+		t.Fatalf("got events %v", types)
+	}
+
+	var registered struct {
+		UserID string `json:"user_id"`
+		Email  string `json:"email"`
+	}
+	if err := json.Unmarshal(events[0].Data, &registered); err != nil {
+		t.Fatal(err)
+	}
+	if registered.Email != apitest.Kim.Email || registered.UserID != events[0].AggregateID {
+		t.Fatalf("unexpected user.registered payload: %s", events[0].Data)
+	}
+
+	// 이미 전달한 이벤트는 다시 전달하지 않음
+	if events := h.PublishEvents(); len(events) != 0 {
+		t.Fatalf("published again: %+v", events)
+	}
+}
+
+// notMeToken 은 새 기기 로그인 알림 본문의 "본인이 아닙니다" 링크에서 token 을 꺼냄
+func notMeToken(t *testing.T, body string) string {
+	t.Helper()
+
+	for _, line := range strings.Split(body, "\n") {
+		if !strings.Contains(line, "/auth/not-me?") {
+			continue
+		}
+		link, err := url.Parse(strings.TrimSpace(line))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return link.Query().Get("token")
+	}
+
+	t.Fatalf("no not-me link in notification:\n%s", body)
+	return ""
+}
+
+func TestRequestDecoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		headers []apitest.Header
+		status  int
+		code    string
+	}{
+		{"malformed json", `{"phone": "01012345678"`, nil, http.StatusBadRequest, "MALFORMED_JSON"},
+		{"syntax error", `{"phone": 01012345678x}`, nil, http.StatusBadRequest, "MALFORMED_JSON"},
+		{"trailing data", `{"phone": "01012345678"} {}`, nil, http.StatusBadRequest, "MALFORMED_JSON"},
+		{"wrong type", `{"phone": 1012345678}`, nil, http.StatusBadRequest, "INVALID_TYPE"},
+		{"not an object", `["01012345678"]`, nil, http.StatusBadRequest, "INVALID_TYPE"},
+		{"empty body", ``, nil, http.StatusBadRequest, "MISSING_PARAMETERS"},
+		{"unsupported content type", `phone=01012345678`, []apitest.Header{{"Content-Type": "application/x-www-form-urlencoded"}}, http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE"},
+		{"unknown fields are ignored", `{"phone": "01012345678", "extra": true}`, nil, http.StatusOK, "SUCCESS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := apitest.New(t)
+
+			res := h.Do(http.MethodPost, "/api/v1/auth/sms", tt.body, tt.headers...)
+			res.AssertStatus(t, tt.status)
+			if code := res.Envelope(t).Code; code != tt.code {
+				t.Fatalf("got code %s, want %s: %s", code, tt.code, res.Body)
+			}
+		})
+	}
+
+	t.Run("reports field of wrong type", func(t *testing.T) {
+		h := apitest.New(t)
+
+		h.Do(http.MethodPost, "/api/v1/auth/sign-up", `{"email": "kim@example.com", "password": 12345678}`).AssertGolden(t, "sign_up_invalid_type")
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		cfg := apitest.NewConfig(t)
+		cfg.Server.RejectUnknownFields = true
+		h := apitest.NewWithConfig(t, cfg)
+
+		h.Do(http.MethodPost, "/api/v1/auth/sms", `{"phone": "01012345678", "extra": true}`).AssertGolden(t, "sms_unknown_field")
+	})
+
+	t.Run("rejects oversized body", func(t *testing.T) {
+		cfg := apitest.NewConfig(t)
+		cfg.Server.MaxBodyBytes = 64
+		h := apitest.NewWithConfig(t, cfg)
+
+		res := h.Do(http.MethodPost, "/api/v1/auth/sms", gin.H{"phone": apitest.Kim.Phone, "padding": strings.Repeat("x", 100)})
+		res.AssertStatus(t, http.StatusRequestEntityTooLarge)
+		if code := res.Envelope(t).Code; code != "BODY_TOO_LARGE" {
+			t.Fatalf("got code %s", code)
+		}
+	})
+}
+
+func TestLocalization(t *testing.T) {
+	t.Run("translates validation errors with Accept-Language", func(t *testing.T) {
+		h := apitest.New(t)
+
+		res := h.Do(http.MethodPost, "/api/v1/auth/sign-up", gin.H{"email": "not-an-email", "name": "김"}, apitest.Header{"Accept-Language": "en-US,en;q=0.9,ko;q=0.8"})
+		res.AssertGolden(t, "sign_up_invalid_fields_en")
+		if lang := res.Header.Get("Content-Language"); lang != "en" {
+			t.Fatalf("got Content-Language %q", lang)
+		}
+	})
+
+	t.Run("translates decode errors", func(t *testing.T) {
+		h := apitest.New(t)
+
+		h.Do(http.MethodPost, "/api/v1/auth/sign-up", `{"email": "kim@example.com", "password": 12345678}`, apitest.Header{"Accept-Language": "en"}).AssertGolden(t, "sign_up_invalid_type_en")
+	})
+
+	t.Run("falls back to Korean for unsupported languages", func(t *testing.T) {
+		h := apitest.New(t)
+
+		res := h.Do(http.MethodPost, "/api/v1/auth/sms", gin.H{}, apitest.Header{"Accept-Language": "fr-FR"})
+		if message := res.Envelope(t).Message; !strings.HasPrefix(message, "필수 입력 정보가 부족합니다.") {
+			t.Fatalf("got message %q", message)
+		}
+		if lang := res.Header.Get("Content-Language"); lang != "ko" {
+			t.Fatalf("got Content-Language %q", lang)
+		}
+	})
+
+	t.Run("uses the user preference over Accept-Language", func(t *testing.T) {
+		h := apitest.New(t)
+		h.CreateUser(apitest.Kim)
+
+		h.Do(http.MethodPut, "/api/v1/users/me/locale", gin.H{"locale": "en"}, h.SignIn(apitest.Kim)).AssertGolden(t, "update_locale_ok")
+
+		// 설정한 언어는 다시 로그인해서 발급한 토큰부터 적용
+		token := h.SignIn(apitest.Kim)
+		res := h.Do(http.MethodGet, "/api/v1/users/"+strings.Repeat("0", 24), nil, token, apitest.Header{"Accept-Language": "ko"})
+		res.AssertGolden(t, "get_user_not_found_en")
+	})
+
+	t.Run("rejects unsupported locale", func(t *testing.T) {
+		h := apitest.New(t)
+		h.CreateUser(apitest.Kim)
+
+		h.Do(http.MethodPut, "/api/v1/users/me/locale", gin.H{"locale": "fr"}, h.SignIn(apitest.Kim)).AssertGolden(t, "update_locale_invalid")
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	h := apitest.New(t)
+	before := scrapeMetrics(t, h)
+
+	h.Do(http.MethodPost, "/api/v1/auth/sms", gin.H{"phone": apitest.Kim.Phone}).AssertStatus(t, http.StatusOK)
+	h.CreateUser(apitest.Kim)
+	h.SignIn(apitest.Kim)
+	h.Do(http.MethodPost, "/api/v1/auth/sign-in", gin.H{"email": apitest.Kim.Email, "password": "wrong-password"}).AssertStatus(t, http.StatusNotFound)
+
+	after := scrapeMetrics(t, h)
+
+	for series, want := range map[string]float64{
+		`signupin_sign_in_total{outcome="success",reason=""}`:                                                   1,
+		`signupin_sign_in_total{outcome="failure",reason="NOT_FOUND_ERROR"}`:                                    1,
+		`signupin_auth_codes_total{event="issued",type="auth_number"}`:                                          2, // 전화번호 인증 + 로그인 후 갱신
+		`signupin_tokens_issued_total{type="session"}`:                                                          1,
+		`signupin_http_request_duration_seconds_count{method="POST",route="/api/v1/auth/sign-in",status="200"}`: 1,
+		`signupin_http_request_duration_seconds_count{method="POST",route="/api/v1/auth/sign-in",status="404"}`: 1,
+		`signupin_repository_call_duration_seconds_count{method="SaveOne",repository="session"}`:                1,
+		`signupin_repository_call_errors_total{method="GetOne",repository="user"}`:                              0, // 없는 회원은 실패로 세지 않음
+	} {
+		if got := after[series] - before[series]; got != want {
+			t.Errorf("%s: got +%v, want +%v", series, got, want)
+		}
+	}
+}
+
+// scrapeMetrics 는 /metrics 응답을 series -> 값으로 변환
+func scrapeMetrics(t *testing.T, h *apitest.Harness) map[string]float64 {
+	t.Helper()
+
+	res := h.Do(http.MethodGet, "/metrics", nil)
+	res.AssertStatus(t, http.StatusOK)
+
+	result := map[string]float64{}
+	for _, line := range strings.Split(string(res.Body), "\n") {
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.LastIndex(line, " ")
+		value, err := strconv.ParseFloat(line[i+1:], 64)
+		if err != nil {
+			t.Fatalf("invalid metric line %q: %v", line, err)
+		}
+		result[line[:i]] = value
+	}
+	return result
+}
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	h := apitest.New(t)
+	userID := h.CreateUser(apitest.Kim)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	traceparent := apitest.Header{"traceparent": "00-" + traceID + "-00f067aa0ba902b7-01"}
+	h.Do(http.MethodPost, "/api/v1/auth/sign-in", gin.H{"email": apitest.Kim.Email, "password": apitest.Kim.Password}, traceparent).AssertStatus(t, http.StatusOK)
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+
+	request, ok := spans["POST /api/v1/auth/sign-in"]
+	if !ok {
+		t.Fatalf("no request span in %v", spanNames(recorder.Ended()))
+	}
+	if got := request.SpanContext().TraceID().String(); got != traceID {
+		t.Errorf("request span should continue the incoming trace: got trace %s", got)
+	}
+	if got := request.Parent().SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("request span parent: got %s", got)
+	}
+
+	getOne, ok := spans["user.Usecase/GetOne"]
+	if !ok {
+		t.Fatalf("no usecase span in %v", spanNames(recorder.Ended()))
+	}
+	if getOne.Parent().SpanID() != request.SpanContext().SpanID() {
+		t.Errorf("usecase span should be a child of the request span")
+	}
+	attrs := map[attribute.Key]string{}
+	for _, kv := range getOne.Attributes() {
+		attrs[kv.Key] = kv.Value.Emit()
+	}
+	if attrs[tracing.AttrUserID] != userID || attrs[tracing.AttrOutcome] != tracing.OutcomeSuccess {
+		t.Errorf("usecase span attributes: got %v", attrs)
+	}
+}
+
+func spanNames(spans []sdktrace.ReadOnlySpan) []string {
+	names := make([]string, 0, len(spans))
+	for _, span := range spans {
+		names = append(names, span.Name())
+	}
+	return names
+}
+
+func TestRequestLog(t *testing.T) {
+	var out bytes.Buffer
+	previous := slog.Default()
+	logging.SetDefault(&out, slog.LevelInfo)
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	h := apitest.New(t)
+	userID := h.CreateUser(apitest.Kim)
+	auth := h.SignIn(apitest.Kim)
+	auth["X-Request-ID"] = "req-log-1"
+
+	out.Reset()
+	h.Do(http.MethodGet, "/api/v1/users/"+userID+"?authnumber=483920", nil, auth).AssertStatus(t, http.StatusOK)
+
+	var line map[string]interface{}
+	for _, raw := range bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n")) {
+		var entry map[string]interface{}
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			t.Fatalf("log line is not JSON: %s", raw)
+		}
+		if entry["msg"] == "request" {
+			line = entry
+		}
+	}
+	if line == nil {
+		t.Fatalf("no request log in\n%s", out.String())
+	}
+
+	want := map[string]interface{}{
+		"level":      "INFO",
+		"request_id": "req-log-1",
+		"user_id":    userID,
+		"route":      "/api/v1/users/:userID",
+		"status":     float64(http.StatusOK),
+	}
+	for key, value := range want {
+		if line[key] != value {
+			t.Errorf("%s: got %v, want %v", key, line[key], value)
+		}
+	}
+	for _, leaked := range []string{"483920", apitest.Kim.Email, apitest.Kim.Phone} {
+		if strings.Contains(out.String(), leaked) {
+			t.Errorf("log contains %q:\n%s", leaked, out.String())
+		}
+	}
+}
+
+func TestAuditLog(t *testing.T) {
+	h := apitest.New(t)
+	userID := h.CreateUser(apitest.Kim)
+	h.CreateAdmin()
+
+	h.SignIn(apitest.Kim)
+	h.Do(http.MethodPost, "/api/v1/auth/sign-in", gin.H{"email": apitest.Kim.Email, "password": "wrong-password"}).AssertStatus(t, http.StatusNotFound)
+	admin := h.SignIn(apitest.Admin)
+
+	t.Run("searches sign in events", func(t *testing.T) {
+		res := h.Do(http.MethodGet, "/api/v1/admin/audit-events?action=sign_in", nil, admin)
+		res.AssertStatus(t, http.StatusOK)
+
+		var found struct {
+			Events []struct {
+				Action     string `json:"action"`
+				Outcome    string `json:"outcome"`
+				Reason     string `json:"reason"`
+				ActorID    string `json:"actor_id"`
+				Identifier string `json:"identifier"`
+				IP         string `json:"ip"`
+				UserAgent  string `json:"user_agent"`
+				Hash       string `json:"hash"`
+			} `json:"events"`
+			Total int64 `json:"total"`
+		}
+		res.Data(t, &found)
+
+		if found.Total != 3 || len(found.Events) != 3 {
+			t.Fatalf("got %d events (total %d), want 3\n%s", len(found.Events), found.Total, res.Body)
+		}
+		// 최신순 (관리자 로그인, 실패, 성공)
+		failed, succeeded := found.Events[1], found.Events[2]
+		if failed.Outcome != "failure" || failed.Reason == "" || failed.Identifier != apitest.Kim.Email {
+			t.Errorf("unexpected failed sign in: %+v", failed)
+		}
+		if succeeded.Outcome != "success" || succeeded.ActorID != userID {
+			t.Errorf("unexpected sign in: %+v", succeeded)
+		}
+		if succeeded.IP != "192.0.2.1" || succeeded.UserAgent != "apitest" || succeeded.Hash == "" {
+			t.Errorf("missing request context: %+v", succeeded)
+		}
+	})
+
+	t.Run("paginates", func(t *testing.T) {
+		res := h.Do(http.MethodGet, "/api/v1/admin/audit-events?action=sign_in&size=1&page=2", nil, admin)
+		res.AssertStatus(t, http.StatusOK)
+
+		var found struct {
+			Events []json.RawMessage `json:"events"`
+			Page   int               `json:"page"`
+			Total  int64             `json:"total"`
+		}
+		res.Data(t, &found)
+		if len(found.Events) != 1 || found.Page != 2 || found.Total != 3 {
+			t.Errorf("unexpected page: %s", res.Body)
+		}
+	})
+
+	t.Run("verifies hash chain", func(t *testing.T) {
+		res := h.Do(http.MethodGet, "/api/v1/admin/audit-events/verify", nil, admin)
+		res.AssertStatus(t, http.StatusOK)
+
+		var verified struct {
+			Valid   bool  `json:"valid"`
+			Checked int64 `json:"checked"`
+		}
+		res.Data(t, &verified)
+		if !verified.Valid || verified.Checked == 0 {
+			t.Errorf("unexpected verify result: %s", res.Body)
+		}
+	})
+
+	t.Run("rejects non admin", func(t *testing.T) {
+		h.Do(http.MethodGet, "/api/v1/admin/audit-events", nil, h.SignIn(apitest.Kim)).AssertStatus(t, http.StatusForbidden)
+		h.Do(http.MethodGet, "/api/v1/admin/audit-events", nil).AssertStatus(t, http.StatusUnauthorized)
+	})
+}
+
+func TestSocialSignIn(t *testing.T) {
+	t.Run("signs up with verified email and records session and device", func(t *testing.T) {
+		cfg := apitest.NewConfig(t)
+		apitest.NewIdP(t, cfg)
+		h := apitest.NewWithConfig(t, cfg)
+
+		res := h.SocialSignIn()
+		res.AssertStatus(t, http.StatusOK)
+		var signedIn struct {
+			Created bool `json:"created"`
+			User    struct {
+				Id          string `json:"id"`
+				AccessToken string `json:"accesstoken"`
+			} `json:"user"`
+		}
+		res.Data(t, &signedIn)
+		if !signedIn.Created || signedIn.User.AccessToken == "" {
+			t.Fatalf("unexpected sign in: %s", res.Body)
+		}
+
+		sessions := h.Do(http.MethodGet, "/api/v1/users/me/sessions", nil, apitest.Bearer(signedIn.User.AccessToken))
+		sessions.AssertStatus(t, http.StatusOK)
+		var found []json.RawMessage
+		sessions.Data(t, &found)
+		if len(found) != 1 {
+			t.Errorf("got %d sessions, want 1", len(found))
+		}
+
+		if count, err := h.Deps.Devices.CountDevices(context.Background(), signedIn.User.Id); err != nil || count != 1 {
+			t.Errorf("got %d devices (%v), want 1", count, err)
+		}
+	})
+
+	t.Run("rejects unverified email", func(t *testing.T) {
+		cfg := apitest.NewConfig(t)
+		idp := apitest.NewIdP(t, cfg)
+		idp.EmailVerified = false
+		h := apitest.NewWithConfig(t, cfg)
+
+		res := h.SocialSignIn()
+		res.AssertStatus(t, http.StatusForbidden)
+		if _, err := h.Deps.Users.GetOne(context.Background(), idp.Email); err == nil {
+			t.Fatal("user is provisioned with unverified email")
+		}
+	})
+
+	t.Run("rejects blocklisted IP", func(t *testing.T) {
+		cfg := apitest.NewConfig(t)
+		apitest.NewIdP(t, cfg)
+		cfg.Risk.Blocklist = []string{"192.0.2.0/24"}
+		h := apitest.NewWithConfig(t, cfg)
+
+		res := h.SocialSignIn()
+		res.AssertStatus(t, http.StatusForbidden)
+		if code := res.Envelope(t).Code; code != "RISK_DENIED" {
+			t.Fatalf("got %s, want RISK_DENIED", code)
+		}
+	})
+
+	t.Run("requires step-up after failed attempts", func(t *testing.T) {
+		cfg := apitest.NewConfig(t)
+		idp := apitest.NewIdP(t, cfg)
+		idp.Email = apitest.Kim.Email
+		h := apitest.NewWithConfig(t, cfg)
+		h.CreateUser(apitest.Kim)
+
+		// 외부 계정 연결 후 비밀번호 로그인 실패 누적
+		h.SocialSignIn(h.SignIn(apitest.Kim)).AssertStatus(t, http.StatusOK)
+		for i := 0; i < 3; i++ {
+			h.Do(http.MethodPost, "/api/v1/auth/sign-in", gin.H{"email": apitest.Kim.Email, "password": "wrong-password"}).AssertStatus(t, http.StatusNotFound)
+		}
+
+		res := h.SocialSignIn()
+		res.AssertStatus(t, http.StatusUnauthorized)
+		var challenge struct {
+			ChallengeID string `json:"challenge_id"`
+		}
+		res.Data(t, &challenge)
+
+		messages := h.Outbox.Messages(apitest.Kim.Phone)
+		if len(messages) == 0 {
+			t.Fatal("step-up code is not sent")
+		}
+		body := messages[len(messages)-1].Body
+		code := body[len(body)-6:]
+
+		restart := h.Do(http.MethodGet, "/api/v1/auth/social/mock?"+url.Values{"challenge_id": {challenge.ChallengeID}, "code": {code}}.Encode(), nil)
+		restart.AssertStatus(t, http.StatusFound)
+		h.SocialCallback(restart.Header.Get("Location")).AssertStatus(t, http.StatusOK)
+	})
+}
+
+func TestSocialSignInAudit(t *testing.T) {
+	cfg := apitest.NewConfig(t)
+	idp := apitest.NewIdP(t, cfg)
+	h := apitest.NewWithConfig(t, cfg)
+	h.CreateAdmin()
+
+	res := h.SocialSignIn()
+	res.AssertStatus(t, http.StatusOK)
+	var signedIn struct {
+		User struct {
+			Id          string `json:"id"`
+			AccessToken string `json:"accesstoken"`
+		} `json:"user"`
+	}
+	res.Data(t, &signedIn)
+
+	// 이미 연결된 외부 계정으로 다시 계정 연결 요청
+	h.SocialSignIn(apitest.Bearer(signedIn.User.AccessToken)).AssertStatus(t, http.StatusOK)
+
+	res = h.Do(http.MethodGet, "/api/v1/admin/audit-events?actor_id="+signedIn.User.Id, nil, h.SignIn(apitest.Admin))
+	res.AssertStatus(t, http.StatusOK)
+	var found struct {
+		Events []struct {
+			Action     string `json:"action"`
+			Outcome    string `json:"outcome"`
+			TargetID   string `json:"target_id"`
+			Identifier string `json:"identifier"`
+		} `json:"events"`
+	}
+	res.Data(t, &found)
+
+	if len(found.Events) != 1 {
+		t.Fatalf("got %d events, want 1\n%s", len(found.Events), res.Body)
+	}
+	if e := found.Events[0]; e.Action != "sign_in" || e.Outcome != "success" || e.TargetID != signedIn.User.Id || e.Identifier != idp.Email {
+		t.Errorf("unexpected social sign in event: %+v", e)
+	}
+
+	res = h.Do(http.MethodGet, "/api/v1/admin/audit-events?action=identity_link", nil, h.SignIn(apitest.Admin))
+	res.AssertStatus(t, http.StatusOK)
+	res.Data(t, &found)
+	if len(found.Events) != 1 || found.Events[0].Outcome != "success" || found.Events[0].TargetID != "mock:"+idp.Subject {
+		t.Errorf("unexpected identity link events: %s", res.Body)
+	}
+}
+
+func TestWebhooks(t *testing.T) {
+	const secret = "integration-secret-0001"
+
+	var mu sync.Mutex
+	var received []http.Header
+	status := http.StatusOK
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		body, _ := io.ReadAll(r.Body)
+		if err := webhook.Verify(secret, r.Header.Get(webhook.HeaderSignature), body, time.Now(), time.Minute); err != nil {
+			t.Errorf("invalid signature: %v", err)
+		}
+		received = append(received, r.Header)
+		w.WriteHeader(status)
+	}))
+	defer receiver.Close()
+
+	h := apitest.New(t)
+	h.CreateAdmin()
+	admin := h.SignIn(apitest.Admin)
+
+	res := h.Do(http.MethodPost, "/api/v1/admin/webhooks", gin.H{
+		"url":         receiver.URL,
+		"secret":      secret,
+		"event_types": []string{"user.registered"},
+	}, admin)
+	res.AssertStatus(t, http.StatusCreated)
+	var endpoint struct {
+		Id     string `json:"id"`
+		Secret string `json:"secret"`
+	}
+	res.Data(t, &endpoint)
+	if endpoint.Secret != secret {
+		t.Fatalf("got secret %q", endpoint.Secret)
+	}
+
+	t.Run("rejects unknown event type", func(t *testing.T) {
+		h.Do(http.MethodPost, "/api/v1/admin/webhooks", gin.H{"url": receiver.URL, "event_types": []string{"user.unknown"}}, admin).AssertStatus(t, http.StatusBadRequest)
+	})
+
+	t.Run("requires admin", func(t *testing.T) {
+		h.CreateUser(apitest.Kim)
+		h.Do(http.MethodGet, "/api/v1/admin/webhooks", nil, h.SignIn(apitest.Kim)).AssertStatus(t, http.StatusForbidden)
+	})
+
+	t.Run("delivers signed user events", func(t *testing.T) {
+		h.Do(http.MethodPost, "/api/v1/auth/sign-up", apitest.Lee.SignUpBody(h.IssueAuthNumber())).AssertStatus(t, http.StatusOK)
+
+		if delivered := h.DeliverWebhooks(); delivered != 1 {
+			t.Fatalf("delivered %d webhooks, want 1", delivered)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if len(received) != 1 || received[0].Get(webhook.HeaderEventType) != "user.registered" {
+			t.Fatalf("unexpected requests: %v", received)
+		}
+	})
+
+	t.Run("records failures and replays dead deliveries", func(t *testing.T) {
+		mu.Lock()
+		status = http.StatusInternalServerError
+		mu.Unlock()
+
+		test := h.Do(http.MethodPost, "/api/v1/admin/webhooks/"+endpoint.Id+"/test", nil, admin)
+		test.AssertStatus(t, http.StatusAccepted)
+		var queued struct {
+			Id string `json:"id"`
+		}
+		test.Data(t, &queued)
+
+		// DeliverWebhooks 는 한번 실패하면 dead 로 처리
+		if delivered := h.DeliverWebhooks(); delivered != 0 {
+			t.Fatalf("delivered %d webhooks, want 0", delivered)
+		}
+
+		res := h.Do(http.MethodGet, "/api/v1/admin/webhook-deliveries?status=dead&endpoint_id="+endpoint.Id, nil, admin)
+		res.AssertStatus(t, http.StatusOK)
+		var found struct {
+			Deliveries []struct {
+				Id        string `json:"id"`
+				EventType string `json:"event_type"`
+				Logs      []struct {
+					StatusCode int `json:"status_code"`
+				} `json:"logs"`
+			} `json:"deliveries"`
+			Total int64 `json:"total"`
+		}
+		res.Data(t, &found)
+		if found.Total != 1 || found.Deliveries[0].Id != queued.Id || found.Deliveries[0].EventType != "webhook.test" || found.Deliveries[0].Logs[0].StatusCode != http.StatusInternalServerError {
+			t.Fatalf("unexpected dead deliveries: %s", res.Body)
+		}
+
+		mu.Lock()
+		status = http.StatusOK
+		mu.Unlock()
+
+		h.Do(http.MethodPost, "/api/v1/admin/webhook-deliveries/"+queued.Id+"/replay", nil, admin).AssertStatus(t, http.StatusAccepted)
+		if delivered := h.DeliverWebhooks(); delivered != 1 {
+			t.Fatalf("delivered %d webhooks after replay, want 1", delivered)
+		}
+	})
+
+	t.Run("deletes webhook", func(t *testing.T) {
+		h.Do(http.MethodDelete, "/api/v1/admin/webhooks/"+endpoint.Id, nil, admin).AssertStatus(t, http.StatusOK)
+		h.Do(http.MethodPost, "/api/v1/admin/webhooks/"+endpoint.Id+"/test", nil, admin).AssertStatus(t, http.StatusNotFound)
+	})
+}
+
+func TestRegisterClient(t *testing.T) {
+	h := apitest.New(t)
+	h.CreateUser(apitest.Kim)
+	h.CreateAdmin()
+	kim := h.SignIn(apitest.Kim)
+	admin := h.SignIn(apitest.Admin)
+
+	client := gin.H{"client_name": "batch", "grant_types": []string{"client_credentials"}, "scope": "users:read"}
+
+	t.Run("rejects non admin", func(t *testing.T) {
+		h.Do(http.MethodPost, "/api/v1/oauth/clients", client, kim).AssertStatus(t, http.StatusForbidden)
+	})
+
+	t.Run("registers client", func(t *testing.T) {
+		res := h.Do(http.MethodPost, "/api/v1/oauth/clients", client, admin)
+		res.AssertStatus(t, http.StatusCreated)
+
+		var found struct {
+			ClientID     string `json:"client_id"`
+			ClientSecret string `json:"client_secret"`
+			Scope        string `json:"scope"`
+		}
+		res.Data(t, &found)
+		if found.ClientID == "" || found.ClientSecret == "" || found.Scope != "users:read" {
+			t.Fatalf("unexpected client: %s", res.Body)
+		}
+	})
+
+	t.Run("rejects unknown scope", func(t *testing.T) {
+		unknown := gin.H{"client_name": "batch", "grant_types": []string{"client_credentials"}, "scope": "users:read admin:all"}
+		h.Do(http.MethodPost, "/api/v1/oauth/clients", unknown, admin).AssertStatus(t, http.StatusBadRequest)
+	})
+}
+
+func TestClientCredentials(t *testing.T) {
+	h := apitest.New(t)
+	userID := h.CreateUser(apitest.Kim)
+	h.CreateAdmin()
+
+	res := h.Do(http.MethodPost, "/api/v1/oauth/clients", gin.H{"client_name": "batch", "grant_types": []string{"client_credentials"}, "scope": "users:read"}, h.SignIn(apitest.Admin))
+	res.AssertStatus(t, http.StatusCreated)
+	var client struct {
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+	}
+	res.Data(t, &client)
+
+	form := apitest.Header{"Content-Type": "application/x-www-form-urlencoded"}
+	token := func(t *testing.T, secret, scope string) *apitest.Response {
+		t.Helper()
+		body := url.Values{"grant_type": {"client_credentials"}, "client_id": {client.ClientID}, "client_secret": {secret}}
+		if len(scope) > 0 {
+			body.Set("scope", scope)
+		}
+		return h.Do(http.MethodPost, "/api/oauth/token", body.Encode(), form)
+	}
+	oauthError := func(t *testing.T, res *apitest.Response, status int, code string) {
+		t.Helper()
+		res.AssertStatus(t, status)
+
+		var found struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(res.Body, &found); err != nil {
+			t.Fatal(err)
+		}
+		if found.Error != code {
+			t.Fatalf("got error %q, want %q: %s", found.Error, code, res.Body)
+		}
+	}
+
+	t.Run("issues token for granted scope", func(t *testing.T) {
+		res := token(t, client.ClientSecret, "users:read")
+		res.AssertStatus(t, http.StatusOK)
+
+		var issued struct {
+			AccessToken string `json:"access_token"`
+			Scope       string `json:"scope"`
+		}
+		if err := json.Unmarshal(res.Body, &issued); err != nil {
+			t.Fatal(err)
+		}
+		if issued.AccessToken == "" || issued.Scope != "users:read" {
+			t.Fatalf("unexpected token response: %s", res.Body)
+		}
+
+		h.Do(http.MethodGet, "/api/v1/users/"+userID, nil, apitest.Bearer(issued.AccessToken)).AssertStatus(t, http.StatusOK)
+	})
+
+	t.Run("rejects wrong secret", func(t *testing.T) {
+		oauthError(t, token(t, "wrong", ""), http.StatusUnauthorized, "invalid_client")
+	})
+
+	t.Run("rejects scope not granted to client", func(t *testing.T) {
+		// AllowedScopes 에 있지만 클라이언트에 부여하지 않은 scope
+		oauthError(t, token(t, client.ClientSecret, "users:read audit:read"), http.StatusBadRequest, "invalid_scope")
+		// AllowedScopes 에 없는 scope
+		oauthError(t, token(t, client.ClientSecret, "admin:all"), http.StatusBadRequest, "invalid_scope")
+	})
+
+	t.Run("rejects client token on user only routes", func(t *testing.T) {
+		res := token(t, client.ClientSecret, "")
+		res.AssertStatus(t, http.StatusOK)
+		var issued struct {
+			AccessToken string `json:"access_token"`
+		}
+		if err := json.Unmarshal(res.Body, &issued); err != nil {
+			t.Fatal(err)
+		}
+		bearer := apitest.Bearer(issued.AccessToken)
+
+		h.Do(http.MethodGet, "/api/v1/users/me/sessions", nil, bearer).AssertStatus(t, http.StatusForbidden)
+		h.Do(http.MethodGet, "/api/v1/users/me/api-keys", nil, bearer).AssertStatus(t, http.StatusForbidden)
+		h.Do(http.MethodPut, "/api/v1/users/me/locale", gin.H{"locale": "en"}, bearer).AssertStatus(t, http.StatusForbidden)
+		h.Do(http.MethodDelete, "/api/v1/users/me", nil, bearer).AssertStatus(t, http.StatusForbidden)
+		h.Do(http.MethodGet, "/api/oauth/userinfo", nil, bearer).AssertStatus(t, http.StatusUnauthorized)
+	})
+}
+
+func TestAuthorizationCode(t *testing.T) {
+	h := apitest.New(t)
+	kimID := h.CreateUser(apitest.Kim)
+	leeID := h.CreateUser(apitest.Lee)
+	h.CreateAdmin()
+	admin := h.SignIn(apitest.Admin)
+
+	const redirectURI = "https://app.example.com/callback"
+	registered := h.Do(http.MethodPost, "/api/v1/oauth/clients", gin.H{"client_name": "app", "redirect_uris": []string{redirectURI}}, admin)
+	registered.AssertStatus(t, http.StatusCreated)
+	var client struct {
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+	}
+	registered.Data(t, &client)
+
+	// PKCE S256: code_challenge = BASE64URL(SHA256(code_verifier))
+	const verifier = "dBjftJeZ4CVP-mJ92K1ZuYMtuJQ6a2kC8H7RtK0qVgM"
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	form := apitest.Header{"Content-Type": "application/x-www-form-urlencoded"}
+	authorize := func(f apitest.Fixture) *apitest.Response {
+		return h.Do(http.MethodPost, "/api/oauth/authorize", url.Values{
+			"response_type":         {"code"},
+			"client_id":             {client.ClientID},
+			"redirect_uri":          {redirectURI},
+			"scope":                 {"openid email"},
+			"state":                 {"xyz"},
+			"code_challenge":        {challenge},
+			"code_challenge_method": {"S256"},
+			"identifier":            {f.Email},
+			"password":              {f.Password},
+		}.Encode(), form)
+	}
+	issueCode := func(t *testing.T) string {
+		res := authorize(apitest.Kim)
+		res.AssertStatus(t, http.StatusFound)
+		location, err := url.Parse(res.Header.Get("Location"))
+		if err != nil || !strings.HasPrefix(location.String(), redirectURI) || location.Query().Get("state") != "xyz" {
+			t.Fatalf("unexpected redirect: %s", res.Header.Get("Location"))
+		}
+		code := location.Query().Get("code")
+		if code == "" {
+			t.Fatalf("no code in redirect: %s", location)
+		}
+		return code
+	}
+	exchange := func(code, codeVerifier string) *apitest.Response {
+		return h.Do(http.MethodPost, "/api/oauth/token", url.Values{
+			"grant_type":    {"authorization_code"},
+			"code":          {code},
+			"redirect_uri":  {redirectURI},
+			"client_id":     {client.ClientID},
+			"client_secret": {client.ClientSecret},
+			"code_verifier": {codeVerifier},
+		}.Encode(), form)
+	}
+	assertOAuthError := func(t *testing.T, res *apitest.Response, status int, want string) {
+		t.Helper()
+		res.AssertStatus(t, status)
+		var found struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(res.Body, &found); err != nil || found.Error != want {
+			t.Fatalf("got %s, want error %s", res.Body, want)
+		}
+	}
+
+	t.Run("exchanges code with verifier and serves userinfo", func(t *testing.T) {
+		res := exchange(issueCode(t), verifier)
+		res.AssertStatus(t, http.StatusOK)
+		var token struct {
+			AccessToken string `json:"access_token"`
+			IDToken     string `json:"id_token"`
+			Scope       string `json:"scope"`
+		}
+		if err := json.Unmarshal(res.Body, &token); err != nil || token.AccessToken == "" || token.IDToken == "" {
+			t.Fatalf("unexpected token response: %s", res.Body)
+		}
+
+		info := h.Do(http.MethodGet, "/api/oauth/userinfo", nil, apitest.Bearer(token.AccessToken))
+		info.AssertStatus(t, http.StatusOK)
+		var claims struct {
+			Sub   string `json:"sub"`
+			Email string `json:"email"`
+		}
+		if err := json.Unmarshal(info.Body, &claims); err != nil || claims.Sub != kimID || claims.Email != apitest.Kim.Email {
+			t.Fatalf("unexpected userinfo: %s", info.Body)
+		}
+	})
+
+	t.Run("rejects reused code", func(t *testing.T) {
+		code := issueCode(t)
+		exchange(code, verifier).AssertStatus(t, http.StatusOK)
+		assertOAuthError(t, exchange(code, verifier), http.StatusBadRequest, "invalid_grant")
+	})
+
+	t.Run("rejects wrong verifier", func(t *testing.T) {
+		code := issueCode(t)
+		assertOAuthError(t, exchange(code, "wrong-verifier-wrong-verifier-wrong-verifier"), http.StatusBadRequest, "invalid_grant")
+		// 검증에 실패한 코드는 올바른 verifier 로도 다시 사용할 수 없음
+		assertOAuthError(t, exchange(code, verifier), http.StatusBadRequest, "invalid_grant")
+	})
+
+	t.Run("rejects userinfo without valid token", func(t *testing.T) {
+		h.Do(http.MethodGet, "/api/oauth/userinfo", nil, apitest.Bearer("invalid")).AssertStatus(t, http.StatusUnauthorized)
+	})
+
+	t.Run("renders login again on wrong password", func(t *testing.T) {
+		wrong := apitest.Kim
+		wrong.Password = "wrong-password"
+		authorize(wrong).AssertStatus(t, http.StatusUnauthorized)
+	})
+
+	t.Run("rejects user who must reset password", func(t *testing.T) {
+		h.RequirePasswordReset(leeID)
+		res := authorize(apitest.Lee)
+		res.AssertStatus(t, http.StatusForbidden)
+		if res.Header.Get("Location") != "" {
+			t.Fatalf("unexpected redirect: %s", res.Header.Get("Location"))
+		}
+	})
+}
+
+func TestOpenAPI(t *testing.T) {
+	t.Run("serves the document", func(t *testing.T) {
+		h := apitest.New(t)
+
+		h.Do(http.MethodGet, "/api/openapi.json", nil).AssertGolden(t, "openapi")
+	})
+
+	t.Run("documents every route of NewController", func(t *testing.T) {
+		h := apitest.New(t)
+
+		var doc openapi.Document
+		if err := json.Unmarshal(h.Do(http.MethodGet, "/api/openapi.json", nil).Body, &doc); err != nil {
+			t.Fatal(err)
+		}
+		documented := doc.Operations()
+
+		// 의존성 없이 라우트만 등록
+		engine := gin.New()
+		api.NewController(engine, validator.New(), nil, nil, nil, nil, nil, nil, nil, func(c *gin.Context) {})
+
+		routes := engine.Routes()
+		for _, route := range routes {
+			key := route.Method + " " + openapi.ToPath(route.Path)
+			op, ok := documented[key]
+			if !ok {
+				t.Errorf("%s is not documented (add it to userRoutes)", key)
+				continue
+			}
+			if !strings.HasSuffix(route.Handler, "."+op.OperationID+"-fm") {
+				t.Errorf("%s: documented as %s, handled by %s", key, op.OperationID, route.Handler)
+			}
+			delete(documented, key)
+		}
+		for key := range documented {
+			t.Errorf("%s is documented but not registered", key)
+		}
+	})
+
+	t.Run("serves Swagger UI", func(t *testing.T) {
+		h := apitest.New(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/docs", nil)
+		rec := httptest.NewRecorder()
+		h.Engine.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/html") {
+			t.Fatalf("got %d %s", rec.Code, rec.Header().Get("Content-Type"))
+		}
+		if !strings.Contains(rec.Body.String(), `\/api\/openapi.json`) {
+			t.Fatalf("page does not load the document:\n%s", rec.Body)
+		}
+	})
+}
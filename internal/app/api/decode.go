@@ -0,0 +1,131 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/app/api/middleware"
+	"signupin-api/internal/pkg/i18n"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/kkodecaffeine/go-common/errorcode"
+
+	"github.com/kkodecaffeine/go-common/rest"
+)
+
+// 요청 본문을 해석할 수 없는 경우의 응답 코드
+var (
+	CodeMalformedJSON        = errorcode.CodeDescription{HttpStatusCode: 400, Code: "MALFORMED_JSON", Message: "요청 본문이 올바른 JSON 형식이 아닙니다.▸ "}
+	CodeInvalidType          = errorcode.CodeDescription{HttpStatusCode: 400, Code: "INVALID_TYPE", Message: "입력 값의 타입이 올바르지 않습니다.▸ "}
+	CodeUnknownField         = errorcode.CodeDescription{HttpStatusCode: 400, Code: "UNKNOWN_FIELD", Message: "알 수 없는 입력 항목입니다.▸ "}
+	CodeBodyTooLarge         = errorcode.CodeDescription{HttpStatusCode: 413, Code: "BODY_TOO_LARGE", Message: "요청 본문이 너무 큽니다.▸ "}
+	CodeUnsupportedMediaType = errorcode.CodeDescription{HttpStatusCode: 415, Code: "UNSUPPORTED_MEDIA_TYPE", Message: "지원하지 않는 Content-Type 입니다.▸ "}
+)
+
+// encoding/json 은 알 수 없는 필드 오류를 별도 타입 없이 반환
+const unknownFieldPrefix = "json: unknown field "
+
+// decodeJSON 은 요청 본문 JSON 을 req 로 읽음 (검증은 하지 않음)
+// Content-Type 이 없으면 JSON 으로 간주하고, 본문이 비어있으면 req 를 그대로 두어 필수 항목 검증에서 응답
+func decodeJSON(c *gin.Context, req interface{}) *rest.CustomError {
+	locale := middleware.Locale(c)
+
+	if contentType := c.ContentType(); len(contentType) > 0 && contentType != binding.MIMEJSON && !strings.HasSuffix(contentType, "+json") {
+		return &rest.CustomError{CodeDesc: &CodeUnsupportedMediaType, Message: i18n.T(locale, "decode.media_type_only", contentType, binding.MIMEJSON)}
+	}
+	if c.Request.Body == nil || c.Request.Body == http.NoBody {
+		return nil
+	}
+
+	decoder := json.NewDecoder(c.Request.Body)
+	if middleware.RejectUnknownFields(c) {
+		decoder.DisallowUnknownFields()
+	}
+
+	err := decoder.Decode(req)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err == nil {
+		// 값 하나 뒤에 남은 내용이 있으면 잘못된 본문
+		if _, err = decoder.Token(); errors.Is(err, io.EOF) {
+			return nil
+		} else if err == nil {
+			return &rest.CustomError{CodeDesc: &CodeMalformedJSON, Message: i18n.T(locale, "decode.trailing_data")}
+		}
+	}
+
+	return toDecodeError(err, locale)
+}
+
+func toDecodeError(err error, locale string) *rest.CustomError {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var sizeErr *http.MaxBytesError
+
+	switch {
+	case errors.As(err, &sizeErr):
+		return &rest.CustomError{CodeDesc: &CodeBodyTooLarge, Message: i18n.T(locale, "decode.too_large", strconv.FormatInt(sizeErr.Limit, 10))}
+	case errors.As(err, &syntaxErr):
+		return &rest.CustomError{CodeDesc: &CodeMalformedJSON, Message: i18n.T(locale, "decode.syntax", strconv.FormatInt(syntaxErr.Offset, 10), strings.TrimPrefix(syntaxErr.Error(), "json: "))}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return &rest.CustomError{CodeDesc: &CodeMalformedJSON, Message: i18n.T(locale, "decode.unexpected_eof")}
+	case errors.As(err, &typeErr):
+		field := typeErr.Field
+		if len(field) == 0 {
+			field = "(body)"
+		}
+		want := jsonType(typeErr.Type)
+		return &rest.CustomError{
+			CodeDesc: &CodeInvalidType,
+			Message:  field,
+			Data: dto.ValidationErrorResponse{Errors: []dto.ValidationError{{
+				Field:   field,
+				Rule:    "type",
+				Param:   want,
+				Message: i18n.T(locale, "rule.type", want, typeErr.Value),
+			}}},
+		}
+	case strings.HasPrefix(err.Error(), unknownFieldPrefix):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), unknownFieldPrefix), `"`)
+		return &rest.CustomError{
+			CodeDesc: &CodeUnknownField,
+			Message:  field,
+			Data: dto.ValidationErrorResponse{Errors: []dto.ValidationError{{
+				Field:   field,
+				Rule:    "unknown",
+				Message: i18n.T(locale, "rule.unknown"),
+			}}},
+		}
+	}
+	return &rest.CustomError{CodeDesc: &CodeMalformedJSON, Message: err.Error()}
+}
+
+// jsonType 은 Go 타입에 해당하는 JSON 타입 이름
+func jsonType(t reflect.Type) string {
+	if t == reflect.TypeOf(time.Time{}) {
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Ptr:
+		return jsonType(t.Elem())
+	}
+	return "object"
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+// API 키 발급
+type PostAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required"`                                                                  // 키 이름
+	Scopes    []string   `json:"scopes" binding:"omitempty,dive,oneof=users:read clients:write audit:read webhooks:write"` // 부여할 scope
+	ExpiresAt *time.Time `json:"expires_at"`                                                                               // 만료 시각 (빈 값은 만료 없음)
+}
+
+type PostAPIKeyResponse struct {
+	Key string `json:"key"` // API 키 (발급 시 1회만 노출)
+	GetAPIKeyResponse
+}
+
+// API 키 조회
+type GetAPIKeyResponse struct {
+	Id         string     `json:"id"`           // 아이디
+	Name       string     `json:"name"`         // 키 이름
+	Prefix     string     `json:"prefix"`       // 키 앞부분
+	Scopes     []string   `json:"scopes"`       // 부여된 scope
+	CreatedAt  time.Time  `json:"createdAt"`    // 발급 시각
+	ExpiresAt  *time.Time `json:"expires_at"`   // 만료 시각
+	LastUsedAt *time.Time `json:"last_used_at"` // 마지막 사용 시각
+	RevokedAt  *time.Time `json:"revoked_at"`   // 폐기 시각
+}
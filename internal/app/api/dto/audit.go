@@ -0,0 +1,53 @@
+package dto
+
+import "time"
+
+// 감사 로그 조회 요청 (관리자)
+type GetAuditEventsRequest struct {
+	Action   string    `form:"action"`                                            // 이벤트 종류 (ex. sign_in)
+	Outcome  string    `form:"outcome" binding:"omitempty,oneof=success failure"` // 결과
+	ActorID  string    `form:"actor_id"`                                          // 요청 주체 아이디
+	TargetID string    `form:"target_id"`                                         // 대상 아이디
+	IP       string    `form:"ip"`                                                // 접속 IP
+	From     time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`      // 이후 (포함, RFC 3339)
+	To       time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`        // 이전 (제외, RFC 3339)
+	Page     int       `form:"page" binding:"omitempty,min=1"`                    // 페이지 (1 부터, 기본값 1)
+	Size     int       `form:"size" binding:"omitempty,min=1,max=100"`            // 페이지 크기 (기본값 20)
+}
+
+// 감사 로그 조회 결과 (최신순)
+type GetAuditEventsResponse struct {
+	Events []GetAuditEventResponse `json:"events"`
+	Page   int                     `json:"page"`
+	Size   int                     `json:"size"`
+	Total  int64                   `json:"total"` // 조건에 맞는 전체 이벤트 수
+}
+
+type GetAuditEventResponse struct {
+	Seq        int64     `json:"seq"`
+	Action     string    `json:"action"`
+	Outcome    string    `json:"outcome"`
+	Reason     string    `json:"reason,omitempty"`
+	ActorType  string    `json:"actor_type"`
+	ActorID    string    `json:"actor_id,omitempty"`
+	TargetType string    `json:"target_type,omitempty"`
+	TargetID   string    `json:"target_id,omitempty"`
+	Identifier string    `json:"identifier,omitempty"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	RequestID  string    `json:"request_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+	PrevHash   string    `json:"prev_hash"`
+	Hash       string    `json:"hash"`
+}
+
+// 감사 로그 무결성 확인 결과
+// 마지막 이벤트 이후가 삭제된 경우는 확인할 수 없으므로 LastSeq, LastHash 를 외부에 보관하여 비교
+type GetAuditVerifyResponse struct {
+	Valid    bool   `json:"valid"`
+	Checked  int64  `json:"checked"`             // 확인한 이벤트 수
+	LastSeq  int64  `json:"last_seq"`            // 마지막으로 확인한 순번
+	LastHash string `json:"last_hash"`           // 마지막으로 확인한 해시
+	BrokenAt int64  `json:"broken_at,omitempty"` // 연결이 깨진 순번
+	Reason   string `json:"reason,omitempty"`    // 연결이 깨진 이유
+}
@@ -16,13 +16,17 @@ type PostSignUpRequest struct {
 	Name       string `json:"name" binding:"required" validate:"min=2"`       // 이름
 	Password   string `json:"password" binding:"required" validate:"min=8"`   // 비밀번호
 	Phone      string `json:"phone" binding:"required,customPhone"`           // 전화번호
+	Locale     string `json:"locale" binding:"omitempty,oneof=ko en"`         // 응답 메시지 언어 (선택, 없으면 Accept-Language)
 }
 
 // 회원 로그인
 type PostSignInRequest struct {
-	Email    string `json:"email" binding:"customEmail"` // 이메일
-	Password string `json:"password" binding:"required"` // 비밀번호
-	Phone    string `json:"phone" binding:"customPhone"` // 전화번호
+	Email    string `json:"email" binding:"omitempty,customEmail"` // 이메일
+	Password string `json:"password" binding:"required"`           // 비밀번호
+	Phone    string `json:"phone" binding:"omitempty,customPhone"` // 전화번호
+
+	ChallengeID string `json:"challenge_id"` // 추가 인증 아이디 (추가 인증 요청을 받은 경우)
+	Code        string `json:"code"`         // 추가 인증 코드
 }
 
 // 회원 조회
@@ -32,6 +36,10 @@ type GetUserResponse struct {
 	NickName string `json:"nickname"` // 닉네임
 	Name     string `json:"name"`     // 이름
 	Phone    string `json:"phone"`    // 전화번호
+	Locale   string `json:"locale"`   // 응답 메시지 언어 (설정하지 않았으면 빈 값)
+
+	Passwordless          bool `json:"passwordless"`            // 비밀번호 미설정 여부 (소셜 로그인으로 가입 후 비밀번호를 정하지 않음)
+	PasswordResetRequired bool `json:"password_reset_required"` // 비밀번호 재설정 필요 여부
 }
 
 type GetUserWithTokenResponse struct {
@@ -41,6 +49,14 @@ type GetUserWithTokenResponse struct {
 	NickName    string `json:"nickname"`    // 닉네임
 	Name        string `json:"name"`        // 이름
 	Phone       string `json:"phone"`       // 전화번호
+	Locale      string `json:"locale"`      // 응답 메시지 언어 (설정하지 않았으면 빈 값)
+
+	PasswordResetRequired bool `json:"password_reset_required"` // 비밀번호 재설정 필요 여부
+}
+
+// "본인이 아닙니다" 신고
+type PostNotMeRequest struct {
+	Token string `json:"token" binding:"required"` // 알림 링크에 담긴 토큰
 }
 
 // 비밀번호 수정
@@ -50,4 +66,18 @@ type PutPasswordRequest struct {
 	Password     string `json:"password" binding:"required" validate:"min=8"`     // 비밀번호
 	NewPassword  string `json:"newpassword" binding:"required" validate:"min=8"`  // 신규 비밀번호
 	Confirmation string `json:"confirmation" binding:"required" validate:"min=8"` // 신규 비밀번호 확인
+
+	ChallengeID string `json:"challenge_id"` // 추가 인증 아이디 (추가 인증 요청을 받은 경우)
+	Code        string `json:"code"`         // 추가 인증 코드
+}
+
+// 응답 메시지 언어 설정
+type PutLocaleRequest struct {
+	Locale string `json:"locale" binding:"required,oneof=ko en"` // 언어 (ko, en)
+}
+
+// 회원 탈퇴
+type DeleteUserRequest struct {
+	AuthNumber string `json:"authnumber" binding:"required" validate:"len=6"` // 인증번호
+	Password   string `json:"password" binding:"required" validate:"min=8"`   // 비밀번호
 }
@@ -0,0 +1,19 @@
+package dto
+
+// 프로세스 상태 (/healthz)
+type GetHealthResponse struct {
+	Status string `json:"status"` // ok
+}
+
+// 요청 처리 가능 여부 (/readyz)
+type GetReadyResponse struct {
+	Status string                      `json:"status"` // ready, not_ready, draining
+	Checks map[string]DependencyStatus `json:"checks"` // 의존성별 상태
+}
+
+// 의존성 하나의 상태
+type DependencyStatus struct {
+	Status    string `json:"status"`          // up, down
+	LatencyMs int64  `json:"latency_ms"`      // 확인에 걸린 시간
+	Error     string `json:"error,omitempty"` // 실패 사유
+}
@@ -0,0 +1,106 @@
+package dto
+
+// 클라이언트 등록
+type PostClientRequest struct {
+	ClientName   string   `json:"client_name" binding:"required"`                                                   // 클라이언트 이름
+	RedirectURIs []string `json:"redirect_uris" binding:"omitempty,dive,url"`                                       // 허용할 redirect_uri 목록 (authorization_code 필수)
+	GrantTypes   []string `json:"grant_types" binding:"omitempty,dive,oneof=authorization_code client_credentials"` // 허용할 grant_type 목록 (기본값: authorization_code)
+	Scope        string   `json:"scope"`                                                                            // client_credentials 로 요청 가능한 scope (공백 구분)
+	Public       bool     `json:"public"`                                                                           // 시크릿 없이 PKCE 만 사용하는 클라이언트 여부
+}
+
+type PostClientResponse struct {
+	ClientID     string   `json:"client_id"`               // 클라이언트 아이디
+	ClientSecret string   `json:"client_secret,omitempty"` // 클라이언트 시크릿 (등록 시 1회만 노출)
+	ClientName   string   `json:"client_name"`             // 클라이언트 이름
+	RedirectURIs []string `json:"redirect_uris"`           // 허용된 redirect_uri 목록
+	GrantTypes   []string `json:"grant_types"`             // 허용된 grant_type 목록
+	Scope        string   `json:"scope,omitempty"`         // client_credentials 로 요청 가능한 scope
+}
+
+// 인가 요청
+type GetAuthorizeRequest struct {
+	ResponseType        string `form:"response_type" binding:"required"`         // code 만 지원
+	ClientID            string `form:"client_id" binding:"required"`             // 클라이언트 아이디
+	RedirectURI         string `form:"redirect_uri" binding:"required"`          // redirect_uri
+	Scope               string `form:"scope" binding:"required"`                 // openid 포함 필수
+	State               string `form:"state"`                                    // state
+	Nonce               string `form:"nonce"`                                    // nonce
+	CodeChallenge       string `form:"code_challenge" binding:"required"`        // PKCE code_challenge
+	CodeChallengeMethod string `form:"code_challenge_method" binding:"required"` // S256 만 지원
+}
+
+// 인가 요청 (로그인 폼 제출)
+type PostAuthorizeRequest struct {
+	GetAuthorizeRequest
+	Identifier string `form:"identifier" binding:"required"` // 이메일 혹은 전화번호
+	Password   string `form:"password" binding:"required"`   // 비밀번호
+
+	ChallengeID string `form:"challenge_id"` // 추가 인증 아이디 (추가 인증 요청을 받은 경우)
+	StepUpCode  string `form:"step_up_code"` // 추가 인증 코드
+}
+
+// 토큰 발급
+type PostTokenRequest struct {
+	GrantType    string `form:"grant_type" binding:"required"` // authorization_code, client_credentials
+	Scope        string `form:"scope"`                         // 요청 scope (client_credentials)
+	Code         string `form:"code"`                          // 인가 코드
+	RedirectURI  string `form:"redirect_uri"`                  // 인가 요청 시 사용한 redirect_uri
+	ClientID     string `form:"client_id"`                     // 클라이언트 아이디 (client_secret_post / public)
+	ClientSecret string `form:"client_secret"`                 // 클라이언트 시크릿 (client_secret_post)
+	CodeVerifier string `form:"code_verifier"`                 // PKCE code_verifier
+}
+
+type PostTokenResponse struct {
+	AccessToken string `json:"access_token"`       // 액세스 토큰
+	TokenType   string `json:"token_type"`         // Bearer
+	ExpiresIn   int64  `json:"expires_in"`         // 만료까지 남은 시간 (초)
+	IDToken     string `json:"id_token,omitempty"` // ID 토큰
+	Scope       string `json:"scope,omitempty"`    // 허용된 scope
+}
+
+// 회원 정보 (OIDC userinfo)
+type GetUserInfoResponse struct {
+	Sub         string `json:"sub"`                    // 회원 아이디
+	Email       string `json:"email,omitempty"`        // 이메일
+	Name        string `json:"name,omitempty"`         // 이름
+	NickName    string `json:"nickname,omitempty"`     // 닉네임
+	PhoneNumber string `json:"phone_number,omitempty"` // 전화번호
+}
+
+// OpenID Provider 메타데이터
+type GetDiscoveryResponse struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	RegistrationEndpoint              string   `json:"registration_endpoint"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+}
+
+type GetJWKSResponse struct {
+	Keys []JWK `json:"keys"`
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// RFC 6749 5.2 오류 응답
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
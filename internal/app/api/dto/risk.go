@@ -0,0 +1,10 @@
+package dto
+
+import "time"
+
+// 추가 인증 요청 (위험도 평가 결과 step_up)
+type StepUpResponse struct {
+	ChallengeID string    `json:"challenge_id"` // 추가 인증 아이디 (challenge_id 와 code 를 담아 다시 요청)
+	Methods     []string  `json:"methods"`      // 추가 인증 수단 (sms)
+	ExpiresAt   time.Time `json:"expires_at"`   // 만료 시각
+}
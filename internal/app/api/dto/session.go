@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// 세션 조회
+type GetSessionResponse struct {
+	Id          string    `json:"id"`           // 아이디
+	DeviceLabel string    `json:"device_label"` // 기기 이름
+	UserAgent   string    `json:"user_agent"`   // User-Agent
+	IP          string    `json:"ip"`           // 접속 IP
+	Current     bool      `json:"current"`      // 현재 요청에 사용 중인 세션 여부
+	CreatedAt   time.Time `json:"createdAt"`    // 로그인 시각
+	LastSeenAt  time.Time `json:"last_seen_at"` // 마지막 사용 시각
+	ExpiresAt   time.Time `json:"expires_at"`   // 만료 시각
+}
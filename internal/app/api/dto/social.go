@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+// 소셜 로그인 시작 (추가 인증 요청을 받은 경우 문자로 받은 코드를 담아 다시 시작)
+type GetSocialSignInRequest struct {
+	ChallengeID string `form:"challenge_id"` // 추가 인증 아이디
+	Code        string `form:"code"`         // 추가 인증 코드
+}
+
+// 소셜 로그인 callback
+type GetSocialCallbackRequest struct {
+	Code             string `form:"code"`              // 인가 코드
+	State            string `form:"state"`             // state
+	Error            string `form:"error"`             // 인증 제공자가 전달한 오류 코드
+	ErrorDescription string `form:"error_description"` // 인증 제공자가 전달한 오류 설명
+}
+
+type GetSocialCallbackResponse struct {
+	Created  bool                      `json:"created"`        // 최초 로그인으로 회원 가입이 처리되었는지 여부
+	User     *GetUserWithTokenResponse `json:"user,omitempty"` // 로그인한 회원 정보 (w/ JWT), 계정 연결 요청인 경우 빈 값
+	Identity *GetIdentityResponse      `json:"identity"`       // 연결된 외부 계정
+
+	ChallengeID string `json:"-"` // 소셜 로그인 시작 시 전달한 추가 인증 아이디 (위험도 평가에서 확인)
+	StepUpCode  string `json:"-"` // 소셜 로그인 시작 시 전달한 추가 인증 코드
+}
+
+// 계정 연결 시작
+type GetSocialAuthorizeResponse struct {
+	AuthorizationURL string `json:"authorization_url"` // 인증 제공자의 로그인 화면 주소
+}
+
+// 연결된 외부 계정 조회
+type GetIdentityResponse struct {
+	Provider  string    `json:"provider"`  // 인증 제공자
+	Subject   string    `json:"subject"`   // 인증 제공자의 계정 아이디
+	Email     string    `json:"email"`     // 인증 제공자가 전달한 이메일
+	CreatedAt time.Time `json:"createdAt"` // 연결 시각
+}
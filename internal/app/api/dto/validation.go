@@ -0,0 +1,13 @@
+package dto
+
+// 입력 검증 실패 (MISSING_PARAMETERS, INVALID_PARAMETERS 응답의 data)
+type ValidationErrorResponse struct {
+	Errors []ValidationError `json:"errors"` // 실패한 필드 목록 (필드마다 처음 실패한 규칙 하나)
+}
+
+type ValidationError struct {
+	Field   string `json:"field"`   // 요청 필드 이름 (json / query 이름, 배열 항목은 ex. scopes[0])
+	Rule    string `json:"rule"`    // 실패한 검증 규칙 (ex. required, min, oneof)
+	Param   string `json:"param"`   // 규칙 값 (ex. min=8 의 8, 없으면 빈 값)
+	Message string `json:"message"` // 설명
+}
@@ -0,0 +1,62 @@
+package dto
+
+import "time"
+
+// 웹훅 등록 (관리자)
+type PostWebhookRequest struct {
+	URL         string   `json:"url" binding:"required,url"`                // 수신 주소 (http, https)
+	Secret      string   `json:"secret" binding:"omitempty,min=16,max=128"` // 서명 키 (빈 값은 생성)
+	EventTypes  []string `json:"event_types" binding:"required,min=1"`      // 구독할 이벤트 종류 (ex. user.registered)
+	Description string   `json:"description" binding:"max=200"`             // 설명
+}
+
+type PostWebhookResponse struct {
+	Secret string `json:"secret"` // 서명 키 (등록 시 1회만 노출)
+	GetWebhookResponse
+}
+
+// 웹훅 조회
+type GetWebhookResponse struct {
+	Id          string    `json:"id"`          // 아이디
+	URL         string    `json:"url"`         // 수신 주소
+	EventTypes  []string  `json:"event_types"` // 구독하는 이벤트 종류
+	Description string    `json:"description"` // 설명
+	CreatedBy   string    `json:"created_by"`  // 등록한 관리자
+	CreatedAt   time.Time `json:"createdAt"`   // 등록 시각
+}
+
+// 웹훅 전달 기록 조회 (관리자)
+type GetWebhookDeliveriesRequest struct {
+	EndpointID string `form:"endpoint_id"`                                                    // 웹훅 아이디
+	EventID    string `form:"event_id"`                                                       // 이벤트 아이디
+	Status     string `form:"status" binding:"omitempty,oneof=pending failed succeeded dead"` // 상태
+	Page       int    `form:"page" binding:"omitempty,min=1"`                                 // 페이지 (1 부터, 기본값 1)
+	Size       int    `form:"size" binding:"omitempty,min=1,max=100"`                         // 페이지 크기 (기본값 20)
+}
+
+// 웹훅 전달 기록 조회 결과 (최신순)
+type GetWebhookDeliveriesResponse struct {
+	Deliveries []GetWebhookDeliveryResponse `json:"deliveries"`
+	Page       int                          `json:"page"`
+	Size       int                          `json:"size"`
+	Total      int64                        `json:"total"` // 조건에 맞는 전체 전달 수
+}
+
+type GetWebhookDeliveryResponse struct {
+	Id            string                      `json:"id"`
+	EndpointID    string                      `json:"endpoint_id"`
+	EventID       string                      `json:"event_id"`
+	EventType     string                      `json:"event_type"`
+	Status        string                      `json:"status"`   // pending, failed, succeeded, dead
+	Attempts      int                         `json:"attempts"` // 시도 횟수 (다시 전달하면 0 부터)
+	NextAttemptAt time.Time                   `json:"next_attempt_at"`
+	CreatedAt     time.Time                   `json:"createdAt"`
+	Logs          []GetWebhookAttemptResponse `json:"logs"` // 시도별 결과 (오래된 순)
+}
+
+type GetWebhookAttemptResponse struct {
+	StatusCode int       `json:"status_code"` // 응답 코드 (연결 실패는 0)
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	At         time.Time `json:"at"`
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/pkg/health"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kkodecaffeine/go-common/rest"
+)
+
+type HealthController struct {
+	checker *health.Checker
+}
+
+// NewHealthController returns new health controller instance
+// 오케스트레이터가 호출하는 경로이므로 /api 밖에 등록하고 CORS, 요청 수 제한을 적용하지 않음
+func NewHealthController(e *gin.Engine, checker *health.Checker) HealthController {
+	ctrl := HealthController{checker}
+
+	e.GET("/healthz", ctrl.Live)
+	e.GET("/readyz", ctrl.Ready)
+
+	return ctrl
+}
+
+/**
+ * 프로세스 상태 API (liveness)
+ * 의존성을 확인하지 않고 프로세스가 요청에 응답할 수 있으면 성공
+ */
+func (ctrl *HealthController) Live(c *gin.Context) {
+	response := rest.NewApiResponse()
+
+	response.Succeed("", dto.GetHealthResponse{Status: "ok"})
+	c.JSON(http.StatusOK, response)
+}
+
+/**
+ * 요청 처리 가능 여부 API (readiness)
+ * MongoDB / SQL DB 연결, SMS 발송, 서명 키를 확인하고 의존성별 상태 반환
+ * 의존성이 하나라도 실패하거나 종료 중이면 503 (NOT_READY)
+ */
+func (ctrl *HealthController) Ready(c *gin.Context) {
+	response := rest.NewApiResponse()
+
+	result, ready := ctrl.checker.Check(c.Request.Context())
+	if !ready {
+		response.Error(&health.CodeNotReady, result.Status, result)
+		c.JSON(health.CodeNotReady.HttpStatusCode, response)
+		return
+	}
+
+	response.Succeed("", result)
+	c.JSON(http.StatusOK, response)
+}
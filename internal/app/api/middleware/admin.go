@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kkodecaffeine/go-common/errorcode"
+)
+
+// Admin 은 관리자 API 를 선언, ADMIN_SUBJECTS 에 등록된 회원 / 클라이언트만 허용
+// 클라이언트 토큰 / API 키는 Scopes 로 scope 를 함께 확인
+func Admin(subjects []string) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(subjects))
+	for _, subject := range subjects {
+		allowed[subject] = true
+	}
+
+	return func(c *gin.Context) {
+		principal := GetPrincipal(c)
+		if principal == nil {
+			abort(c, &errorcode.ACCESS_DENIED, "unauthorized")
+			return
+		}
+
+		if !allowed[principal.Subject] {
+			abort(c, &errorcode.FORBIDDEN_REQUEST, "admin only")
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"signupin-api/internal/pkg/apikey"
+	"signupin-api/internal/pkg/oidc"
+	"signupin-api/internal/pkg/reqctx"
+	"signupin-api/internal/pkg/session"
+	"signupin-api/internal/pkg/user"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kkodecaffeine/go-common/errorcode"
+	"github.com/kkodecaffeine/go-common/rest"
+	"github.com/kkodecaffeine/go-common/utils"
+)
+
+const principalKey = "principal"
+
+// 인증 주체 종류
+const (
+	PrincipalUser   = "user"   // 회원 로그인 API 로 발급된 JWT
+	PrincipalClient = "client" // client_credentials 로 발급된 클라이언트 토큰
+	PrincipalAPIKey = "apikey" // 회원이 발급한 API 키 (Subject 는 회원 아이디)
+)
+
+// Principal 은 요청을 보낸 인증 주체
+type Principal struct {
+	Kind      string   // PrincipalUser, PrincipalClient, PrincipalAPIKey
+	Subject   string   // 회원 아이디 혹은 클라이언트 아이디
+	Scopes    []string // 클라이언트 토큰 / API 키의 scope
+	SessionID string   // 회원 토큰이 묶여있는 세션 아이디
+
+	PasswordResetRequired bool   // 비밀번호 재설정 전까지 비밀번호 수정 API 만 허용
+	Locale                string // 회원이 설정한 응답 메시지 언어 (회원 토큰만)
+}
+
+func (p *Principal) hasAnyScope(accepted []string) bool {
+	for _, scope := range p.Scopes {
+		for _, expected := range accepted {
+			if scope == expected {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Authenticate 는 회원 JWT, 클라이언트 토큰 혹은 API 키를 검증한 뒤 Principal 을 gin.Context 에 저장
+// API 키는 X-API-Key 헤더 혹은 Authorization: Bearer sk_... 로 전달
+// 회원 JWT 는 세션이 종료되지 않았고 회원이 있는 경우에만 허용
+// 회원 JWT 와 API 키는 회원이 비밀번호를 재설정해야 하면 PasswordResetRequired 로 표시
+func Authenticate(clients oidc.Usecase, keys apikey.Usecase, sessions session.Usecase, users user.Usecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString := utils.ExtractToken(c)
+		if key := c.GetHeader("X-API-Key"); len(key) > 0 {
+			tokenString = key
+		}
+
+		if apikey.IsAPIKey(tokenString) {
+			userID, scopes, err := keys.Authenticate(c.Request.Context(), tokenString)
+			if err != nil {
+				abort(c, &errorcode.ACCESS_DENIED, "unauthorized")
+				return
+			}
+
+			// 키 주인이 비밀번호를 재설정해야 하면 회원 토큰과 같이 차단 (탈퇴한 회원의 키는 거부)
+			owner, cerr := users.GetOneByID(c.Request.Context(), userID)
+			if cerr != nil {
+				abort(c, &errorcode.ACCESS_DENIED, "unauthorized")
+				return
+			}
+
+			setPrincipal(c, &Principal{Kind: PrincipalAPIKey, Subject: userID, Scopes: scopes, PasswordResetRequired: owner.PasswordResetRequired})
+			c.Next()
+			return
+		}
+
+		if clientID, scopes, err := clients.VerifyClientToken(c.Request.Context(), tokenString); err == nil {
+			setPrincipal(c, &Principal{Kind: PrincipalClient, Subject: clientID, Scopes: scopes})
+			c.Next()
+			return
+		}
+
+		claims, err := sessions.ParseToken(tokenString)
+		if err != nil {
+			abort(c, &errorcode.ACCESS_DENIED, "unauthorized")
+			return
+		}
+
+		if err := sessions.Verify(c.Request.Context(), claims.UserID, claims.SessionID); err != nil {
+			abort(c, &errorcode.ACCESS_DENIED, "unauthorized")
+			return
+		}
+
+		// 탈퇴한 회원의 토큰은 세션이 남아있어도 거부
+		if _, err := users.GetOneByID(c.Request.Context(), claims.UserID); err != nil {
+			abort(c, &errorcode.ACCESS_DENIED, "unauthorized")
+			return
+		}
+
+		setPrincipal(c, &Principal{
+			Kind:                  PrincipalUser,
+			Subject:               claims.UserID,
+			SessionID:             claims.SessionID,
+			PasswordResetRequired: claims.PasswordResetRequired,
+			Locale:                claims.Locale,
+		})
+		c.Next()
+	}
+}
+
+// Scopes 는 라우트가 허용하는 클라이언트 / API 키 scope 를 선언
+// 회원 토큰은 항상 통과하며, 클라이언트 토큰과 API 키는 허용된 scope 중 하나 이상을 가지고 있어야 함
+// 허용 scope 가 없는 라우트는 회원 토큰 전용
+// 비밀번호 재설정이 필요한 회원 토큰은 PasswordReset 으로 선언한 라우트만 허용
+func Scopes(accepted ...string) gin.HandlerFunc {
+	return authorize(accepted, false)
+}
+
+// PasswordReset 은 비밀번호 재설정이 필요한 회원 토큰도 허용하는 회원 전용 라우트를 선언
+func PasswordReset() gin.HandlerFunc {
+	return authorize(nil, true)
+}
+
+func authorize(accepted []string, allowPendingReset bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := GetPrincipal(c)
+		if principal == nil {
+			abort(c, &errorcode.ACCESS_DENIED, "unauthorized")
+			return
+		}
+
+		if principal.Kind != PrincipalUser && !principal.hasAnyScope(accepted) {
+			abort(c, &errorcode.FORBIDDEN_REQUEST, "insufficient scope")
+			return
+		}
+
+		if principal.PasswordResetRequired && !allowPendingReset {
+			abort(c, &errorcode.RESOLVE_REQUIRED_ACTIONS, "")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// setPrincipal 은 Principal 을 gin.Context 에 저장하고 회원 아이디를 요청 context 에 담음
+func setPrincipal(c *gin.Context, principal *Principal) {
+	c.Set(principalKey, principal)
+	if principal.Kind != PrincipalClient {
+		c.Request = c.Request.WithContext(reqctx.WithUserID(c.Request.Context(), principal.Subject))
+	}
+}
+
+// GetPrincipal 은 Authenticate 에서 저장한 Principal 을 반환
+func GetPrincipal(c *gin.Context) *Principal {
+	value, ok := c.Get(principalKey)
+	if !ok {
+		return nil
+	}
+
+	principal, _ := value.(*Principal)
+	return principal
+}
+
+func abort(c *gin.Context, codeDesc *errorcode.CodeDescription, message string) {
+	response := rest.NewApiResponse()
+	response.Error(codeDesc, message, nil)
+
+	c.JSON(codeDesc.HttpStatusCode, response)
+	c.Abort()
+}
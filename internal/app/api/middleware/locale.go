@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"encoding/json"
+	"strings"
+
+	"signupin-api/internal/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Locale 은 응답 메시지 언어 (회원 토큰에 담긴 회원 설정, 없으면 Accept-Language 헤더)
+func Locale(c *gin.Context) string {
+	if principal := GetPrincipal(c); principal != nil && i18n.IsSupported(principal.Locale) {
+		return principal.Locale
+	}
+	return i18n.Parse(c.GetHeader("Accept-Language"))
+}
+
+// Localize 는 JSON 응답의 응답 코드 메시지를 Locale 로 번역
+// 핸들러와 다른 미들웨어는 응답 코드 정의 (한국어) 그대로 응답하고, 상세 메시지와 data 는 바꾸지 않음
+func Localize() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer = &localizedWriter{ResponseWriter: c.Writer, c: c}
+		c.Next()
+	}
+}
+
+type localizedWriter struct {
+	gin.ResponseWriter
+	c *gin.Context
+}
+
+// localizedResponse 는 rest.ApiResponse 의 JSON 형태 (data 는 그대로 다시 씀)
+type localizedResponse struct {
+	Code    string          `json:"code"`
+	Message string          `json:"message,omitempty"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func (w *localizedWriter) Write(b []byte) (int, error) {
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), gin.MIMEJSON) {
+		return w.ResponseWriter.Write(b)
+	}
+
+	locale := Locale(w.c)
+	w.Header().Set("Content-Language", locale)
+	if locale == i18n.Default {
+		return w.ResponseWriter.Write(b)
+	}
+
+	var response localizedResponse
+	if err := json.Unmarshal(b, &response); err != nil || len(response.Code) == 0 || len(response.Message) == 0 {
+		return w.ResponseWriter.Write(b)
+	}
+
+	response.Message = i18n.Message(locale, response.Code, response.Message)
+	translated, err := json.Marshal(response)
+	if err != nil {
+		return w.ResponseWriter.Write(b)
+	}
+	if _, err := w.ResponseWriter.Write(translated); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kkodecaffeine/go-common/errorcode"
+	"golang.org/x/exp/slog"
+)
+
+// Logger 는 요청마다 JSON 로그 한 줄을 남김 (gin.Logger 대신 사용)
+// 요청 아이디 / 회원 아이디 / 트레이스 아이디는 요청 context 에서 추가 (logging.New)
+// 쿼리 문자열은 토큰 / 인증번호가 담길 수 있어 경로만 기록, 5xx 는 error, 4xx 는 warn 수준
+func Logger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if len(route) == 0 {
+			route = "unmatched"
+		}
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("route", route),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("client_ip", c.ClientIP()),
+			slog.String("user_agent", c.Request.UserAgent()),
+			slog.Int("bytes", c.Writer.Size()),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+
+		slog.LogAttrs(c.Request.Context(), level, "request", attrs...)
+	}
+}
+
+// Recovery 는 panic 을 stack 과 함께 로그로 남기고 500 (FAILED_INTERNAL_ERROR) 응답 (gin.Recovery 대신 사용)
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				slog.ErrorCtx(c.Request.Context(), "panic recovered",
+					"method", c.Request.Method,
+					"path", c.Request.URL.Path,
+					"panic", fmt.Sprint(rec),
+					"stack", string(debug.Stack()),
+				)
+
+				if c.Writer.Written() {
+					c.Abort()
+					return
+				}
+				abort(c, &errorcode.FAILED_INTERNAL_ERROR, "internal server error")
+			}
+		}()
+		c.Next()
+	}
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"time"
+
+	"signupin-api/internal/pkg/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics 는 요청 처리 시간을 등록된 경로 (ex. /api/v1/users/:userID) 별로 기록
+// 등록되지 않은 경로는 label 이 늘어나지 않도록 하나로 묶음
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if len(route) == 0 {
+			route = "unmatched"
+		}
+		metrics.ObserveRequest(route, c.Request.Method, c.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"signupin-api/internal/pkg/reqctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+const keyRejectUnknownFields = "rejectUnknownFields"
+
+// RequestID 는 X-Request-ID 헤더 (없으면 새로 생성) 를 요청 context 와 응답 헤더에 담음
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestIDHeader)
+		if len(requestID) == 0 || len(requestID) > 64 {
+			requestID = newRequestID()
+		}
+
+		c.Request = c.Request.WithContext(reqctx.WithRequestID(c.Request.Context(), requestID))
+		c.Header(requestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+// Timeout 은 요청 context 에 deadline 을 설정, 이후 usecase / repository 호출은 deadline 이 지나면 취소됨
+// 라우트별로 더 짧은 deadline 을 지정할 수 있음 (이미 설정된 deadline 보다 길게 늘릴 수는 없음)
+func Timeout(d time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// Body 는 요청 본문을 maxBytes 로 제한 (넘으면 본문을 읽을 때 *http.MaxBytesError)
+// rejectUnknownFields 는 요청 JSON 에 요청 모델에 없는 필드가 있을 때 거부할지 여부 (RejectUnknownFields 로 확인)
+func Body(maxBytes int64, rejectUnknownFields bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body != nil {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		}
+		c.Set(keyRejectUnknownFields, rejectUnknownFields)
+		c.Next()
+	}
+}
+
+// RejectUnknownFields 는 요청 JSON 의 알 수 없는 필드를 거부해야 하는지 확인
+func RejectUnknownFields(c *gin.Context) bool {
+	return c.GetBool(keyRejectUnknownFields)
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
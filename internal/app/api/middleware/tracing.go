@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"strconv"
+
+	"signupin-api/internal/pkg/reqctx"
+	"signupin-api/internal/pkg/tracing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing 은 요청마다 span 을 시작하고 요청 context 에 담음 (이후 usecase / repository span 의 상위 span)
+// 요청 헤더에 W3C traceparent 가 있으면 상위 서비스의 트레이스에 이어서 기록
+// 인증된 요청은 회원 아이디, 응답 상태 코드에 따라 결과를 기록
+func Tracing() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		route := c.FullPath()
+		if len(route) == 0 {
+			route = "unmatched"
+		}
+
+		ctx, span := tracing.Tracer().Start(ctx, c.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPMethodKey.String(c.Request.Method),
+				semconv.HTTPRouteKey.String(route),
+				semconv.HTTPTargetKey.String(c.Request.URL.Path),
+				semconv.HTTPUserAgentKey.String(c.Request.UserAgent()),
+				semconv.HTTPClientIPKey.String(c.ClientIP()),
+				attribute.String("http.request_id", reqctx.RequestID(ctx)),
+			))
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(status))
+		if userID := reqctx.UserID(c.Request.Context()); len(userID) > 0 {
+			span.SetAttributes(tracing.AttrUserID.String(userID))
+		}
+
+		switch {
+		case status >= 500:
+			span.SetAttributes(tracing.AttrOutcome.String(tracing.OutcomeError))
+			span.SetStatus(codes.Error, strconv.Itoa(status))
+		case status >= 400:
+			span.SetAttributes(tracing.AttrOutcome.String(tracing.OutcomeFailure))
+		default:
+			span.SetAttributes(tracing.AttrOutcome.String(tracing.OutcomeSuccess))
+		}
+	}
+}
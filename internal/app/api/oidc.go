@@ -0,0 +1,334 @@
+package api
+
+import (
+	"html/template"
+	"net/http"
+	"net/url"
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/app/api/middleware"
+	"signupin-api/internal/pkg/audit"
+	"signupin-api/internal/pkg/device"
+	"signupin-api/internal/pkg/oidc"
+	"signupin-api/internal/pkg/risk"
+	"signupin-api/internal/pkg/user"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/kkodecaffeine/go-common/errorcode"
+	"github.com/kkodecaffeine/go-common/utils"
+
+	"github.com/kkodecaffeine/go-common/rest"
+)
+
+var loginTemplate = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>로그인</title></head>
+<body>
+	{{if .Error}}<p>{{.Error}}</p>{{end}}
+	<form method="post">
+		<input type="hidden" name="response_type" value="{{.Request.ResponseType}}">
+		<input type="hidden" name="client_id" value="{{.Request.ClientID}}">
+		<input type="hidden" name="redirect_uri" value="{{.Request.RedirectURI}}">
+		<input type="hidden" name="scope" value="{{.Request.Scope}}">
+		<input type="hidden" name="state" value="{{.Request.State}}">
+		<input type="hidden" name="nonce" value="{{.Request.Nonce}}">
+		<input type="hidden" name="code_challenge" value="{{.Request.CodeChallenge}}">
+		<input type="hidden" name="code_challenge_method" value="{{.Request.CodeChallengeMethod}}">
+		<input type="text" name="identifier" placeholder="이메일 혹은 전화번호" value="{{.Identifier}}">
+		<input type="password" name="password" placeholder="비밀번호">
+		{{if .ChallengeID}}<input type="hidden" name="challenge_id" value="{{.ChallengeID}}">
+		<input type="text" name="step_up_code" placeholder="문자로 받은 인증번호">{{end}}
+		<button type="submit">로그인</button>
+	</form>
+</body>
+</html>
+`))
+
+type OIDCController struct {
+	v       *validator.Validate
+	usecase oidc.Usecase
+	users   user.Usecase
+	audit   audit.Usecase
+	checks  signInChecks
+}
+
+// NewOIDCController returns new OpenID Connect provider controller instance
+func NewOIDCController(e *gin.Engine, v *validator.Validate, uc oidc.Usecase, users user.Usecase, risks risk.Usecase, devices device.Usecase, audits audit.Usecase, authenticate gin.HandlerFunc, admins []string) OIDCController {
+	ctrl := OIDCController{v, uc, users, audits, signInChecks{risks, devices, audits}}
+
+	e.GET("/.well-known/openid-configuration", ctrl.Discovery)
+
+	oauth := e.Group("/oauth")
+	oauth.GET("/jwks", ctrl.JWKS)
+	oauth.GET("/authorize", ctrl.AuthorizeForm)
+	oauth.POST("/authorize", ctrl.Authorize)
+	oauth.POST("/token", ctrl.Token)
+	oauth.GET("/userinfo", ctrl.UserInfo)
+	oauth.POST("/userinfo", ctrl.UserInfo)
+
+	authorized := e.Group("/v1").Group("/")
+	authorized.Use(authenticate)
+	authorized.POST("/oauth/clients", middleware.Admin(admins), middleware.Scopes("clients:write"), ctrl.RegisterClient)
+
+	return ctrl
+}
+
+/**
+ * OpenID Provider 메타데이터 조회 API
+ * @return : OpenID Connect Discovery 1.0 형식의 메타데이터
+ */
+func (ctrl *OIDCController) Discovery(c *gin.Context) {
+	c.JSON(http.StatusOK, ctrl.usecase.Discovery(c.Request.Context()))
+}
+
+/**
+ * 서명 공개키 조회 API
+ * @return : ID 토큰 검증에 사용하는 JWK Set
+ */
+func (ctrl *OIDCController) JWKS(c *gin.Context) {
+	c.JSON(http.StatusOK, ctrl.usecase.JWKS(c.Request.Context()))
+}
+
+/**
+ * 클라이언트 등록 API
+ * JWT 검증 과정 후 로그인을 위임할 애플리케이션 혹은 서비스 간 호출에 사용할 클라이언트 (client_credentials) 등록
+ * 관리자 (ADMIN_SUBJECTS) 만 호출 가능, scope 는 oidc.AllowedScopes 에 있는 값만 부여
+ * @return : 클라이언트 아이디, 클라이언트 시크릿 (public 클라이언트 제외, 1회만 노출)
+ */
+func (ctrl *OIDCController) RegisterClient(c *gin.Context) {
+	response := rest.NewApiResponse()
+	entry := auditEntry(c, audit.ActionClientRegister)
+	defer auditResponse(c, ctrl.audit, entry, response)
+
+	var req dto.PostClientRequest
+	if !bindJSON(c, ctrl.v, response, &req) {
+		return
+	}
+
+	result, err := ctrl.usecase.RegisterClient(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, nil)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+	entry.Target(audit.TargetClient, result.ClientID)
+
+	response.Created("", result)
+	c.JSON(http.StatusCreated, response)
+}
+
+/**
+ * 인가 요청 API (로그인 화면)
+ * 클라이언트 및 redirect_uri 검증 후 로그인 화면 노출
+ * redirect_uri 가 검증된 이후의 오류는 redirect_uri 로 전달
+ */
+func (ctrl *OIDCController) AuthorizeForm(c *gin.Context) {
+	var req dto.GetAuthorizeRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.OAuthErrorResponse{Error: oidc.ErrInvalidRequest, ErrorDescription: err.Error()})
+		return
+	}
+
+	if err := ctrl.usecase.VerifyRedirectURI(c.Request.Context(), req.ClientID, req.RedirectURI); err != nil {
+		writeOAuthError(c, err)
+		return
+	}
+
+	if err := ctrl.usecase.ValidateAuthorizeRequest(c.Request.Context(), &req); err != nil {
+		redirectOAuthError(c, req.RedirectURI, req.State, err)
+		return
+	}
+
+	renderLogin(c, http.StatusOK, &req, "", "", "")
+}
+
+/**
+ * 인가 요청 API (로그인 폼 제출)
+ * 회원 로그인 API 와 동일하게 (이메일, 비밀번호) 혹은 (전화번호, 비밀번호) 로 회원 확인 후 위험도 평가
+ * 추가 인증이 필요하면 문자로 받은 인증번호를 입력하는 로그인 화면을 다시 노출, 비밀번호 재설정이 필요한 회원은 거부
+ * 로그인한 기기를 기록하고 (새 기기 알림) 감사 이벤트 (sign_in) 기록
+ * @return : 인가 코드를 담아 redirect_uri 로 이동
+ */
+func (ctrl *OIDCController) Authorize(c *gin.Context) {
+	var req dto.PostAuthorizeRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.OAuthErrorResponse{Error: oidc.ErrInvalidRequest, ErrorDescription: err.Error()})
+		return
+	}
+
+	if err := ctrl.usecase.VerifyRedirectURI(c.Request.Context(), req.ClientID, req.RedirectURI); err != nil {
+		writeOAuthError(c, err)
+		return
+	}
+
+	if err := ctrl.usecase.ValidateAuthorizeRequest(c.Request.Context(), &req.GetAuthorizeRequest); err != nil {
+		redirectOAuthError(c, req.RedirectURI, req.State, err)
+		return
+	}
+
+	// 로그인 화면을 다시 노출하거나 이동한 결과를 감사 이벤트로 기록
+	entry := auditEntry(c, audit.ActionSignIn)
+	entry.Identifier = req.Identifier
+	reason := ""
+	defer func() {
+		if c.Writer.Written() {
+			recordAudit(c, ctrl.audit, entry.Result(c.Writer.Status(), reason))
+		}
+	}()
+
+	meta := sessionMetadata(c)
+	found, err := ctrl.users.GetOne(c.Request.Context(), req.Identifier, req.Password)
+	if err != nil {
+		reason = err.CodeDesc.Code
+		ctrl.checks.recordFailure(c, risk.ActionSignIn, req.Identifier, err)
+		if err.CodeDesc.Code == errorcode.NOT_FOUND_ERROR.Code {
+			renderLogin(c, http.StatusUnauthorized, &req.GetAuthorizeRequest, req.Identifier, "", "이메일(전화번호) 혹은 비밀번호가 올바르지 않습니다.")
+			return
+		}
+		writeOAuthError(c, err)
+		return
+	}
+	// 비밀번호를 확인한 이후의 결과는 회원 본인의 요청으로 기록
+	entry.Target(audit.TargetUser, found.Id)
+	entry.ActorType, entry.ActorID = middleware.PrincipalUser, found.Id
+
+	signal := &risk.Signal{Action: risk.ActionSignIn, Identifier: req.Identifier, UserID: found.Id, IP: meta.IP, Time: time.Now()}
+	if err := ctrl.checks.assess(c, signal, meta, found.Phone, req.ChallengeID, req.StepUpCode); err != nil {
+		reason = err.CodeDesc.Code
+		challenge, stepUp := err.Data.(*dto.StepUpResponse)
+		switch {
+		case stepUp:
+			renderLogin(c, err.CodeDesc.HttpStatusCode, &req.GetAuthorizeRequest, req.Identifier, challenge.ChallengeID, "추가 인증이 필요합니다. 문자로 받은 인증번호를 입력하세요.")
+		case err.CodeDesc.Code == risk.CodeDenied.Code:
+			redirectOAuthError(c, req.RedirectURI, req.State, &rest.CustomError{CodeDesc: err.CodeDesc, Message: "sign-in denied by risk policy", Data: oidc.ErrAccessDenied})
+		case err.CodeDesc.HttpStatusCode >= http.StatusInternalServerError:
+			writeOAuthError(c, err)
+		default:
+			// 추가 인증 코드가 올바르지 않거나 만료된 경우
+			renderLogin(c, err.CodeDesc.HttpStatusCode, &req.GetAuthorizeRequest, req.Identifier, "", "인증번호가 올바르지 않거나 만료되었습니다. 다시 로그인하세요.")
+		}
+		return
+	}
+
+	// 비밀번호 재설정 전까지는 다른 애플리케이션에 로그인을 위임하지 않음 (회원 토큰은 비밀번호 수정 API 만 허용)
+	if found.PasswordResetRequired {
+		reason = "PASSWORD_RESET_REQUIRED"
+		renderLogin(c, http.StatusForbidden, &req.GetAuthorizeRequest, req.Identifier, "", "비밀번호 재설정이 필요합니다. 비밀번호를 재설정한 후 다시 로그인하세요.")
+		return
+	}
+
+	code, err := ctrl.usecase.Authorize(c.Request.Context(), &req, found.Id)
+	if err != nil {
+		reason = err.CodeDesc.Code
+		if err.Data == nil {
+			writeOAuthError(c, err)
+			return
+		}
+		redirectOAuthError(c, req.RedirectURI, req.State, err)
+		return
+	}
+
+	ctrl.checks.checkDevice(c, found, "", meta)
+
+	query := url.Values{"code": {code}}
+	if len(req.State) > 0 {
+		query.Set("state", req.State)
+	}
+	c.Redirect(http.StatusFound, appendQuery(req.RedirectURI, query))
+}
+
+/**
+ * 토큰 발급 API
+ * 클라이언트 인증 (client_secret_basic, client_secret_post, none) 후 인가 코드 및 PKCE code_verifier 검증
+ * @return : 액세스 토큰, ID 토큰
+ */
+func (ctrl *OIDCController) Token(c *gin.Context) {
+	var req dto.PostTokenRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.OAuthErrorResponse{Error: oidc.ErrInvalidRequest, ErrorDescription: err.Error()})
+		return
+	}
+
+	if clientID, secret, ok := c.Request.BasicAuth(); ok {
+		req.ClientID = clientID
+		req.ClientSecret = secret
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+
+	result, err := ctrl.usecase.Exchange(c.Request.Context(), &req)
+	if err != nil {
+		writeOAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+/**
+ * 회원 정보 조회 API (OIDC userinfo)
+ * 액세스 토큰 검증 후 scope 에 해당하는 회원 정보 반환
+ */
+func (ctrl *OIDCController) UserInfo(c *gin.Context) {
+	result, err := ctrl.usecase.UserInfo(c.Request.Context(), utils.ExtractToken(c))
+	if err != nil {
+		if err.Data == oidc.ErrInvalidToken {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+		}
+		writeOAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// renderLogin 은 인가 요청 로그인 화면 노출, challengeID 가 있으면 추가 인증 코드 입력란을 함께 노출
+func renderLogin(c *gin.Context, status int, req *dto.GetAuthorizeRequest, identifier, challengeID, message string) {
+	c.Status(status)
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	_ = loginTemplate.Execute(c.Writer, struct {
+		Request     *dto.GetAuthorizeRequest
+		Identifier  string
+		ChallengeID string
+		Error       string
+	}{req, identifier, challengeID, message})
+}
+
+func writeOAuthError(c *gin.Context, err *rest.CustomError) {
+	code, ok := err.Data.(string)
+	if !ok {
+		code = oidc.ErrServerError
+	}
+	c.JSON(err.CodeDesc.HttpStatusCode, dto.OAuthErrorResponse{Error: code, ErrorDescription: err.Message})
+}
+
+func redirectOAuthError(c *gin.Context, redirectURI, state string, err *rest.CustomError) {
+	code, ok := err.Data.(string)
+	if !ok {
+		code = oidc.ErrServerError
+	}
+
+	query := url.Values{"error": {code}, "error_description": {err.Message}}
+	if len(state) > 0 {
+		query.Set("state", state)
+	}
+	c.Redirect(http.StatusFound, appendQuery(redirectURI, query))
+}
+
+func appendQuery(rawURL string, query url.Values) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	values := parsed.Query()
+	for key := range query {
+		values.Set(key, query.Get(key))
+	}
+	parsed.RawQuery = values.Encode()
+
+	return parsed.String()
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"net/http"
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/pkg/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+const openAPIPath = "/openapi.json"
+
+// userRoutes 는 NewController 가 등록하는 라우트의 요청 / 응답 모델
+// NewController 의 라우트를 바꾸면 함께 바꿔야 함 (TestOpenAPI 에서 확인)
+var userRoutes = []openapi.Route{
+	{
+		Method: http.MethodPost, Path: "/v1/auth/sms", OperationID: "SendSMS", Tag: "auth",
+		Summary:  "전화번호 인증",
+		Request:  dto.PostSMSRequest{},
+		Response: dto.PostSMSResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: "/v1/auth/sign-up", OperationID: "SignUp", Tag: "auth",
+		Summary:     "회원 가입",
+		Description: "전화번호 인증으로 받은 인증번호가 일치하면 가입, 이미 가입한 이메일이면 AUTH_EMAIL_ALREADY_EXISTS",
+		Request:     dto.PostSignUpRequest{},
+		Response:    dto.GetUserResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: "/v1/auth/sign-in", OperationID: "SignIn", Tag: "auth",
+		Summary:     "회원 로그인",
+		Description: "이메일 혹은 전화번호와 비밀번호로 로그인, 위험도 평가 결과에 따라 추가 인증 (STEP_UP_REQUIRED) 혹은 거부 (RISK_DENIED)",
+		Request:     dto.PostSignInRequest{},
+		Response:    dto.GetUserWithTokenResponse{},
+		Errors: map[int]interface{}{
+			http.StatusUnauthorized: dto.StepUpResponse{},
+			http.StatusForbidden:    nil,
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/v1/auth/not-me", OperationID: "NotMeForm", Tag: "auth",
+		Summary:     "본인 아님 신고 확인 화면",
+		Description: "새 기기 로그인 알림의 링크로 열리는 화면, 확인 버튼을 누르면 본인 아님 신고 API 호출 (링크를 여는 것만으로는 세션을 종료하지 않음)",
+		Parameters: []openapi.Parameter{
+			{Name: "token", In: "query", Required: true, Description: "알림에 담긴 토큰", Schema: &openapi.Schema{Type: "string"}},
+		},
+		Page: true,
+	},
+	{
+		Method: http.MethodPost, Path: "/v1/auth/not-me", OperationID: "NotMe", Tag: "auth",
+		Summary:     "본인 아님 신고",
+		Description: "알림에 담긴 토큰으로 해당 기기의 세션을 종료하고 다음 로그인부터 비밀번호 재설정 요구",
+		Request:     dto.PostNotMeRequest{},
+	},
+	{
+		Method: http.MethodGet, Path: "/v1/users/:userID", OperationID: "GetMe", Tag: "users",
+		Summary:  "회원 정보 조회",
+		Response: dto.GetUserResponse{},
+		Auth:     true,
+		Scopes:   []string{"users:read"},
+	},
+	{
+		Method: http.MethodPut, Path: "/v1/users/reset-password", OperationID: "UpdatePassword", Tag: "users",
+		Summary:     "비밀번호 수정",
+		Description: "비밀번호 재설정이 필요한 회원도 호출 가능, 위험도 평가 결과에 따라 추가 인증 (STEP_UP_REQUIRED) 혹은 거부 (RISK_DENIED)",
+		Request:     dto.PutPasswordRequest{},
+		Auth:        true,
+		Errors: map[int]interface{}{
+			http.StatusUnauthorized: dto.StepUpResponse{},
+			http.StatusForbidden:    nil,
+		},
+	},
+	{
+		Method: http.MethodPut, Path: "/v1/users/me/locale", OperationID: "UpdateLocale", Tag: "users",
+		Summary:     "응답 메시지 언어 설정",
+		Description: "다음 로그인에서 발급한 토큰부터 Accept-Language 보다 우선 적용",
+		Request:     dto.PutLocaleRequest{},
+		Response:    dto.GetUserResponse{},
+		Auth:        true,
+	},
+	{
+		Method: http.MethodDelete, Path: "/v1/users/me", OperationID: "DeleteMe", Tag: "users",
+		Summary: "회원 탈퇴",
+		Request: dto.DeleteUserRequest{},
+		Auth:    true,
+	},
+}
+
+type OpenAPIController struct {
+	doc  *openapi.Document
+	page []byte
+}
+
+// NewOpenAPIController returns new OpenAPI controller instance
+// 문서는 등록 시 한 번만 생성하고, Swagger UI 는 /docs 에서 제공
+func NewOpenAPIController(e *gin.Engine, routes []openapi.Route) (OpenAPIController, error) {
+	info := openapi.Info{
+		Title:       "signupin-api",
+		Description: "회원 가입 / 로그인 API, 모든 응답은 {code, message, data} 형태",
+		Version:     "1.0.0",
+	}
+
+	doc, err := openapi.Build(info, e.BasePath(), dto.ValidationErrorResponse{}, routes)
+	if err != nil {
+		return OpenAPIController{}, err
+	}
+	page, err := openapi.UI(info.Title, e.BasePath()+openAPIPath)
+	if err != nil {
+		return OpenAPIController{}, err
+	}
+
+	ctrl := OpenAPIController{doc, page}
+
+	e.GET(openAPIPath, ctrl.Spec)
+	e.GET("/docs", ctrl.UI)
+
+	return ctrl, nil
+}
+
+/**
+ * OpenAPI 문서 API
+ * rest.ApiResponse 로 감싸지 않고 OpenAPI 3 문서를 그대로 응답
+ */
+func (ctrl *OpenAPIController) Spec(c *gin.Context) {
+	c.JSON(http.StatusOK, ctrl.doc)
+}
+
+/**
+ * Swagger UI
+ * OpenAPI 문서 API 의 문서를 보여주는 페이지
+ */
+func (ctrl *OpenAPIController) UI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", ctrl.page)
+}
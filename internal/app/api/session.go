@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+	"signupin-api/internal/app/api/middleware"
+	"signupin-api/internal/pkg/audit"
+	"signupin-api/internal/pkg/session"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/kkodecaffeine/go-common/rest"
+)
+
+type SessionController struct {
+	v       *validator.Validate
+	usecase session.Usecase
+	audit   audit.Usecase
+}
+
+// NewSessionController returns new session controller instance
+func NewSessionController(e *gin.Engine, v *validator.Validate, uc session.Usecase, audits audit.Usecase, authenticate gin.HandlerFunc) SessionController {
+	ctrl := SessionController{v, uc, audits}
+
+	authorized := e.Group("/v1").Group("/")
+	authorized.Use(authenticate)
+	authorized.GET("/users/me/sessions", middleware.Scopes(), ctrl.GetAll)
+	authorized.DELETE("/users/me/sessions/:id", middleware.Scopes(), ctrl.Revoke)
+
+	return ctrl
+}
+
+/**
+ * 세션 목록 조회 API
+ * JWT 검증 과정 후 로그인한 회원의 종료되지 않은 세션 목록 조회
+ * @return : 세션 목록 (기기 이름, IP, 로그인 시각, 마지막 사용 시각, 현재 세션 여부)
+ */
+func (ctrl *SessionController) GetAll(c *gin.Context) {
+	response := rest.NewApiResponse()
+
+	principal := middleware.GetPrincipal(c)
+	result, err := ctrl.usecase.GetAll(c.Request.Context(), principal.Subject, principal.SessionID)
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	response.Succeed("", result)
+	c.JSON(http.StatusOK, response)
+}
+
+/**
+ * 세션 종료 API
+ * JWT 검증 과정 후 로그인한 회원의 세션 종료
+ * 종료된 세션에 묶인 토큰은 만료 전이라도 사용 불가
+ */
+func (ctrl *SessionController) Revoke(c *gin.Context) {
+	response := rest.NewApiResponse()
+	entry := auditEntry(c, audit.ActionTokenRevoke).Target(audit.TargetSession, c.Param("id"))
+	defer auditResponse(c, ctrl.audit, entry, response)
+
+	err := ctrl.usecase.Revoke(c.Request.Context(), middleware.GetPrincipal(c).Subject, c.Param("id"))
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	response.Succeed("", nil)
+	c.JSON(http.StatusOK, response)
+}
+
+// sessionMetadata 는 로그인 요청에서 세션에 기록할 접속 정보를 추출
+func sessionMetadata(c *gin.Context) *session.Metadata {
+	return &session.Metadata{
+		UserAgent:      c.Request.UserAgent(),
+		AcceptLanguage: c.GetHeader("Accept-Language"),
+		IP:             c.ClientIP(),
+		DeviceLabel:    c.GetHeader("X-Device-Label"),
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"net/http"
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/pkg/audit"
+	"signupin-api/internal/pkg/device"
+	"signupin-api/internal/pkg/risk"
+	"signupin-api/internal/pkg/session"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kkodecaffeine/go-common/errorcode"
+	"github.com/kkodecaffeine/go-common/rest"
+	"golang.org/x/exp/slog"
+)
+
+// signInChecks 는 로그인 방식 (회원 로그인 API, OIDC 인가 요청, 소셜 로그인) 과 상관없이 거치는 확인
+// 회원 확인 실패 기록, 위험도 평가 (추가 인증 포함), 로그인한 기기 기록 및 새 기기 알림
+type signInChecks struct {
+	risk    risk.Usecase
+	devices device.Usecase
+	audit   audit.Usecase
+}
+
+// recordFailure 는 회원 확인에 실패한 경우 (NOT_FOUND) 위험도 평가의 실패 횟수로 기록
+func (s *signInChecks) recordFailure(c *gin.Context, action, identifier string, err *rest.CustomError) {
+	if err.CodeDesc.Code == errorcode.NOT_FOUND_ERROR.Code {
+		_ = s.risk.RecordFailure(c.Request.Context(), action, identifier, c.ClientIP())
+	}
+}
+
+// assess 는 위험도 평가 결과에 따라 요청을 계속 진행할지 결정
+// 거부되면 risk.CodeDenied, 추가 인증이 필요하면 추가 인증 정보 (dto.StepUpResponse) 를 Data 로 담은 risk.CodeStepUpRequired 반환
+// 추가 인증 코드를 함께 요청한 경우 코드 확인 후 평가
+func (s *signInChecks) assess(c *gin.Context, signal *risk.Signal, meta *session.Metadata, phone, challengeID, code string) *rest.CustomError {
+	known, err := s.devices.IsKnown(c.Request.Context(), signal.UserID, meta)
+	if err != nil {
+		return err
+	}
+	signal.NewDevice = !known
+
+	if len(challengeID) > 0 {
+		err := s.risk.VerifyChallenge(c.Request.Context(), challengeID, signal.UserID, signal.Action, code)
+
+		entry := auditEntry(c, audit.ActionCodeVerify).Target(audit.TargetStepUp, challengeID)
+		entry.Identifier = signal.Identifier
+		if err != nil {
+			entry.Result(err.CodeDesc.HttpStatusCode, err.CodeDesc.Code)
+		} else {
+			entry.Result(http.StatusOK, "")
+		}
+		recordAudit(c, s.audit, entry)
+
+		if err != nil {
+			return err
+		}
+		signal.StepUpVerified = true
+	}
+
+	assessment, err := s.risk.Assess(c.Request.Context(), signal)
+	if err != nil {
+		return err
+	}
+
+	switch assessment.Decision {
+	case risk.DecisionDeny:
+		return &rest.CustomError{CodeDesc: &risk.CodeDenied}
+	case risk.DecisionStepUp:
+		challenge, err := s.risk.StartChallenge(c.Request.Context(), signal.UserID, signal.Action, phone)
+		if err != nil {
+			return err
+		}
+
+		entry := auditEntry(c, audit.ActionCodeIssue).Target(audit.TargetStepUp, challenge.ChallengeID).Result(http.StatusOK, "")
+		entry.Identifier = signal.Identifier
+		recordAudit(c, s.audit, entry)
+
+		return &rest.CustomError{CodeDesc: &risk.CodeStepUpRequired, Data: challenge}
+	}
+
+	return nil
+}
+
+// checkDevice 는 로그인한 기기를 기록하고 처음 보는 기기 혹은 IP 인 경우 회원에게 알림
+// 알림 처리 실패로 로그인이 실패하지 않도록 로그만 남김
+func (s *signInChecks) checkDevice(c *gin.Context, found *dto.GetUserWithTokenResponse, sessionID string, meta *session.Metadata) {
+	if err := s.devices.Check(c.Request.Context(), found, sessionID, meta); err != nil {
+		slog.WarnCtx(c.Request.Context(), "device check failed", "code", err.CodeDesc.Code, "error", err.Message)
+	}
+}
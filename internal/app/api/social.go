@@ -0,0 +1,184 @@
+package api
+
+import (
+	"net/http"
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/app/api/middleware"
+	"signupin-api/internal/pkg/audit"
+	"signupin-api/internal/pkg/device"
+	"signupin-api/internal/pkg/risk"
+	"signupin-api/internal/pkg/session"
+	"signupin-api/internal/pkg/social"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/kkodecaffeine/go-common/rest"
+)
+
+type SocialController struct {
+	v        *validator.Validate
+	usecase  social.Usecase
+	sessions session.Usecase
+	audit    audit.Usecase
+	checks   signInChecks
+}
+
+// NewSocialController returns new social login controller instance
+func NewSocialController(e *gin.Engine, v *validator.Validate, uc social.Usecase, sessions session.Usecase, risks risk.Usecase, devices device.Usecase, audits audit.Usecase, authenticate gin.HandlerFunc) SocialController {
+	ctrl := SocialController{v, uc, sessions, audits, signInChecks{risks, devices, audits}}
+
+	v1 := e.Group("/v1")
+	v1.GET("/auth/social/:provider", ctrl.Redirect)
+	v1.GET("/auth/social/:provider/callback", ctrl.Callback)
+	v1.POST("/auth/social/:provider/callback", ctrl.Callback) // response_mode=form_post (ex. apple)
+
+	authorized := v1.Group("/")
+	authorized.Use(authenticate)
+	authorized.GET("/users/me/identities", middleware.Scopes(), ctrl.GetIdentities)
+	authorized.POST("/users/me/identities/:provider", middleware.Scopes(), ctrl.Link)
+	authorized.DELETE("/users/me/identities/:provider", middleware.Scopes(), ctrl.Unlink)
+
+	return ctrl
+}
+
+/**
+ * 소셜 로그인 API
+ * 인증 제공자의 로그인 화면으로 이동
+ * 추가 인증 요청을 받은 경우 challenge_id 와 문자로 받은 code 를 담아 다시 호출
+ */
+func (ctrl *SocialController) Redirect(c *gin.Context) {
+	response := rest.NewApiResponse()
+
+	var req dto.GetSocialSignInRequest
+	_ = c.ShouldBindQuery(&req)
+
+	authorizationURL, err := ctrl.usecase.AuthorizationURL(c.Request.Context(), c.Param("provider"), "", &req)
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	c.Redirect(http.StatusFound, authorizationURL)
+}
+
+/**
+ * 소셜 로그인 callback API
+ * 인가 코드 교환 후 외부 계정과 연결된 회원으로 로그인
+ * 연결된 회원이 없으면 최초 로그인으로 간주하여 회원 가입 처리
+ * 계정 연결 요청으로 시작한 경우 로그인한 회원에게 외부 계정 연결
+ * 로그인은 회원 로그인 API 와 동일하게 위험도 평가 (추가 인증 포함) 후 세션을 기록하고 새 기기 알림
+ * 결과를 감사 이벤트 (sign_in, 계정 연결은 identity_link) 로 기록
+ * @return : 회원 정보 (w/ ID, JWT), 연결된 외부 계정
+ */
+func (ctrl *SocialController) Callback(c *gin.Context) {
+	response := rest.NewApiResponse()
+
+	entry := auditEntry(c, audit.ActionSignIn)
+	defer auditResponse(c, ctrl.audit, entry, response)
+
+	var req dto.GetSocialCallbackRequest
+	_ = c.ShouldBind(&req)
+
+	result, err := ctrl.usecase.Callback(c.Request.Context(), c.Param("provider"), &req)
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	entry.Identifier = result.Identity.Email
+	if result.User == nil {
+		entry.Action = audit.ActionIdentityLink
+		entry.Target(audit.TargetIdentity, result.Identity.Provider+":"+result.Identity.Subject)
+	}
+
+	// 로그인한 경우 회원 로그인 API 와 동일하게 위험도 평가 후 세션 기록
+	if result.User != nil {
+		entry.Target(audit.TargetUser, result.User.Id)
+		entry.ActorType, entry.ActorID = middleware.PrincipalUser, result.User.Id
+
+		meta := sessionMetadata(c)
+		signal := &risk.Signal{Action: risk.ActionSignIn, Identifier: result.Identity.Email, UserID: result.User.Id, IP: meta.IP, Time: time.Now()}
+		if err := ctrl.checks.assess(c, signal, meta, result.User.Phone, result.ChallengeID, result.StepUpCode); err != nil {
+			response.Error(err.CodeDesc, err.Message, err.Data)
+			c.JSON(err.CodeDesc.HttpStatusCode, response)
+			return
+		}
+
+		meta.PasswordResetRequired = result.User.PasswordResetRequired
+		meta.Locale = result.User.Locale
+
+		token, sessionID, err := ctrl.sessions.Start(c.Request.Context(), result.User.Id, meta)
+		if err != nil {
+			response.Error(err.CodeDesc, err.Message, err.Data)
+			c.JSON(err.CodeDesc.HttpStatusCode, response)
+			return
+		}
+		result.User.AccessToken = token
+
+		ctrl.checks.checkDevice(c, result.User, sessionID, meta)
+	}
+
+	response.Succeed("", result)
+	c.JSON(http.StatusOK, response)
+}
+
+/**
+ * 연결된 외부 계정 조회 API
+ * JWT 검증 과정 후 로그인한 회원에게 연결된 외부 계정 조회
+ */
+func (ctrl *SocialController) GetIdentities(c *gin.Context) {
+	response := rest.NewApiResponse()
+
+	result, err := ctrl.usecase.GetIdentities(c.Request.Context(), middleware.GetPrincipal(c).Subject)
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	response.Succeed("", result)
+	c.JSON(http.StatusOK, response)
+}
+
+/**
+ * 외부 계정 연결 API
+ * JWT 검증 과정 후 인증 제공자의 로그인 화면 주소 반환
+ * 인증 제공자 로그인을 마치면 callback API 에서 로그인한 회원에게 외부 계정 연결
+ */
+func (ctrl *SocialController) Link(c *gin.Context) {
+	response := rest.NewApiResponse()
+
+	authorizationURL, err := ctrl.usecase.AuthorizationURL(c.Request.Context(), c.Param("provider"), middleware.GetPrincipal(c).Subject, nil)
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	response.Succeed("", dto.GetSocialAuthorizeResponse{AuthorizationURL: authorizationURL})
+	c.JSON(http.StatusOK, response)
+}
+
+/**
+ * 외부 계정 연결 해제 API
+ * JWT 검증 과정 후 로그인한 회원에게 연결된 외부 계정 해제
+ * 소셜 로그인으로 가입한 회원의 유일한 로그인 수단은 해제 불가
+ */
+func (ctrl *SocialController) Unlink(c *gin.Context) {
+	response := rest.NewApiResponse()
+
+	err := ctrl.usecase.Unlink(c.Request.Context(), middleware.GetPrincipal(c).Subject, c.Param("provider"))
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	response.Succeed("", nil)
+	c.JSON(http.StatusOK, response)
+}
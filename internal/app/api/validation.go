@@ -0,0 +1,131 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/app/api/middleware"
+	"signupin-api/internal/pkg/i18n"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/kkodecaffeine/go-common/errorcode"
+
+	"github.com/kkodecaffeine/go-common/rest"
+)
+
+// registerValidator 는 검증 오류의 필드 이름으로 struct 필드 이름 대신 json / form 태그 이름을 사용하고
+// 검증 규칙 설명을 언어별로 번역할 수 있도록 설정
+func registerValidator(v *validator.Validate) error {
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if len(name) > 0 {
+				return name
+			}
+		}
+		return ""
+	})
+	return i18n.RegisterValidation(v)
+}
+
+// bindJSON 은 요청 본문을 req 로 읽고 binding 태그와 validate 태그 (v) 를 모두 검증
+// 본문을 해석할 수 없으면 decodeJSON 의 오류로, 검증에 실패하면 실패한 필드를 모두 담아 응답하고 false 반환
+func bindJSON(c *gin.Context, v *validator.Validate, response *rest.ApiResponse, req interface{}) bool {
+	if err := decodeJSON(c, req); err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return false
+	}
+	return validate(c, v, response, req, binding.Validator.ValidateStruct(req))
+}
+
+// bindQuery 는 query string 을 req 로 읽고 binding 태그 검증, 실패하면 bindJSON 과 동일하게 응답
+func bindQuery(c *gin.Context, response *rest.ApiResponse, req interface{}) bool {
+	err := c.ShouldBindWith(req, binding.Query)
+	if _, ok := err.(validator.ValidationErrors); err != nil && !ok {
+		response.Error(&errorcode.INVALID_PARAMETERS, err.Error(), nil)
+		c.JSON(http.StatusBadRequest, response)
+		return false
+	}
+	return validate(c, nil, response, req, err)
+}
+
+// validate 는 binding 태그 검증 결과 (err) 와 validate 태그 (v) 검증 결과를 모아 응답
+func validate(c *gin.Context, v *validator.Validate, response *rest.ApiResponse, req interface{}, err error) bool {
+	locale := middleware.Locale(c)
+
+	var errs []dto.ValidationError
+	if fields, ok := err.(validator.ValidationErrors); ok {
+		errs = appendValidationErrors(errs, fields, locale)
+	}
+
+	// binding 태그 검증을 통과하지 못한 필드는 validate 태그 오류를 중복으로 담지 않음
+	if v != nil {
+		if fields, ok := v.Struct(req).(validator.ValidationErrors); ok {
+			errs = appendValidationErrors(errs, fields, locale)
+		}
+	}
+
+	if len(errs) == 0 {
+		return true
+	}
+	writeValidationErrors(c, response, errs)
+	return false
+}
+
+// writeValidationErrors 는 누락된 필드가 있으면 MISSING_PARAMETERS, 아니면 INVALID_PARAMETERS 로 응답
+// 메시지에는 실패한 필드 이름, data 에는 필드별 규칙과 설명을 담음
+func writeValidationErrors(c *gin.Context, response *rest.ApiResponse, errs []dto.ValidationError) {
+	code := &errorcode.INVALID_PARAMETERS
+	fields := make([]string, 0, len(errs))
+	for _, e := range errs {
+		if e.Rule == "required" {
+			code = &errorcode.MISSING_PARAMETERS
+		}
+		fields = append(fields, e.Field)
+	}
+
+	response.Error(code, strings.Join(fields, ", "), dto.ValidationErrorResponse{Errors: errs})
+	c.JSON(http.StatusBadRequest, response)
+}
+
+func appendValidationErrors(errs []dto.ValidationError, fields validator.ValidationErrors, locale string) []dto.ValidationError {
+	for _, fe := range fields {
+		field := fieldPath(fe)
+		if hasField(errs, field) {
+			continue
+		}
+		errs = append(errs, dto.ValidationError{
+			Field:   field,
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: i18n.ValidationMessage(locale, fe),
+		})
+	}
+	return errs
+}
+
+// fieldPath 는 요청 모델 이름을 뺀 필드 경로 (ex. PostAPIKeyRequest.scopes[0] → scopes[0])
+func fieldPath(fe validator.FieldError) string {
+	namespace := fe.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return fe.Field()
+}
+
+func hasField(errs []dto.ValidationError, field string) bool {
+	for _, e := range errs {
+		if e.Field == field {
+			return true
+		}
+	}
+	return false
+}
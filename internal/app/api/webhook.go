@@ -0,0 +1,167 @@
+package api
+
+import (
+	"net/http"
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/app/api/middleware"
+	"signupin-api/internal/pkg/audit"
+	"signupin-api/internal/pkg/webhook"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/kkodecaffeine/go-common/rest"
+)
+
+type WebhookController struct {
+	v       *validator.Validate
+	usecase webhook.Usecase
+	audit   audit.Usecase
+}
+
+// NewWebhookController returns new webhook controller instance
+func NewWebhookController(e *gin.Engine, v *validator.Validate, uc webhook.Usecase, audits audit.Usecase, authenticate gin.HandlerFunc, admins []string) WebhookController {
+	ctrl := WebhookController{v, uc, audits}
+
+	admin := e.Group("/v1/admin")
+	admin.Use(authenticate, middleware.Admin(admins), middleware.Scopes("webhooks:write"))
+	admin.POST("/webhooks", ctrl.SaveOne)
+	admin.GET("/webhooks", ctrl.GetAll)
+	admin.DELETE("/webhooks/:id", ctrl.DeleteOne)
+	admin.POST("/webhooks/:id/test", ctrl.Test)
+	admin.GET("/webhook-deliveries", ctrl.GetDeliveries)
+	admin.POST("/webhook-deliveries/:id/replay", ctrl.Replay)
+
+	return ctrl
+}
+
+/**
+ * 웹훅 등록 API (관리자)
+ * 수신 주소, 서명 키, 구독할 이벤트 종류 등록 (서명 키를 보내지 않으면 생성)
+ * @return : 등록한 웹훅 (서명 키는 등록 시 1회만 노출)
+ */
+func (ctrl *WebhookController) SaveOne(c *gin.Context) {
+	response := rest.NewApiResponse()
+	entry := auditEntry(c, audit.ActionWebhookChange)
+	defer auditResponse(c, ctrl.audit, entry, response)
+
+	var req dto.PostWebhookRequest
+	if !bindJSON(c, ctrl.v, response, &req) {
+		return
+	}
+
+	result, err := ctrl.usecase.SaveEndpoint(c.Request.Context(), middleware.GetPrincipal(c).Subject, &req)
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+	entry.Target(audit.TargetWebhook, result.Id)
+
+	response.Created("", result)
+	c.JSON(http.StatusCreated, response)
+}
+
+/**
+ * 웹훅 목록 조회 API (관리자)
+ * @return : 등록한 웹훅 목록 (최신순, 서명 키 제외)
+ */
+func (ctrl *WebhookController) GetAll(c *gin.Context) {
+	response := rest.NewApiResponse()
+
+	result, err := ctrl.usecase.GetEndpoints(c.Request.Context())
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	response.Succeed("", result)
+	c.JSON(http.StatusOK, response)
+}
+
+/**
+ * 웹훅 삭제 API (관리자)
+ * 삭제 후 남은 전달은 보내지 않고 dead 로 처리
+ */
+func (ctrl *WebhookController) DeleteOne(c *gin.Context) {
+	response := rest.NewApiResponse()
+	entry := auditEntry(c, audit.ActionWebhookChange).Target(audit.TargetWebhook, c.Param("id"))
+	defer auditResponse(c, ctrl.audit, entry, response)
+
+	if err := ctrl.usecase.DeleteEndpoint(c.Request.Context(), c.Param("id")); err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	response.Succeed("", nil)
+	c.JSON(http.StatusOK, response)
+}
+
+/**
+ * 웹훅 테스트 API (관리자)
+ * 구독 여부와 관계없이 webhook.test 이벤트를 전달 대기열에 추가
+ * @return : 추가한 전달 (결과는 전달 기록 조회 API 로 확인)
+ */
+func (ctrl *WebhookController) Test(c *gin.Context) {
+	response := rest.NewApiResponse()
+	entry := auditEntry(c, audit.ActionWebhookSend).Target(audit.TargetWebhook, c.Param("id"))
+	defer auditResponse(c, ctrl.audit, entry, response)
+
+	result, err := ctrl.usecase.Test(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	response.Succeed("", result)
+	c.JSON(http.StatusAccepted, response)
+}
+
+/**
+ * 웹훅 전달 기록 조회 API (관리자)
+ * 웹훅, 이벤트, 상태로 조회
+ * @return : 최신순 전달 목록과 시도별 응답 코드 / 오류 (page, size, 전체 건수)
+ */
+func (ctrl *WebhookController) GetDeliveries(c *gin.Context) {
+	response := rest.NewApiResponse()
+
+	var req dto.GetWebhookDeliveriesRequest
+	if !bindQuery(c, response, &req) {
+		return
+	}
+
+	result, err := ctrl.usecase.GetDeliveries(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	response.Succeed("", result)
+	c.JSON(http.StatusOK, response)
+}
+
+/**
+ * 웹훅 다시 전달 API (관리자)
+ * 전달을 마쳤거나 (succeeded) 재시도를 포기한 (dead) 전달을 처음부터 다시 시도
+ * @return : 다시 대기열에 추가한 전달
+ */
+func (ctrl *WebhookController) Replay(c *gin.Context) {
+	response := rest.NewApiResponse()
+	entry := auditEntry(c, audit.ActionWebhookSend).Target(audit.TargetDelivery, c.Param("id"))
+	defer auditResponse(c, ctrl.audit, entry, response)
+
+	result, err := ctrl.usecase.Replay(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(err.CodeDesc, err.Message, err.Data)
+		c.JSON(err.CodeDesc.HttpStatusCode, response)
+		return
+	}
+
+	response.Succeed("", result)
+	c.JSON(http.StatusAccepted, response)
+}
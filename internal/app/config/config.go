@@ -0,0 +1,794 @@
+// Package config 는 API 서버 설정을 읽고 검증
+//
+// 설정은 아래 순서로 덮어씀 (뒤에 있을수록 우선)
+//  1. 기본값
+//  2. 설정 파일 (-config 혹은 CONFIG_FILE, .env 형식, 지정하지 않으면 config/.env 혹은 ../config/.env)
+//  3. 환경 변수
+//  4. 명령행 옵션 (ex. -server-port 8080, -mongo-url mongodb://...)
+//
+// 설정 파일에서 읽은 값은 Config 로만 전달하며 환경 변수로 반영하지 않음
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // RISK_TIMEZONE 을 OS 의 timezone 정보 없이도 검증하기 위함
+
+	"signupin-api/internal/pkg/logging"
+
+	"github.com/joho/godotenv"
+	"golang.org/x/exp/slog"
+)
+
+// 트레이스 전송 방식 (TRACING_EXPORTER)
+const (
+	TracingNone   = "none"
+	TracingOTLP   = "otlp"   // OTLP/HTTP 수집기 (TRACING_OTLP_ENDPOINT)
+	TracingStdout = "stdout" // 표준 출력 (로컬 개발용)
+	TracingFile   = "file"   // TRACING_FILE 에 JSON 으로 기록 (로컬 개발용)
+)
+
+// 회원 / 세션 저장소 (STORAGE_BACKEND)
+const (
+	BackendMongo    = "mongo"
+	BackendMemory   = "memory"
+	BackendPostgres = "postgres"
+	BackendSQLite   = "sqlite"
+)
+
+// 도메인 이벤트를 전달할 곳 (OUTBOX_SINKS)
+const (
+	SinkLog     = "log"     // 이벤트를 로그로 기록
+	SinkWebhook = "webhook" // 관리자 API 로 등록한 웹훅 주소로 서명해서 전달
+)
+
+// 소셜 로그인 인증 제공자 (SOCIAL_PROVIDERS), mock 은 로컬 개발용으로 주소를 모두 직접 지정
+var SocialProviders = []string{"google", "kakao", "naver", "apple", "mock"}
+
+// 설정 파일을 지정하지 않은 경우 순서대로 찾아서 처음 발견한 파일 사용 (저장소 루트 혹은 cmd/ 에서 실행)
+var defaultFiles = []string{"config/.env", "../config/.env"}
+
+// Config 는 API 서버 설정
+type Config struct {
+	Server  Server
+	Storage Storage
+	Mongo   Mongo
+	CORS    CORS
+	JWT     JWT
+	SMS     SMS
+	Email   Email
+	Social  Social
+	Risk    Risk
+	Tracing Tracing
+	Log     Log
+	Admin   Admin
+	Outbox  Outbox
+	Webhook Webhook
+
+	File  string // 읽은 설정 파일 (없으면 빈 값)
+	Print bool   // -print-config, 설정을 출력하고 종료
+}
+
+type Server struct {
+	Host            string        // SERVER_HOST, 로그 / 문서 표시용 호스트
+	Port            int           // SERVER_PORT
+	PublicBaseURL   string        // PUBLIC_BASE_URL, 알림 링크의 API 주소 (ex. https://example.com/api)
+	RequestTimeout  time.Duration // REQUEST_TIMEOUT, 요청별 기본 deadline
+	ShutdownTimeout time.Duration // SHUTDOWN_TIMEOUT, 종료 시 진행 중인 요청을 기다리는 시간
+	DrainDelay      time.Duration // SHUTDOWN_DRAIN_DELAY, 종료 신호 후 /readyz 를 not-ready 로 두고 요청을 계속 받는 시간
+
+	MaxBodyBytes        int  // MAX_BODY_BYTES, 요청 본문 최대 크기 (bytes, 넘으면 413)
+	RejectUnknownFields bool // REJECT_UNKNOWN_FIELDS, 요청 JSON 에 요청 모델에 없는 필드가 있으면 400 (false 는 무시)
+}
+
+// Addr 는 http.Server 의 접속 주소
+func (s Server) Addr() string {
+	return fmt.Sprintf(":%d", s.Port)
+}
+
+// Storage 는 회원 / 인증번호 / 회원 이벤트 / 세션 저장소 설정
+// postgres, sqlite 는 이 저장소만 SQL DB 에 두고 나머지 저장소 (MongoStores) 는 MongoDB 에 두는 혼합 구성이므로 MONGO_URL 도 필요
+type Storage struct {
+	Backend     string // STORAGE_BACKEND (mongo, memory, postgres, sqlite)
+	DatabaseURL string // DATABASE_URL, STORAGE_BACKEND 가 postgres, sqlite 인 경우만 사용
+}
+
+// MongoStores 는 STORAGE_BACKEND 가 memory 가 아니면 항상 MongoDB 에 두는 저장소 (SQL 저장소 없음)
+var MongoStores = []string{"devices", "risk", "oidc clients", "social identities", "api keys", "audit log", "webhooks"}
+
+// SQL 은 회원 / 세션을 SQL DB 에 두는 구성 (postgres, sqlite) 인지 여부
+func (s Storage) SQL() bool {
+	return s.Backend == BackendPostgres || s.Backend == BackendSQLite
+}
+
+type Mongo struct {
+	URL      string // MONGO_URL
+	Database string // MONGO_DATABASE
+}
+
+type CORS struct {
+	AllowOrigins []string // FRONT_SERVER_HOST (쉼표 구분)
+}
+
+type JWT struct {
+	Secret         string // API_SECRET, 회원 JWT 서명 키
+	OIDCIssuer     string // OIDC_ISSUER, ID 토큰의 iss 와 discovery 문서의 주소 (ex. https://example.com/api)
+	OIDCSigningKey string // OIDC_SIGNING_KEY, RSA 개인 키 파일 (STORAGE_BACKEND 가 memory 인 경우만 비워둘 수 있음, 빈 값은 실행할 때마다 생성)
+
+	SessionTTL time.Duration // SESSION_TTL, 로그인 세션 (세션에 묶인 회원 JWT) 유효 시간
+}
+
+type SMS struct {
+	APIURL string // SMS_API_URL (빈 값은 로그로 대신함)
+	APIKey string // SMS_API_KEY
+}
+
+type Email struct {
+	SMTPAddr string // SMTP_ADDR (빈 값은 로그로 대신함)
+	From     string // SMTP_FROM
+	Username string // SMTP_USERNAME
+	Password string // SMTP_PASSWORD
+}
+
+type Social struct {
+	Providers []string                   // SOCIAL_PROVIDERS, 사용할 인증 제공자 (쉼표 구분, SocialProviders 중)
+	Settings  map[string]*SocialProvider // SOCIAL_<NAME>_*, 인증 제공자별 설정 (SocialProviders 마다 하나)
+}
+
+// SocialProvider 는 인증 제공자 하나의 설정, 비어있는 항목은 알려진 인증 제공자의 기본 설정 사용
+type SocialProvider struct {
+	ClientID           string   // SOCIAL_<NAME>_CLIENT_ID
+	ClientSecret       string   // SOCIAL_<NAME>_CLIENT_SECRET
+	AuthURL            string   // SOCIAL_<NAME>_AUTH_URL
+	TokenURL           string   // SOCIAL_<NAME>_TOKEN_URL
+	UserInfoURL        string   // SOCIAL_<NAME>_USERINFO_URL
+	RedirectURL        string   // SOCIAL_<NAME>_REDIRECT_URL
+	Issuer             string   // SOCIAL_<NAME>_ISSUER
+	Scopes             []string // SOCIAL_<NAME>_SCOPES (공백 혹은 쉼표 구분)
+	SubjectClaim       string   // SOCIAL_<NAME>_SUBJECT_CLAIM
+	EmailClaim         string   // SOCIAL_<NAME>_EMAIL_CLAIM
+	NameClaim          string   // SOCIAL_<NAME>_NAME_CLAIM
+	EmailVerifiedClaim string   // SOCIAL_<NAME>_EMAIL_VERIFIED_CLAIM, 값이 true 인 경우에만 이메일로 회원 가입
+}
+
+type Risk struct {
+	Blocklist        []string // RISK_BLOCKLIST, 차단할 IP 혹은 CIDR (쉼표 구분)
+	BlocklistFile    string   // RISK_BLOCKLIST_FILE, 한 줄에 하나
+	GeoDB            string   // RISK_GEO_DB, 한 줄에 "CIDR,국가코드" 형식의 파일
+	AllowedCountries []string // RISK_ALLOWED_COUNTRIES (ex. KR,JP)
+	UnusualHours     string   // RISK_UNUSUAL_HOURS, 평소와 다른 로그인 시간대 (ex. 1-6, 빈 값은 사용 안함)
+	Timezone         string   // RISK_TIMEZONE
+	StepUpScore      int      // RISK_STEP_UP_SCORE, 이 점수 이상이면 추가 인증
+	DenyScore        int      // RISK_DENY_SCORE, 이 점수 이상이면 거부
+}
+
+type Tracing struct {
+	Exporter     string  // TRACING_EXPORTER (none, otlp, stdout, file)
+	OTLPEndpoint string  // TRACING_OTLP_ENDPOINT, OTLP/HTTP 수집기 주소 (ex. http://localhost:4318)
+	File         string  // TRACING_FILE, Exporter 가 file 인 경우 기록할 파일
+	SampleRatio  float64 // TRACING_SAMPLE_RATIO, 상위 서비스가 결정하지 않은 요청 중 기록할 비율 (0 ~ 1)
+}
+
+type Admin struct {
+	Subjects []string // ADMIN_SUBJECTS, 관리자 API 를 호출할 수 있는 회원 / 클라이언트 아이디 (쉼표 구분)
+}
+
+type Outbox struct {
+	Sinks     []string      // OUTBOX_SINKS, 이벤트를 전달할 곳 (쉼표 구분, log, webhook)
+	Interval  time.Duration // OUTBOX_INTERVAL, 전달할 이벤트를 조회하는 주기 (재시도 대기 시간의 기준)
+	BatchSize int           // OUTBOX_BATCH_SIZE, 한번에 조회하는 이벤트 수
+}
+
+// Webhook 은 OUTBOX_SINKS 에 webhook 이 있는 경우 사용 (조회 주기 / 수는 Outbox 와 같음)
+type Webhook struct {
+	Timeout       time.Duration // WEBHOOK_TIMEOUT, 요청 하나의 제한 시간
+	MaxAttempts   int           // WEBHOOK_MAX_ATTEMPTS, 이 횟수만큼 실패하면 dead (관리자 API 로 다시 전달)
+	RetryInterval time.Duration // WEBHOOK_RETRY_INTERVAL, 첫 실패 후 대기 시간 (이후 두배씩)
+	MaxBackoff    time.Duration // WEBHOOK_MAX_BACKOFF, 재시도 대기 시간의 최대값
+}
+
+type Log struct {
+	Level string // LOG_LEVEL (debug, info, warn, error)
+}
+
+// Default 는 기본값만 채운 설정
+func Default() *Config {
+	settings := map[string]*SocialProvider{}
+	for _, name := range SocialProviders {
+		settings[name] = &SocialProvider{}
+	}
+
+	return &Config{
+		Server: Server{
+			Port:            8080,
+			RequestTimeout:  12 * time.Second,
+			ShutdownTimeout: 15 * time.Second,
+			MaxBodyBytes:    1 << 20,
+		},
+		Storage: Storage{Backend: BackendMongo},
+		Mongo:   Mongo{Database: "kkodecaffeine"},
+		JWT:     JWT{SessionTTL: 24 * time.Hour},
+		Social:  Social{Settings: settings},
+		Risk:    Risk{UnusualHours: "1-6", Timezone: "Asia/Seoul", StepUpScore: 40, DenyScore: 80},
+		Tracing: Tracing{Exporter: TracingNone, SampleRatio: 1},
+		Log:     Log{Level: "info"},
+		Outbox:  Outbox{Sinks: []string{SinkLog}, Interval: time.Second, BatchSize: 100},
+		Webhook: Webhook{Timeout: 10 * time.Second, MaxAttempts: 8, RetryInterval: 30 * time.Second, MaxBackoff: time.Hour},
+	}
+}
+
+// 값 종류에 따라 Dump 에서 가리는 방식
+const (
+	plain  = iota
+	secret // 값 전체를 가림
+	dsn    // URL 의 비밀번호만 가림
+)
+
+// field 는 설정 항목 하나 (환경 변수, 설정 파일, 명령행 옵션에서 같은 이름 사용)
+type field struct {
+	key   string // 환경 변수 이름 (ex. SERVER_PORT), 명령행 옵션은 소문자 + "-" (ex. -server-port)
+	usage string
+	mask  int
+	get   func() string
+	set   func(string) error
+}
+
+func (f *field) flagName() string {
+	return strings.ReplaceAll(strings.ToLower(f.key), "_", "-")
+}
+
+func (c *Config) fields() []*field {
+	fields := []*field{
+		stringField("SERVER_HOST", "host shown in logs and docs", plain, &c.Server.Host),
+		intField("SERVER_PORT", "port to listen on", &c.Server.Port),
+		stringField("PUBLIC_BASE_URL", "public API base URL used in notification links", plain, &c.Server.PublicBaseURL),
+		durationField("REQUEST_TIMEOUT", "default deadline of each request", &c.Server.RequestTimeout),
+		durationField("SHUTDOWN_TIMEOUT", "time to wait for in-flight requests on shutdown", &c.Server.ShutdownTimeout),
+		durationField("SHUTDOWN_DRAIN_DELAY", "time to keep serving with /readyz not ready before shutdown", &c.Server.DrainDelay),
+		intField("MAX_BODY_BYTES", "maximum request body size in bytes", &c.Server.MaxBodyBytes),
+		boolField("REJECT_UNKNOWN_FIELDS", "reject JSON request bodies with fields the API does not accept", &c.Server.RejectUnknownFields),
+
+		stringField("STORAGE_BACKEND", "user / session storage (mongo, memory, postgres, sqlite); postgres and sqlite still keep the other stores in MongoDB", plain, &c.Storage.Backend),
+		stringField("DATABASE_URL", "SQL database URL for postgres / sqlite backends (users and sessions only)", dsn, &c.Storage.DatabaseURL),
+
+		stringField("MONGO_URL", "MongoDB connection URL", dsn, &c.Mongo.URL),
+		stringField("MONGO_DATABASE", "MongoDB database name", plain, &c.Mongo.Database),
+
+		listField("FRONT_SERVER_HOST", "allowed CORS origins (comma separated)", &c.CORS.AllowOrigins),
+
+		stringField("API_SECRET", "user JWT signing secret", secret, &c.JWT.Secret),
+		stringField("OIDC_ISSUER", "OpenID Connect issuer", plain, &c.JWT.OIDCIssuer),
+		stringField("OIDC_SIGNING_KEY", "OpenID Connect RSA private key file", plain, &c.JWT.OIDCSigningKey),
+		durationField("SESSION_TTL", "lifetime of a sign-in session and its user JWT", &c.JWT.SessionTTL),
+
+		stringField("SMS_API_URL", "SMS gateway URL (empty logs messages instead)", plain, &c.SMS.APIURL),
+		stringField("SMS_API_KEY", "SMS gateway API key", secret, &c.SMS.APIKey),
+
+		stringField("SMTP_ADDR", "SMTP server host:port (empty logs messages instead)", plain, &c.Email.SMTPAddr),
+		stringField("SMTP_FROM", "sender address", plain, &c.Email.From),
+		stringField("SMTP_USERNAME", "SMTP username", plain, &c.Email.Username),
+		stringField("SMTP_PASSWORD", "SMTP password", secret, &c.Email.Password),
+
+		listField("SOCIAL_PROVIDERS", "social sign-in providers to enable (comma separated: "+strings.Join(SocialProviders, ", ")+")", &c.Social.Providers),
+
+		listField("RISK_BLOCKLIST", "blocked IPs or CIDRs (comma separated)", &c.Risk.Blocklist),
+		stringField("RISK_BLOCKLIST_FILE", "file of blocked IPs or CIDRs, one per line", plain, &c.Risk.BlocklistFile),
+		stringField("RISK_GEO_DB", "file of \"CIDR,country\" lines used for country rules", plain, &c.Risk.GeoDB),
+		listField("RISK_ALLOWED_COUNTRIES", "countries allowed to sign in (comma separated, empty allows all)", &c.Risk.AllowedCountries),
+		stringField("RISK_UNUSUAL_HOURS", "unusual sign-in hours as <from>-<to> (empty disables)", plain, &c.Risk.UnusualHours),
+		stringField("RISK_TIMEZONE", "timezone of RISK_UNUSUAL_HOURS", plain, &c.Risk.Timezone),
+		intField("RISK_STEP_UP_SCORE", "risk score requiring step-up verification", &c.Risk.StepUpScore),
+		intField("RISK_DENY_SCORE", "risk score denying sign-in", &c.Risk.DenyScore),
+
+		stringField("TRACING_EXPORTER", "trace exporter (none, otlp, stdout, file)", plain, &c.Tracing.Exporter),
+		stringField("TRACING_OTLP_ENDPOINT", "OTLP/HTTP collector URL", plain, &c.Tracing.OTLPEndpoint),
+		stringField("TRACING_FILE", "file to write traces to when TRACING_EXPORTER is file", plain, &c.Tracing.File),
+		floatField("TRACING_SAMPLE_RATIO", "ratio of new traces to sample (0 to 1)", &c.Tracing.SampleRatio),
+
+		stringField("LOG_LEVEL", "minimum log level (debug, info, warn, error)", plain, &c.Log.Level),
+
+		listField("ADMIN_SUBJECTS", "user / client IDs allowed to call admin APIs (comma separated)", &c.Admin.Subjects),
+
+		listField("OUTBOX_SINKS", "where to deliver domain events (comma separated: log, webhook)", &c.Outbox.Sinks),
+		durationField("OUTBOX_INTERVAL", "how often the relay polls for pending events", &c.Outbox.Interval),
+		intField("OUTBOX_BATCH_SIZE", "events fetched per poll", &c.Outbox.BatchSize),
+
+		durationField("WEBHOOK_TIMEOUT", "timeout of each webhook request", &c.Webhook.Timeout),
+		intField("WEBHOOK_MAX_ATTEMPTS", "failed attempts before a webhook delivery is dead-lettered", &c.Webhook.MaxAttempts),
+		durationField("WEBHOOK_RETRY_INTERVAL", "wait after the first failed webhook attempt (doubled each retry)", &c.Webhook.RetryInterval),
+		durationField("WEBHOOK_MAX_BACKOFF", "maximum wait between webhook retries", &c.Webhook.MaxBackoff),
+	}
+
+	// 인증 제공자별 설정 (ex. SOCIAL_GOOGLE_CLIENT_ID)
+	if c.Social.Settings == nil {
+		c.Social.Settings = map[string]*SocialProvider{}
+	}
+	for _, name := range SocialProviders {
+		p, ok := c.Social.Settings[name]
+		if !ok {
+			p = &SocialProvider{}
+			c.Social.Settings[name] = p
+		}
+		prefix, label := "SOCIAL_"+strings.ToUpper(name)+"_", name+" "
+		fields = append(fields,
+			stringField(prefix+"CLIENT_ID", label+"OAuth client ID", plain, &p.ClientID),
+			stringField(prefix+"CLIENT_SECRET", label+"OAuth client secret", secret, &p.ClientSecret),
+			stringField(prefix+"AUTH_URL", label+"authorization endpoint (empty uses the preset)", plain, &p.AuthURL),
+			stringField(prefix+"TOKEN_URL", label+"token endpoint (empty uses the preset)", plain, &p.TokenURL),
+			stringField(prefix+"USERINFO_URL", label+"userinfo endpoint (empty uses the preset)", plain, &p.UserInfoURL),
+			stringField(prefix+"REDIRECT_URL", label+"callback URL of this API", plain, &p.RedirectURL),
+			stringField(prefix+"ISSUER", label+"expected ID token issuer", plain, &p.Issuer),
+			wordsField(prefix+"SCOPES", label+"scopes to request (space or comma separated)", &p.Scopes),
+			stringField(prefix+"SUBJECT_CLAIM", label+"claim path of the account ID", plain, &p.SubjectClaim),
+			stringField(prefix+"EMAIL_CLAIM", label+"claim path of the email", plain, &p.EmailClaim),
+			stringField(prefix+"EMAIL_VERIFIED_CLAIM", label+"claim path of the email verification flag (first sign-in requires true)", plain, &p.EmailVerifiedClaim),
+			stringField(prefix+"NAME_CLAIM", label+"claim path of the name", plain, &p.NameClaim),
+		)
+	}
+
+	return fields
+}
+
+// Load 는 args (os.Args[1:]) 의 명령행 옵션, 환경 변수, 설정 파일을 합쳐 검증한 설정 반환
+// 설정 항목은 Config 로만 전달하며 환경 변수로 되돌려 쓰지 않음 (API_SECRET 등 비밀 값이 하위 프로세스로 새지 않도록)
+func Load(args []string) (*Config, error) {
+	cfg := Default()
+	fields := cfg.fields()
+
+	fs := flag.NewFlagSet("signupin-api", flag.ContinueOnError)
+	file := fs.String("config", "", "config file (.env format, default CONFIG_FILE or config/.env)")
+	fs.BoolVar(&cfg.Print, "print-config", false, "print the resolved config with secrets redacted and exit")
+	flags := map[string]*string{}
+	for _, f := range fields {
+		flags[f.key] = fs.String(f.flagName(), "", f.usage+" ("+f.key+")")
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	path, err := resolveFile(*file)
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]string
+	if len(path) > 0 {
+		values, err = godotenv.Read(path)
+		if err != nil {
+			return nil, fmt.Errorf("config file %s: %w", path, err)
+		}
+		cfg.File = path
+	}
+
+	var errs Errors
+	for _, f := range fields {
+		source, value, ok := "", "", false
+		if v, set := values[f.key]; set {
+			source, value, ok = path, v, true
+		}
+		if v, set := os.LookupEnv(f.key); set {
+			source, value, ok = "environment", v, true
+		}
+		fs.Visit(func(fl *flag.Flag) {
+			if fl.Name == f.flagName() {
+				source, value, ok = "-"+fl.Name, *flags[f.key], true
+			}
+		})
+		if !ok {
+			continue
+		}
+		if err := f.set(value); err != nil {
+			errs = append(errs, fmt.Sprintf("%s (from %s): %v", f.key, source, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// resolveFile 은 읽을 설정 파일 경로 반환, 명시적으로 지정한 파일이 없으면 오류
+func resolveFile(path string) (string, error) {
+	if len(path) == 0 {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if len(path) > 0 {
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("config file: %w", err)
+		}
+		return path, nil
+	}
+
+	for _, candidate := range defaultFiles {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", nil
+}
+
+// Errors 는 잘못된 설정 항목 목록
+type Errors []string
+
+func (e Errors) Error() string {
+	return "invalid config:\n  - " + strings.Join(e, "\n  - ")
+}
+
+// Validate 는 잘못된 설정 항목을 모두 모아서 반환
+func (c *Config) Validate() error {
+	var errs Errors
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Sprintf(format, args...))
+	}
+
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		fail("SERVER_PORT: must be between 1 and 65535, got %d", c.Server.Port)
+	}
+	if len(c.Server.PublicBaseURL) > 0 && !isHTTPURL(c.Server.PublicBaseURL) {
+		fail("PUBLIC_BASE_URL: must be an http(s) URL, got %q", c.Server.PublicBaseURL)
+	}
+	if c.Server.RequestTimeout <= 0 {
+		fail("REQUEST_TIMEOUT: must be positive, got %s", c.Server.RequestTimeout)
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		fail("SHUTDOWN_TIMEOUT: must be positive, got %s", c.Server.ShutdownTimeout)
+	}
+	if c.Server.DrainDelay < 0 {
+		fail("SHUTDOWN_DRAIN_DELAY: must not be negative, got %s", c.Server.DrainDelay)
+	}
+	if c.Server.MaxBodyBytes < 1 {
+		fail("MAX_BODY_BYTES: must be positive, got %d", c.Server.MaxBodyBytes)
+	}
+
+	switch c.Storage.Backend {
+	case BackendMongo, BackendMemory:
+		// SQL DB 를 쓰지 않는데 DATABASE_URL 만 설정한 경우 (postgres 로 옮겼다고 착각하지 않도록)
+		if len(c.Storage.DatabaseURL) > 0 {
+			fail("DATABASE_URL: only used when STORAGE_BACKEND is postgres or sqlite, got STORAGE_BACKEND=%s", c.Storage.Backend)
+		}
+	case BackendPostgres:
+		if len(c.Storage.DatabaseURL) == 0 {
+			fail("DATABASE_URL: required when STORAGE_BACKEND is %s", c.Storage.Backend)
+		} else if u, err := url.Parse(c.Storage.DatabaseURL); err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+			fail("DATABASE_URL: must start with postgres:// or postgresql:// when STORAGE_BACKEND is postgres")
+		}
+	case BackendSQLite:
+		if len(c.Storage.DatabaseURL) == 0 {
+			fail("DATABASE_URL: required when STORAGE_BACKEND is %s", c.Storage.Backend)
+		}
+	default:
+		fail("STORAGE_BACKEND: must be one of mongo, memory, postgres, sqlite, got %q", c.Storage.Backend)
+	}
+
+	// MongoStores 는 memory 가 아니면 STORAGE_BACKEND 와 상관없이 MongoDB 사용 (postgres, sqlite 도 MongoDB 필요)
+	if c.Storage.Backend != BackendMemory {
+		if len(c.Mongo.URL) == 0 {
+			if c.Storage.SQL() {
+				fail("MONGO_URL: required when STORAGE_BACKEND is %s (only users and sessions are stored in SQL, %s stay in MongoDB)", c.Storage.Backend, strings.Join(MongoStores, ", "))
+			} else {
+				fail("MONGO_URL: required")
+			}
+		} else if u, err := url.Parse(c.Mongo.URL); err != nil || (u.Scheme != "mongodb" && u.Scheme != "mongodb+srv") {
+			fail("MONGO_URL: must start with mongodb:// or mongodb+srv://")
+		}
+		if len(c.Mongo.Database) == 0 {
+			fail("MONGO_DATABASE: required")
+		}
+	}
+
+	// gin-contrib/cors 는 scheme 없는 origin 을 허용하지 않음
+	if len(c.CORS.AllowOrigins) == 0 {
+		fail("FRONT_SERVER_HOST: at least one origin is required")
+	}
+	for _, origin := range c.CORS.AllowOrigins {
+		if origin != "*" && !isHTTPURL(origin) {
+			fail("FRONT_SERVER_HOST: origin %q must be \"*\" or start with http:// or https://", origin)
+		}
+	}
+
+	if len(c.JWT.Secret) == 0 {
+		fail("API_SECRET: required")
+	}
+	if c.JWT.SessionTTL <= 0 {
+		fail("SESSION_TTL: must be positive, got %s", c.JWT.SessionTTL)
+	}
+	// 빈 issuer 는 iss 가 빈 토큰과 상대 경로의 discovery 주소를, 빈 서명 키는 재시작마다 바뀌는 키를 만들므로 memory 에서만 허용
+	if len(c.JWT.OIDCIssuer) > 0 && !isHTTPURL(c.JWT.OIDCIssuer) {
+		fail("OIDC_ISSUER: must be an http(s) URL, got %q", c.JWT.OIDCIssuer)
+	} else if len(c.JWT.OIDCIssuer) == 0 && c.Storage.Backend != BackendMemory {
+		fail("OIDC_ISSUER: required unless STORAGE_BACKEND is memory")
+	}
+	if len(c.JWT.OIDCSigningKey) > 0 {
+		if _, err := os.Stat(c.JWT.OIDCSigningKey); err != nil {
+			fail("OIDC_SIGNING_KEY: %v", err)
+		}
+	} else if c.Storage.Backend != BackendMemory {
+		fail("OIDC_SIGNING_KEY: required unless STORAGE_BACKEND is memory")
+	}
+
+	if len(c.SMS.APIURL) > 0 && !isHTTPURL(c.SMS.APIURL) {
+		fail("SMS_API_URL: must be an http(s) URL, got %q", c.SMS.APIURL)
+	}
+	if len(c.Email.SMTPAddr) > 0 && len(c.Email.From) == 0 {
+		fail("SMTP_FROM: required when SMTP_ADDR is set")
+	}
+
+	for _, name := range c.Social.Providers {
+		p, ok := c.Social.Settings[name]
+		if !ok || p == nil {
+			fail("SOCIAL_PROVIDERS: unknown provider %q, must be one of %s", name, strings.Join(SocialProviders, ", "))
+			continue
+		}
+		prefix := "SOCIAL_" + strings.ToUpper(name) + "_"
+		if len(p.ClientID) == 0 {
+			fail("%sCLIENT_ID: required when SOCIAL_PROVIDERS has %s", prefix, name)
+		}
+		if len(p.RedirectURL) == 0 {
+			fail("%sREDIRECT_URL: required when SOCIAL_PROVIDERS has %s", prefix, name)
+		}
+		for _, u := range []struct{ key, value string }{{"AUTH_URL", p.AuthURL}, {"TOKEN_URL", p.TokenURL}, {"USERINFO_URL", p.UserInfoURL}, {"REDIRECT_URL", p.RedirectURL}} {
+			if len(u.value) > 0 && !isHTTPURL(u.value) {
+				fail("%s%s: must be an http(s) URL, got %q", prefix, u.key, u.value)
+			}
+		}
+	}
+
+	for _, entry := range c.Risk.Blocklist {
+		if net.ParseIP(entry) == nil {
+			if _, _, err := net.ParseCIDR(entry); err != nil {
+				fail("RISK_BLOCKLIST: %q is not an IP or CIDR", entry)
+			}
+		}
+	}
+	if len(c.Risk.BlocklistFile) > 0 {
+		if _, err := os.Stat(c.Risk.BlocklistFile); err != nil {
+			fail("RISK_BLOCKLIST_FILE: %v", err)
+		}
+	}
+	if len(c.Risk.GeoDB) > 0 {
+		if _, err := os.Stat(c.Risk.GeoDB); err != nil {
+			fail("RISK_GEO_DB: %v", err)
+		}
+	}
+	if !validHours(c.Risk.UnusualHours) {
+		fail("RISK_UNUSUAL_HOURS: must be <from>-<to> with hours between 0 and 23, got %q", c.Risk.UnusualHours)
+	}
+	if _, err := time.LoadLocation(c.Risk.Timezone); err != nil || len(c.Risk.Timezone) == 0 {
+		fail("RISK_TIMEZONE: unknown timezone %q", c.Risk.Timezone)
+	}
+	if c.Risk.StepUpScore < 1 {
+		fail("RISK_STEP_UP_SCORE: must be positive, got %d", c.Risk.StepUpScore)
+	}
+	if c.Risk.DenyScore < c.Risk.StepUpScore {
+		fail("RISK_DENY_SCORE: must be at least RISK_STEP_UP_SCORE (%d), got %d", c.Risk.StepUpScore, c.Risk.DenyScore)
+	}
+
+	switch c.Tracing.Exporter {
+	case TracingNone, TracingStdout:
+	case TracingOTLP:
+		if !isHTTPURL(c.Tracing.OTLPEndpoint) {
+			fail("TRACING_OTLP_ENDPOINT: must be an http(s) URL when TRACING_EXPORTER is otlp, got %q", c.Tracing.OTLPEndpoint)
+		}
+	case TracingFile:
+		if len(c.Tracing.File) == 0 {
+			fail("TRACING_FILE: required when TRACING_EXPORTER is file")
+		}
+	default:
+		fail("TRACING_EXPORTER: must be one of none, otlp, stdout, file, got %q", c.Tracing.Exporter)
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		fail("TRACING_SAMPLE_RATIO: must be between 0 and 1, got %v", c.Tracing.SampleRatio)
+	}
+
+	if _, err := logging.ParseLevel(c.Log.Level); err != nil {
+		fail("LOG_LEVEL: must be one of debug, info, warn, error, got %q", c.Log.Level)
+	}
+
+	for _, sink := range c.Outbox.Sinks {
+		if sink != SinkLog && sink != SinkWebhook {
+			fail("OUTBOX_SINKS: unknown sink %q, must be one of log, webhook", sink)
+		}
+	}
+	if c.Outbox.Interval <= 0 {
+		fail("OUTBOX_INTERVAL: must be positive, got %s", c.Outbox.Interval)
+	}
+	if c.Outbox.BatchSize < 1 || c.Outbox.BatchSize > 1000 {
+		fail("OUTBOX_BATCH_SIZE: must be between 1 and 1000, got %d", c.Outbox.BatchSize)
+	}
+	if c.Webhook.Timeout <= 0 {
+		fail("WEBHOOK_TIMEOUT: must be positive, got %s", c.Webhook.Timeout)
+	}
+	if c.Webhook.MaxAttempts < 1 {
+		fail("WEBHOOK_MAX_ATTEMPTS: must be at least 1, got %d", c.Webhook.MaxAttempts)
+	}
+	if c.Webhook.RetryInterval <= 0 || c.Webhook.MaxBackoff < c.Webhook.RetryInterval {
+		fail("WEBHOOK_MAX_BACKOFF: must be at least WEBHOOK_RETRY_INTERVAL (%s), got %s", c.Webhook.RetryInterval, c.Webhook.MaxBackoff)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// LogLevel 은 LOG_LEVEL 의 slog.Level (Validate 를 통과한 설정)
+func (c *Config) LogLevel() slog.Level {
+	level, _ := logging.ParseLevel(c.Log.Level)
+	return level
+}
+
+// LogValue 는 Dump 와 같이 비밀 값을 가린 설정을 로그 항목으로 반환 (slog.LogValuer)
+func (c *Config) LogValue() slog.Value {
+	var attrs []slog.Attr
+	if len(c.File) > 0 {
+		attrs = append(attrs, slog.String("file", c.File))
+	}
+	for _, f := range c.fields() {
+		attrs = append(attrs, slog.String(f.key, redact(f.get(), f.mask)))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Dump 는 설정을 "KEY=value" 형식으로 출력, 비밀 값은 가리고 URL 은 비밀번호만 가림
+func (c *Config) Dump(w io.Writer) {
+	if len(c.File) > 0 {
+		fmt.Fprintf(w, "# config file: %s\n", c.File)
+	}
+	for _, f := range c.fields() {
+		fmt.Fprintf(w, "%s=%s\n", f.key, redact(f.get(), f.mask))
+	}
+}
+
+func redact(value string, mask int) string {
+	if len(value) == 0 {
+		return value
+	}
+
+	switch mask {
+	case secret:
+		return "******"
+	case dsn:
+		u, err := url.Parse(value)
+		if err != nil || u.User == nil {
+			return value
+		}
+		return u.Redacted()
+	default:
+		return value
+	}
+}
+
+// validHours 는 RISK_UNUSUAL_HOURS 가 비어있거나 "<from>-<to>" 형식인지 확인
+func validHours(value string) bool {
+	if len(value) == 0 {
+		return true
+	}
+	parts := strings.Split(value, "-")
+	if len(parts) != 2 {
+		return false
+	}
+	for _, part := range parts {
+		hour, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || hour < 0 || hour > 23 {
+			return false
+		}
+	}
+	return true
+}
+
+func isHTTPURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && len(u.Host) > 0
+}
+
+func stringField(key, usage string, mask int, target *string) *field {
+	return &field{
+		key: key, usage: usage, mask: mask,
+		get: func() string { return *target },
+		set: func(v string) error {
+			*target = strings.TrimSpace(v)
+			return nil
+		},
+	}
+}
+
+func intField(key, usage string, target *int) *field {
+	return &field{
+		key: key, usage: usage,
+		get: func() string { return strconv.Itoa(*target) },
+		set: func(v string) error {
+			n, err := strconv.Atoi(strings.TrimSpace(v))
+			if err != nil {
+				return errors.New("must be an integer, got " + strconv.Quote(v))
+			}
+			*target = n
+			return nil
+		},
+	}
+}
+
+func boolField(key, usage string, target *bool) *field {
+	return &field{
+		key: key, usage: usage,
+		get: func() string { return strconv.FormatBool(*target) },
+		set: func(v string) error {
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return errors.New("must be true or false, got " + strconv.Quote(v))
+			}
+			*target = b
+			return nil
+		},
+	}
+}
+
+func floatField(key, usage string, target *float64) *field {
+	return &field{
+		key: key, usage: usage,
+		get: func() string { return strconv.FormatFloat(*target, 'f', -1, 64) },
+		set: func(v string) error {
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return errors.New("must be a number, got " + strconv.Quote(v))
+			}
+			*target = f
+			return nil
+		},
+	}
+}
+
+func durationField(key, usage string, target *time.Duration) *field {
+	return &field{
+		key: key, usage: usage,
+		get: func() string { return target.String() },
+		set: func(v string) error {
+			d, err := time.ParseDuration(strings.TrimSpace(v))
+			if err != nil {
+				return errors.New("must be a duration (ex. 10s, 1m), got " + strconv.Quote(v))
+			}
+			*target = d
+			return nil
+		},
+	}
+}
+
+// wordsField 는 공백 혹은 쉼표로 구분한 목록 (ex. OAuth scope)
+func wordsField(key, usage string, target *[]string) *field {
+	return &field{
+		key: key, usage: usage,
+		get: func() string { return strings.Join(*target, " ") },
+		set: func(v string) error {
+			*target = strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+			return nil
+		},
+	}
+}
+
+func listField(key, usage string, target *[]string) *field {
+	return &field{
+		key: key, usage: usage,
+		get: func() string { return strings.Join(*target, ",") },
+		set: func(v string) error {
+			*target = nil
+			for _, item := range strings.Split(v, ",") {
+				if item = strings.TrimSpace(item); len(item) > 0 {
+					*target = append(*target, item)
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -1,10 +1,204 @@
 package server
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
 	api "signupin-api/internal/app/api"
+	"signupin-api/internal/app/config"
+	"signupin-api/internal/pkg/logging"
+	"signupin-api/internal/pkg/tracing"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/exp/slog"
 )
 
-// NewServer Return new server instance
+// Server 는 API 서버
+// Start 로 실행하고, ctx 가 취소되거나 Shutdown 을 호출하면 진행 중인 요청을 마친 뒤 DB 연결까지 종료
+type Server struct {
+	cfg    *config.Config
+	engine *gin.Engine
+	app    api.App
+	http   *http.Server
+
+	shutdownTracing func(context.Context) error
+
+	// 백그라운드 작업 (App.Run) 은 Serve 에서 시작하고 clean 에서 멈춘 뒤 끝날 때까지 대기
+	stopWorkers context.CancelFunc
+	workerCtx   context.Context
+	workersOnce sync.Once
+	workersDone chan struct{}
+
+	cleanOnce sync.Once
+	cleanErr  error
+}
+
+// New 는 라우트를 등록한 서버 반환 (아직 요청을 받지 않음)
+// deps 가 nil 이면 cfg 의 DB 에 연결, 주입한 경우 주입한 저장소만 사용
+func New(cfg *config.Config, deps *api.Dependencies) (*Server, error) {
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.OTLPEndpoint,
+		File:        cfg.Tracing.File,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("setting up tracing: %w", err)
+	}
+
+	engine, app, err := api.CreateAPIApp(cfg, deps)
+	if err != nil {
+		shutdownTracing(context.Background())
+		return nil, err
+	}
+
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	return &Server{
+		cfg:             cfg,
+		engine:          engine,
+		app:             app,
+		http:            &http.Server{Addr: cfg.Server.Addr(), Handler: engine},
+		shutdownTracing: shutdownTracing,
+		workerCtx:       workerCtx,
+		stopWorkers:     stopWorkers,
+		workersDone:     make(chan struct{}),
+	}, nil
+}
+
+// Handler 는 서버를 실행하지 않고 요청을 처리할 수 있는 http.Handler 반환 (테스트, 다른 서버에 포함)
+func (s *Server) Handler() http.Handler {
+	return s.engine
+}
+
+// Start 는 요청을 받기 시작하고 ctx 가 취소될 때까지 대기
+// ctx 가 취소되면 /readyz 를 not-ready 로 바꾸고 DrainDelay 만큼 요청을 더 받은 뒤, ShutdownTimeout 동안 진행 중인 요청을 마친 뒤 종료
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.cfg.Server.Addr())
+	if err != nil {
+		s.clean()
+		return err
+	}
+
+	return s.Serve(ctx, listener)
+}
+
+// Serve 는 Start 와 같지만 이미 열린 listener 사용 (ex. 테스트의 임의 포트)
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	s.workersOnce.Do(func() {
+		go func() {
+			defer close(s.workersDone)
+			s.app.Run(s.workerCtx)
+		}()
+	})
+
+	errs := make(chan error, 1)
+	go func() {
+		slog.Info("listening and serving HTTP", "addr", listener.Addr().String())
+		errs <- s.http.Serve(listener)
+	}()
+
+	select {
+	case err := <-errs:
+		if errors.Is(err, http.ErrServerClosed) {
+			// 다른 곳에서 Shutdown 을 호출한 경우
+			return nil
+		}
+		s.clean()
+		return err
+	case <-ctx.Done():
+	}
+
+	// 로드밸런서가 /readyz 로 인스턴스를 제외할 때까지 요청을 계속 받음
+	s.app.Drain()
+	if delay := s.cfg.Server.DrainDelay; delay > 0 {
+		slog.Info("draining, readyz reports not ready before shutdown", "drain_delay", delay.String())
+		time.Sleep(delay)
+	}
+
+	slog.Info("shutting down, waiting for in-flight requests", "shutdown_timeout", s.cfg.Server.ShutdownTimeout.String())
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	return s.Shutdown(shutdownCtx)
+}
+
+// Shutdown 은 새 요청을 받지 않고 진행 중인 요청을 마친 뒤 DB 연결 종료
+// ctx 가 만료되면 남은 요청을 기다리지 않고 DB 연결을 종료
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.app.Drain()
+
+	err := s.http.Shutdown(ctx)
+	if cerr := s.clean(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// clean 은 백그라운드 작업을 멈추고 App.Clean 을 한번만 호출한 뒤 남은 span 을 전송
+func (s *Server) clean() error {
+	s.cleanOnce.Do(func() {
+		// Serve 를 호출하지 않았으면 시작하지 않은 것으로 처리
+		s.stopWorkers()
+		s.workersOnce.Do(func() { close(s.workersDone) })
+		<-s.workersDone
+
+		s.cleanErr = s.app.Clean()
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.cfg.Server.ShutdownTimeout)
+		defer cancel()
+		if err := s.shutdownTracing(ctx); err != nil && s.cleanErr == nil {
+			s.cleanErr = err
+		}
+	})
+	return s.cleanErr
+}
+
+// NewServer 는 설정 (config/.env, 환경 변수, 명령행 옵션) 을 읽어 서버를 실행하고
+// SIGINT / SIGTERM 을 받으면 진행 중인 요청을 마친 뒤 종료
+// 로그는 표준 출력에 LOG_LEVEL 이상만 JSON 으로 기록 (logging.SetDefault)
 func NewServer() {
-	api.CreateAPIApp()
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		// 설정 오류는 여러 줄이므로 JSON 로그 대신 그대로 출력
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if cfg.Print {
+		cfg.Dump(os.Stdout)
+		return
+	}
+
+	logging.SetDefault(os.Stdout, cfg.LogLevel())
+	if cfg.LogLevel() > slog.LevelDebug {
+		gin.SetMode(gin.ReleaseMode)
+	}
+	slog.Info("loaded config", "config", cfg)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	s, err := New(cfg, nil)
+	if err != nil {
+		slog.Error("starting server failed", "error", err)
+		os.Exit(1)
+	}
+	if err := s.Start(ctx); err != nil {
+		slog.Error("server stopped with error", "error", err)
+		os.Exit(1)
+	}
+	slog.Info("server stopped")
 }
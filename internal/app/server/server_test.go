@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"signupin-api/internal/app/api/apitest"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestHandler(t *testing.T) {
+	s, err := New(apitest.NewConfig(t), apitest.NewDependencies(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Shutdown(context.Background())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/not-me", nil)
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("got status %d, want %d\n%s", rec.Code, http.StatusBadRequest, rec.Body)
+	}
+}
+
+func TestNewReturnsStartupError(t *testing.T) {
+	cfg := apitest.NewConfig(t)
+	cfg.JWT.OIDCSigningKey = filepath.Join(t.TempDir(), "missing.pem")
+
+	if _, err := New(cfg, apitest.NewDependencies(t)); err == nil || !strings.Contains(err.Error(), "OIDC signing key") {
+		t.Fatalf("got %v, want OIDC signing key error", err)
+	}
+}
+
+func TestServeDrainsInFlightRequests(t *testing.T) {
+	cfg := apitest.NewConfig(t)
+	cfg.Server.ShutdownTimeout = 5 * time.Second
+	s, err := New(cfg, apitest.NewDependencies(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan struct{})
+	s.engine.GET("/slow", func(c *gin.Context) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		c.String(http.StatusOK, "done")
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := "http://" + listener.Addr().String()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() { stopped <- s.Serve(ctx, listener) }()
+
+	type result struct {
+		body string
+		err  error
+	}
+	responses := make(chan result, 1)
+	go func() {
+		res, err := http.Get(url + "/api/slow")
+		if err != nil {
+			responses <- result{err: err}
+			return
+		}
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		responses <- result{string(body), err}
+	}()
+
+	<-started
+	cancel()
+
+	got := <-responses
+	if got.err != nil || got.body != "done" {
+		t.Fatalf("in-flight request was not drained: %q %v", got.body, got.err)
+	}
+
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after shutdown")
+	}
+
+	if _, err := http.Get(url + "/api/slow"); err == nil {
+		t.Fatal("server still accepts requests after shutdown")
+	}
+}
+
+func TestReadyzReportsDrainingOnShutdown(t *testing.T) {
+	cfg := apitest.NewConfig(t)
+	cfg.Server.DrainDelay = 300 * time.Millisecond
+	s, err := New(cfg, apitest.NewDependencies(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := "http://" + listener.Addr().String()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() { stopped <- s.Serve(ctx, listener) }()
+
+	get := func(path string) (int, string) {
+		t.Helper()
+		res, err := http.Get(url + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		body, _ := io.ReadAll(res.Body)
+		return res.StatusCode, string(body)
+	}
+
+	if code, body := get("/readyz"); code != http.StatusOK || !strings.Contains(body, `"keys":{"status":"up"`) {
+		t.Fatalf("before shutdown: got %d %s", code, body)
+	}
+
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+
+	// 종료 대기 중에는 요청을 계속 받지만 /readyz 는 503
+	if code, body := get("/readyz"); code != http.StatusServiceUnavailable || !strings.Contains(body, `"status":"draining"`) {
+		t.Fatalf("while draining: got %d %s", code, body)
+	}
+	if code, body := get("/healthz"); code != http.StatusOK {
+		t.Fatalf("healthz while draining: got %d %s", code, body)
+	}
+
+	if err := <-stopped; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,107 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/kamva/mgm/v3"
+)
+
+const (
+	keyPrefix     = "sk_"           // API 키 식별용 접두어
+	prefixLength  = 8               // 목록에 노출되는 키 앞부분 길이
+	touchInterval = 1 * time.Minute // 마지막 사용 시각 갱신 주기
+)
+
+// AllowedScopes 는 API 키에 부여할 수 있는 scope 목록
+var AllowedScopes = []string{"users:read", "clients:write", "audit:read", "webhooks:write"}
+
+// APIKey 는 회원이 스크립트 / CLI 도구에서 사용하기 위해 발급한 키
+type APIKey struct {
+	mgm.DefaultModel `bson:",inline"`
+	UserID           string     `json:"user_id" bson:"user_id"`           // 회원 아이디
+	Name             string     `json:"name" bson:"name"`                 // 키 이름
+	Prefix           string     `json:"prefix" bson:"prefix"`             // 키 앞부분 (조회 및 목록 노출 용도)
+	KeyHash          string     `json:"-" bson:"key_hash"`                // 키 전체 (sha256)
+	Scopes           []string   `json:"scopes" bson:"scopes"`             // 부여된 scope
+	ExpiresAt        *time.Time `json:"expires_at" bson:"expires_at"`     // 만료 시각 (빈 값은 만료 없음)
+	LastUsedAt       *time.Time `json:"last_used_at" bson:"last_used_at"` // 마지막 사용 시각
+	RevokedAt        *time.Time `json:"revoked_at" bson:"revoked_at"`     // 폐기 시각
+}
+
+func newAPIKey(userID, name string, scopes []string, expiresAt *time.Time) (*APIKey, string) {
+	prefix := randomString(prefixLength)
+	raw := keyPrefix + prefix + "_" + randomString(32)
+
+	return &APIKey{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   hashKey(raw),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}, raw
+}
+
+// IsAPIKey 는 토큰이 API 키 형식인지 확인
+func IsAPIKey(raw string) bool {
+	return strings.HasPrefix(raw, keyPrefix)
+}
+
+// parsePrefix 는 sk_<prefix>_<secret> 형식의 키에서 prefix 를 추출
+func parsePrefix(raw string) (string, bool) {
+	if !IsAPIKey(raw) {
+		return "", false
+	}
+
+	rest := strings.TrimPrefix(raw, keyPrefix)
+	if len(rest) <= prefixLength+1 || rest[prefixLength] != '_' {
+		return "", false
+	}
+	return rest[:prefixLength], true
+}
+
+func (k *APIKey) compare(raw string) bool {
+	return subtle.ConstantTimeCompare([]byte(k.KeyHash), []byte(hashKey(raw))) == 1
+}
+
+func (k *APIKey) isActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+func (k *APIKey) needsTouch(now time.Time) bool {
+	return k.LastUsedAt == nil || now.Sub(*k.LastUsedAt) > touchInterval
+}
+
+func isAllowedScope(scope string) bool {
+	for _, allowed := range AllowedScopes {
+		if allowed == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func hashKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomString 은 n 자리의 영문 소문자 / 숫자 문자열을 반환
+func randomString(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	encoded := strings.ToLower(base64.RawURLEncoding.EncodeToString(b))
+	encoded = strings.NewReplacer("-", "a", "_", "b").Replace(encoded)
+	return encoded[:n]
+}
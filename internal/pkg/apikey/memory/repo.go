@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"signupin-api/internal/pkg/apikey"
+
+	"github.com/kamva/mgm/v3"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// apikeyRepo 는 DB 없이 실행하기 위한 apikey.Repository 구현 (테스트, 로컬 개발용)
+// persistence.apikeyRepo 와 동일하게 조회 결과가 없으면 errortype.IsNotFoundErr 로 확인 가능한 오류 반환
+type apikeyRepo struct {
+	mu   sync.Mutex
+	keys []apikey.APIKey
+}
+
+var _ apikey.Repository = &apikeyRepo{}
+
+func (r *apikeyRepo) SaveOne(ctx context.Context, model *apikey.APIKey) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prepare(&model.DefaultModel)
+	r.keys = append(r.keys, *model)
+	return utils.MapToStringID(model.ID), nil
+}
+
+func (r *apikeyRepo) GetByPrefix(ctx context.Context, prefix string) (*apikey.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.keys {
+		if r.keys[i].Prefix == prefix {
+			found := r.keys[i]
+			return &found, nil
+		}
+	}
+	return nil, notFound(bson.M{"prefix": prefix})
+}
+
+// GetAll 은 회원의 키를 최근 생성 순으로 조회
+func (r *apikeyRepo) GetAll(ctx context.Context, userID string) ([]apikey.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	found := []apikey.APIKey{}
+	for _, key := range r.keys {
+		if key.UserID == userID {
+			found = append(found, key)
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].CreatedAt.After(found[j].CreatedAt) })
+	return found, nil
+}
+
+func (r *apikeyRepo) Revoke(ctx context.Context, userID, ID string, revokedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.keys {
+		if r.keys[i].ID.Hex() == ID && r.keys[i].UserID == userID && r.keys[i].RevokedAt == nil {
+			r.keys[i].RevokedAt = &revokedAt
+			return nil
+		}
+	}
+	return notFound(bson.M{"_id": ID, "user_id": userID, "revoked_at": nil})
+}
+
+func (r *apikeyRepo) RevokeAll(ctx context.Context, userID string, revokedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.keys {
+		if r.keys[i].UserID == userID && r.keys[i].RevokedAt == nil {
+			r.keys[i].RevokedAt = &revokedAt
+		}
+	}
+	return nil
+}
+
+func (r *apikeyRepo) TouchLastUsed(ctx context.Context, ID string, usedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.keys {
+		if r.keys[i].ID.Hex() == ID {
+			r.keys[i].LastUsedAt = &usedAt
+		}
+	}
+	return nil
+}
+
+func prepare(model *mgm.DefaultModel) {
+	if model.ID.IsZero() {
+		model.ID = primitive.NewObjectID()
+	}
+	now := time.Now().UTC()
+	model.CreatedAt = now
+	model.UpdatedAt = now
+}
+
+func notFound(filter bson.M) error {
+	return errortype.NotFoundError(mgm.CollName(&apikey.APIKey{}), filter, nil, nil)
+}
+
+func New() apikey.Repository {
+	return &apikeyRepo{}
+}
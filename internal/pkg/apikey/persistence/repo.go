@@ -0,0 +1,115 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"signupin-api/internal/pkg/apikey"
+
+	"github.com/kamva/mgm/v3"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type apikeyRepo struct {
+	client *mongo.Client
+}
+
+var _ apikey.Repository = &apikeyRepo{}
+
+func (r *apikeyRepo) SaveOne(ctx context.Context, model *apikey.APIKey) (string, error) {
+	coll := mgm.Coll(model)
+	err := coll.CreateWithCtx(ctx, model)
+	if err != nil {
+		return "", errortype.ParseAndReturnDBError(err, coll.Name(), nil, nil, nil)
+	}
+
+	insertedID := utils.MapToStringID(model.ID)
+	return insertedID, nil
+}
+
+func (r *apikeyRepo) GetByPrefix(ctx context.Context, prefix string) (*apikey.APIKey, error) {
+	found := &apikey.APIKey{}
+	filter := bson.M{"prefix": prefix}
+
+	coll := mgm.Coll(found)
+	err := coll.FindOne(ctx, filter).Decode(found)
+	if err != nil {
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return found, nil
+}
+
+func (r *apikeyRepo) GetAll(ctx context.Context, userID string) ([]apikey.APIKey, error) {
+	found := []apikey.APIKey{}
+	filter := bson.M{"user_id": userID}
+
+	coll := mgm.Coll(&apikey.APIKey{})
+	err := coll.SimpleFindWithCtx(ctx, &found, filter, options.Find().SetSort(bson.M{"created_at": -1}))
+	if err != nil {
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return found, nil
+}
+
+func (r *apikeyRepo) Revoke(ctx context.Context, userID, ID string, revokedAt time.Time) error {
+	objectID, err := utils.MapToObjectID(ID)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": objectID, "user_id": userID, "revoked_at": nil}
+	update := bson.M{"$set": bson.M{"revoked_at": revokedAt}}
+
+	coll := mgm.Coll(&apikey.APIKey{})
+	result, err := coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), filter, update, nil)
+	}
+	if result.MatchedCount == 0 {
+		return errortype.NotFoundError(coll.Name(), filter, update, nil)
+	}
+
+	return nil
+}
+
+// RevokeAll 은 회원의 폐기되지 않은 키를 모두 폐기 (폐기할 키가 없어도 성공)
+func (r *apikeyRepo) RevokeAll(ctx context.Context, userID string, revokedAt time.Time) error {
+	filter := bson.M{"user_id": userID, "revoked_at": nil}
+	update := bson.M{"$set": bson.M{"revoked_at": revokedAt}}
+
+	coll := mgm.Coll(&apikey.APIKey{})
+	if _, err := coll.UpdateMany(ctx, filter, update); err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), filter, update, nil)
+	}
+
+	return nil
+}
+
+func (r *apikeyRepo) TouchLastUsed(ctx context.Context, ID string, usedAt time.Time) error {
+	objectID, err := utils.MapToObjectID(ID)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": objectID}
+	update := bson.M{"$set": bson.M{"last_used_at": usedAt}}
+
+	coll := mgm.Coll(&apikey.APIKey{})
+	_, err = coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), filter, update, nil)
+	}
+
+	return nil
+}
+
+func New(client *mongo.Client) apikey.Repository {
+	return &apikeyRepo{client}
+}
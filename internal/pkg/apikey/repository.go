@@ -0,0 +1,20 @@
+package apikey
+
+import (
+	"context"
+	"time"
+)
+
+// Repository interface definition
+type Repository interface {
+	SaveOne(ctx context.Context, model *APIKey) (string, error)
+
+	// GET
+	GetByPrefix(ctx context.Context, prefix string) (*APIKey, error)
+	GetAll(ctx context.Context, userID string) ([]APIKey, error)
+
+	// UPDATE
+	Revoke(ctx context.Context, userID, ID string, revokedAt time.Time) error
+	RevokeAll(ctx context.Context, userID string, revokedAt time.Time) error
+	TouchLastUsed(ctx context.Context, ID string, usedAt time.Time) error
+}
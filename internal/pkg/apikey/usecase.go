@@ -0,0 +1,144 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"signupin-api/internal/app/api/dto"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/errorcode"
+	"github.com/kkodecaffeine/go-common/rest"
+	"github.com/kkodecaffeine/go-common/utils"
+)
+
+// UseCase interface definition
+type Usecase interface {
+	SaveOne(ctx context.Context, userID string, req *dto.PostAPIKeyRequest) (*dto.PostAPIKeyResponse, *rest.CustomError)
+
+	// GET
+	GetAll(ctx context.Context, userID string) ([]dto.GetAPIKeyResponse, *rest.CustomError)
+
+	// UPDATE
+	Revoke(ctx context.Context, userID, ID string) *rest.CustomError
+
+	// 회원 탈퇴 시 회원의 키를 모두 폐기
+	RevokeAll(ctx context.Context, userID string) *rest.CustomError
+
+	// 인증
+	Authenticate(ctx context.Context, raw string) (string, []string, *rest.CustomError)
+}
+
+type usecase struct {
+	repo Repository
+}
+
+func (u *usecase) SaveOne(ctx context.Context, userID string, req *dto.PostAPIKeyRequest) (*dto.PostAPIKeyResponse, *rest.CustomError) {
+	for _, scope := range req.Scopes {
+		if !isAllowedScope(scope) {
+			return nil, &rest.CustomError{CodeDesc: &errorcode.INVALID_PARAMETERS, Message: "scope: " + scope}
+		}
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, &rest.CustomError{CodeDesc: &errorcode.INVALID_PARAMETERS, Message: "expires_at must be in the future"}
+	}
+
+	model, raw := newAPIKey(userID, req.Name, req.Scopes, req.ExpiresAt)
+	if _, err := u.repo.SaveOne(ctx, model); err != nil {
+		return nil, toCustomError(err)
+	}
+
+	return &dto.PostAPIKeyResponse{Key: raw, GetAPIKeyResponse: toResponse(model)}, nil
+}
+
+func (u *usecase) GetAll(ctx context.Context, userID string) ([]dto.GetAPIKeyResponse, *rest.CustomError) {
+	found, err := u.repo.GetAll(ctx, userID)
+	if err != nil && !errortype.IsNotFoundErr(err) {
+		return nil, toCustomError(err)
+	}
+
+	result := make([]dto.GetAPIKeyResponse, 0, len(found))
+	for i := range found {
+		result = append(result, toResponse(&found[i]))
+	}
+	return result, nil
+}
+
+func (u *usecase) Revoke(ctx context.Context, userID, ID string) *rest.CustomError {
+	if err := u.repo.Revoke(ctx, userID, ID, time.Now()); err != nil {
+		return toCustomError(err)
+	}
+	return nil
+}
+
+// RevokeAll 은 회원의 키를 모두 폐기
+func (u *usecase) RevokeAll(ctx context.Context, userID string) *rest.CustomError {
+	if err := u.repo.RevokeAll(ctx, userID, time.Now()); err != nil {
+		return toCustomError(err)
+	}
+	return nil
+}
+
+// Authenticate 는 API 키를 검증한 뒤 회원 아이디와 scope 를 반환
+func (u *usecase) Authenticate(ctx context.Context, raw string) (string, []string, *rest.CustomError) {
+	prefix, ok := parsePrefix(raw)
+	if !ok {
+		return "", nil, &rest.CustomError{CodeDesc: &errorcode.ACCESS_DENIED, Message: "invalid api key"}
+	}
+
+	found, err := u.repo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		if errortype.IsNotFoundErr(err) {
+			return "", nil, &rest.CustomError{CodeDesc: &errorcode.ACCESS_DENIED, Message: "invalid api key"}
+		}
+		return "", nil, toCustomError(err)
+	}
+
+	now := time.Now()
+	if !found.compare(raw) || !found.isActive(now) {
+		return "", nil, &rest.CustomError{CodeDesc: &errorcode.ACCESS_DENIED, Message: "invalid api key"}
+	}
+
+	// 매 요청마다 기록하지 않고 일정 주기로만 갱신
+	if found.needsTouch(now) {
+		_ = u.repo.TouchLastUsed(ctx, utils.MapToStringID(found.ID), now)
+	}
+
+	return found.UserID, found.Scopes, nil
+}
+
+func toResponse(model *APIKey) dto.GetAPIKeyResponse {
+	scopes := model.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	return dto.GetAPIKeyResponse{
+		Id:         utils.MapToStringID(model.ID),
+		Name:       model.Name,
+		Prefix:     keyPrefix + model.Prefix,
+		Scopes:     scopes,
+		CreatedAt:  model.CreatedAt,
+		ExpiresAt:  model.ExpiresAt,
+		LastUsedAt: model.LastUsedAt,
+		RevokedAt:  model.RevokedAt,
+	}
+}
+
+func toCustomError(err error) *rest.CustomError {
+	if errortype.IsDecodeError(err) {
+		return &rest.CustomError{CodeDesc: &errorcode.FAILED_DB_PROCESSING, Message: err.Error()}
+	} else if errortype.IsNotFoundErr(err) {
+		return &rest.CustomError{CodeDesc: &errorcode.NOT_FOUND_ERROR, Message: err.Error()}
+	} else {
+		return &rest.CustomError{CodeDesc: &errorcode.FAILED_INTERNAL_ERROR, Message: err.Error()}
+	}
+}
+
+// NewUsecase returns new Usecase implementation
+func NewUsecase(repo Repository) Usecase {
+	return &usecase{repo: repo}
+}
+
+var _ Usecase = &usecase{}
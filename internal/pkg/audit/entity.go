@@ -0,0 +1,158 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/kamva/mgm/v3"
+)
+
+// 감사 이벤트 종류 (Event.Action)
+const (
+	ActionSignUp         = "sign_up"         // 회원 가입
+	ActionSignIn         = "sign_in"         // 회원 로그인 (소셜 로그인, OIDC 인가 요청 포함)
+	ActionIdentityLink   = "identity_link"   // 외부 계정 연결
+	ActionPasswordChange = "password_change" // 비밀번호 수정
+	ActionUserDelete     = "user_delete"     // 회원 탈퇴
+	ActionCodeIssue      = "code_issue"      // 인증번호 / 추가 인증 코드 발급
+	ActionCodeVerify     = "code_verify"     // 인증번호 / 추가 인증 코드 확인
+	ActionTokenRevoke    = "token_revoke"    // 세션 종료, API 키 폐기
+	ActionClientRegister = "client_register" // 클라이언트 등록
+	ActionAuditSearch    = "audit_search"    // 관리자: 감사 로그 조회
+	ActionAuditVerify    = "audit_verify"    // 관리자: 감사 로그 무결성 확인
+	ActionWebhookChange  = "webhook_change"  // 관리자: 웹훅 등록 / 삭제
+	ActionWebhookSend    = "webhook_send"    // 관리자: 웹훅 테스트 전달 / 다시 전달
+)
+
+// 결과 (Event.Outcome)
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// ActorAnonymous 는 인증하지 않은 요청의 주체 종류 (나머지는 middleware.Principal 의 Kind)
+const ActorAnonymous = "anonymous"
+
+// 대상 종류 (Event.TargetType)
+const (
+	TargetUser       = "user"
+	TargetSession    = "session"
+	TargetAPIKey     = "api_key"
+	TargetClient     = "client"
+	TargetIdentity   = "identity"    // 외부 계정 (아이디는 provider:subject)
+	TargetAuthNumber = "auth_number" // 전화번호 인증번호
+	TargetStepUp     = "step_up"     // 추가 인증 (아이디는 challenge_id)
+	TargetAlert      = "alert"       // 새 기기 로그인 알림 ("본인이 아닙니다" 링크)
+	TargetAuditLog   = "audit_log"
+	TargetWebhook    = "webhook"          // 웹훅 수신 주소
+	TargetDelivery   = "webhook_delivery" // 웹훅 전달
+)
+
+// Entry 는 기록할 감사 이벤트 내용 (순번, 해시는 기록할 때 계산)
+type Entry struct {
+	Action     string
+	Outcome    string
+	Reason     string // 실패 사유 (응답 코드)
+	ActorType  string // user, client, apikey, anonymous
+	ActorID    string // 회원 아이디 혹은 클라이언트 아이디
+	TargetType string
+	TargetID   string
+	Identifier string // 로그인 / 가입에 사용한 이메일 혹은 전화번호
+	IP         string
+	UserAgent  string
+	RequestID  string
+}
+
+// Target 은 대상 지정
+func (e *Entry) Target(kind, ID string) *Entry {
+	e.TargetType, e.TargetID = kind, ID
+	return e
+}
+
+// Result 는 응답 상태 코드에 따라 결과 지정, 실패한 경우 응답 코드를 사유로 기록
+func (e *Entry) Result(status int, code string) *Entry {
+	if status < 400 {
+		e.Outcome, e.Reason = OutcomeSuccess, ""
+	} else {
+		e.Outcome, e.Reason = OutcomeFailure, code
+	}
+	return e
+}
+
+// Event 는 저장된 감사 이벤트, 수정 / 삭제하지 않음 (append-only)
+// Hash 는 이전 이벤트의 Hash 와 이벤트 내용으로 계산하므로 중간 이벤트를 수정 / 삭제하면 이후 연결이 깨짐
+type Event struct {
+	mgm.DefaultModel `bson:",inline"`
+	Seq              int64     `json:"seq" bson:"seq"`                 // 순번 (1 부터, unique)
+	Action           string    `json:"action" bson:"action"`           // 이벤트 종류
+	Outcome          string    `json:"outcome" bson:"outcome"`         // success, failure
+	Reason           string    `json:"reason" bson:"reason"`           // 실패 사유 (응답 코드)
+	ActorType        string    `json:"actor_type" bson:"actor_type"`   // 요청 주체 종류
+	ActorID          string    `json:"actor_id" bson:"actor_id"`       // 요청 주체 아이디
+	TargetType       string    `json:"target_type" bson:"target_type"` // 대상 종류
+	TargetID         string    `json:"target_id" bson:"target_id"`     // 대상 아이디
+	Identifier       string    `json:"identifier" bson:"identifier"`   // 이메일 혹은 전화번호
+	IP               string    `json:"ip" bson:"ip"`                   // 접속 IP
+	UserAgent        string    `json:"user_agent" bson:"user_agent"`   // User-Agent
+	RequestID        string    `json:"request_id" bson:"request_id"`   // 요청 아이디 (X-Request-ID)
+	OccurredAt       time.Time `json:"occurred_at" bson:"occurred_at"` // 발생 시각
+	PrevHash         string    `json:"prev_hash" bson:"prev_hash"`     // 이전 이벤트의 Hash (첫 이벤트는 빈 값)
+	Hash             string    `json:"hash" bson:"hash"`               // sha256(PrevHash, 이벤트 내용)
+}
+
+// CollectionName 은 MongoDB 컬렉션 이름 (mgm 기본값은 events)
+func (e *Event) CollectionName() string {
+	return "audit_events"
+}
+
+// Filter 는 감사 이벤트 조회 조건 (빈 값은 조건에서 제외)
+type Filter struct {
+	Action   string
+	Outcome  string
+	ActorID  string
+	TargetID string
+	IP       string
+	From     time.Time // 이후 (포함)
+	To       time.Time // 이전 (제외)
+}
+
+// newEvent 는 prev 다음 순번의 이벤트 생성, prev 가 nil 이면 첫 이벤트
+// MongoDB 는 밀리초까지만 저장하므로 저장 후에도 같은 해시가 나오도록 발생 시각을 밀리초로 자름
+func newEvent(entry *Entry, prev *Event, now time.Time) *Event {
+	event := &Event{
+		Seq:        1,
+		Action:     entry.Action,
+		Outcome:    entry.Outcome,
+		Reason:     entry.Reason,
+		ActorType:  entry.ActorType,
+		ActorID:    entry.ActorID,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		Identifier: entry.Identifier,
+		IP:         entry.IP,
+		UserAgent:  entry.UserAgent,
+		RequestID:  entry.RequestID,
+		OccurredAt: now.UTC().Truncate(time.Millisecond),
+	}
+	if prev != nil {
+		event.Seq = prev.Seq + 1
+		event.PrevHash = prev.Hash
+	}
+	event.Hash = event.computeHash()
+	return event
+}
+
+// computeHash 는 ID, created_at 등 저장소가 채우는 값을 제외한 내용으로 해시 계산
+func (e *Event) computeHash() string {
+	content, _ := json.Marshal([]interface{}{
+		e.Seq, e.Action, e.Outcome, e.Reason,
+		e.ActorType, e.ActorID, e.TargetType, e.TargetID, e.Identifier,
+		e.IP, e.UserAgent, e.RequestID,
+		e.OccurredAt.UTC().Format(time.RFC3339Nano),
+		e.PrevHash,
+	})
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"signupin-api/internal/pkg/metrics"
+)
+
+// instrumentedRepository 는 저장소 호출 시간과 실패를 지표로 기록
+type instrumentedRepository struct {
+	next Repository
+}
+
+func (r *instrumentedRepository) Append(ctx context.Context, model *Event) (err error) {
+	defer metrics.ObserveRepository("audit", "Append", time.Now(), &err)
+	return r.next.Append(ctx, model)
+}
+
+func (r *instrumentedRepository) GetLast(ctx context.Context) (_ *Event, err error) {
+	defer metrics.ObserveRepository("audit", "GetLast", time.Now(), &err)
+	return r.next.GetLast(ctx)
+}
+
+func (r *instrumentedRepository) Find(ctx context.Context, filter *Filter, skip, limit int64) (_ []Event, _ int64, err error) {
+	defer metrics.ObserveRepository("audit", "Find", time.Now(), &err)
+	return r.next.Find(ctx, filter, skip, limit)
+}
+
+func (r *instrumentedRepository) GetAfter(ctx context.Context, seq int64, limit int64) (_ []Event, err error) {
+	defer metrics.ObserveRepository("audit", "GetAfter", time.Now(), &err)
+	return r.next.GetAfter(ctx, seq, limit)
+}
+
+// Instrument 는 호출 시간과 실패를 지표 (/metrics) 로 기록하는 저장소 반환
+func Instrument(repo Repository) Repository {
+	return &instrumentedRepository{next: repo}
+}
+
+var _ Repository = &instrumentedRepository{}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"signupin-api/internal/pkg/audit"
+
+	"github.com/kamva/mgm/v3"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// auditRepo 는 DB 없이 실행하기 위한 audit.Repository 구현 (테스트, 로컬 개발용)
+// persistence.EnsureIndexes 의 순번 unique index 와 동일하게 같은 순번은 한번만 저장
+type auditRepo struct {
+	mu     sync.Mutex
+	events []audit.Event
+}
+
+var _ audit.Repository = &auditRepo{}
+
+func (r *auditRepo) Append(ctx context.Context, model *audit.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.events {
+		if r.events[i].Seq == model.Seq {
+			return errortype.DuplicatedKeyError(mgm.CollName(model), nil, nil, model, nil)
+		}
+	}
+	prepare(&model.DefaultModel)
+	r.events = append(r.events, *model)
+	return nil
+}
+
+func (r *auditRepo) GetLast(ctx context.Context) (*audit.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.events) == 0 {
+		return nil, notFound(&audit.Event{})
+	}
+	last := r.events[len(r.events)-1]
+	return &last, nil
+}
+
+func (r *auditRepo) Find(ctx context.Context, filter *audit.Filter, skip, limit int64) ([]audit.Event, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var matched []audit.Event
+	for i := len(r.events) - 1; i >= 0; i-- {
+		e := r.events[i]
+		if (filter.Action != "" && e.Action != filter.Action) ||
+			(filter.Outcome != "" && e.Outcome != filter.Outcome) ||
+			(filter.ActorID != "" && e.ActorID != filter.ActorID) ||
+			(filter.TargetID != "" && e.TargetID != filter.TargetID) ||
+			(filter.IP != "" && e.IP != filter.IP) ||
+			(!filter.From.IsZero() && e.OccurredAt.Before(filter.From)) ||
+			(!filter.To.IsZero() && !e.OccurredAt.Before(filter.To)) {
+			continue
+		}
+		matched = append(matched, e)
+	}
+
+	total := int64(len(matched))
+	if skip > total {
+		skip = total
+	}
+	end := skip + limit
+	if end > total {
+		end = total
+	}
+	return matched[skip:end], total, nil
+}
+
+func (r *auditRepo) GetAfter(ctx context.Context, seq int64, limit int64) ([]audit.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var found []audit.Event
+	for _, e := range r.events {
+		if e.Seq > seq && int64(len(found)) < limit {
+			found = append(found, e)
+		}
+	}
+	return found, nil
+}
+
+func prepare(model *mgm.DefaultModel) {
+	if model.ID.IsZero() {
+		model.ID = primitive.NewObjectID()
+	}
+	now := time.Now().UTC()
+	model.CreatedAt = now
+	model.UpdatedAt = now
+}
+
+func notFound(model mgm.Model) error {
+	return errortype.NotFoundError(mgm.CollName(model), nil, nil, nil)
+}
+
+func New() audit.Repository {
+	return &auditRepo{}
+}
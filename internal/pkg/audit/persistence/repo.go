@@ -0,0 +1,127 @@
+package persistence
+
+import (
+	"context"
+
+	"signupin-api/internal/pkg/audit"
+
+	"github.com/kamva/mgm/v3"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type auditRepo struct {
+	client *mongo.Client
+}
+
+var _ audit.Repository = &auditRepo{}
+
+func (r *auditRepo) Append(ctx context.Context, model *audit.Event) error {
+	coll := mgm.Coll(model)
+	err := coll.CreateWithCtx(ctx, model)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), nil, nil, model)
+	}
+
+	return nil
+}
+
+func (r *auditRepo) GetLast(ctx context.Context) (*audit.Event, error) {
+	found := &audit.Event{}
+	filter := bson.M{}
+	opts := options.FindOne().SetSort(bson.D{{Key: "seq", Value: -1}})
+
+	coll := mgm.Coll(found)
+	err := coll.FindOne(ctx, filter, opts).Decode(found)
+	if err != nil {
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return found, nil
+}
+
+func (r *auditRepo) Find(ctx context.Context, filter *audit.Filter, skip, limit int64) ([]audit.Event, int64, error) {
+	query := toQuery(filter)
+
+	coll := mgm.Coll(&audit.Event{})
+	total, err := coll.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, errortype.ParseAndReturnDBError(err, coll.Name(), query, nil, nil)
+	}
+
+	found := []audit.Event{}
+	opts := options.Find().SetSort(bson.D{{Key: "seq", Value: -1}}).SetSkip(skip).SetLimit(limit)
+	err = coll.SimpleFindWithCtx(ctx, &found, query, opts)
+	if err != nil {
+		return nil, 0, errortype.ParseAndReturnDBError(err, coll.Name(), query, nil, nil)
+	}
+
+	return found, total, nil
+}
+
+func (r *auditRepo) GetAfter(ctx context.Context, seq int64, limit int64) ([]audit.Event, error) {
+	filter := bson.M{"seq": bson.M{"$gt": seq}}
+	opts := options.Find().SetSort(bson.D{{Key: "seq", Value: 1}}).SetLimit(limit)
+
+	found := []audit.Event{}
+	coll := mgm.Coll(&audit.Event{})
+	err := coll.SimpleFindWithCtx(ctx, &found, filter, opts)
+	if err != nil {
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return found, nil
+}
+
+func toQuery(filter *audit.Filter) bson.M {
+	query := bson.M{}
+	for key, value := range map[string]string{
+		"action":    filter.Action,
+		"outcome":   filter.Outcome,
+		"actor_id":  filter.ActorID,
+		"target_id": filter.TargetID,
+		"ip":        filter.IP,
+	} {
+		if len(value) > 0 {
+			query[key] = value
+		}
+	}
+
+	occurredAt := bson.M{}
+	if !filter.From.IsZero() {
+		occurredAt["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		occurredAt["$lt"] = filter.To
+	}
+	if len(occurredAt) > 0 {
+		query["occurred_at"] = occurredAt
+	}
+
+	return query
+}
+
+// EnsureIndexes 는 순번 unique index (같은 순번으로 이어서 기록하는 것을 막음) 와 조회용 index 생성 (이미 있으면 무시)
+func EnsureIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "seq", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "actor_id", Value: 1}, {Key: "seq", Value: -1}}},
+		{Keys: bson.D{{Key: "target_id", Value: 1}, {Key: "seq", Value: -1}}},
+		{Keys: bson.D{{Key: "occurred_at", Value: -1}}},
+	}
+
+	coll := mgm.Coll(&audit.Event{})
+	_, err := coll.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), nil, nil, nil)
+	}
+
+	return nil
+}
+
+func New(client *mongo.Client) audit.Repository {
+	return &auditRepo{client}
+}
@@ -0,0 +1,17 @@
+package audit
+
+import (
+	"context"
+)
+
+// Repository interface definition
+// 감사 이벤트는 추가만 가능 (수정 / 삭제 메소드 없음)
+type Repository interface {
+	// 같은 순번의 이벤트가 이미 있으면 errortype.IsDuplicatedKeyErr 로 확인 가능한 오류 반환
+	Append(ctx context.Context, model *Event) error
+
+	// GET
+	GetLast(ctx context.Context) (*Event, error)
+	Find(ctx context.Context, filter *Filter, skip, limit int64) ([]Event, int64, error) // 최신순, 전체 건수
+	GetAfter(ctx context.Context, seq int64, limit int64) ([]Event, error)               // seq 다음부터 순번 순
+}
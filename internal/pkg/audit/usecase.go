@@ -0,0 +1,181 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"signupin-api/internal/app/api/dto"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/errorcode"
+	"github.com/kkodecaffeine/go-common/rest"
+)
+
+const (
+	appendAttempts = 20                   // 다른 요청 (혹은 인스턴스) 이 같은 순번을 먼저 기록한 경우 다시 시도하는 횟수
+	appendBackoff  = 5 * time.Millisecond // 다시 시도하기 전 최대 대기 시간 (시도 횟수만큼 늘어남)
+	defaultSize    = 20                   // 조회 페이지 크기 기본값
+	verifyBatch    = 500                  // 무결성 확인 시 한번에 읽는 이벤트 수
+)
+
+// UseCase interface definition
+type Usecase interface {
+	// 이전 이벤트의 해시에 이어서 감사 이벤트 기록
+	Record(ctx context.Context, entry *Entry) *rest.CustomError
+
+	// GET
+	Search(ctx context.Context, req *dto.GetAuditEventsRequest) (*dto.GetAuditEventsResponse, *rest.CustomError)
+
+	// 처음부터 순서대로 해시 연결을 확인
+	Verify(ctx context.Context) (*dto.GetAuditVerifyResponse, *rest.CustomError)
+}
+
+type usecase struct {
+	repo Repository
+	now  func() time.Time
+}
+
+// Record 는 마지막 이벤트 다음 순번으로 기록
+// 순번은 unique 하므로 여러 요청이 동시에 기록하면 한쪽만 성공하고, 실패한 쪽은 잠시 기다린 뒤 마지막 이벤트를 다시 읽어서 기록
+// 프로세스 안에서 기록을 직렬화하지 않으므로 인스턴스 수와 상관없이 같은 방식으로 순서를 정함
+func (u *usecase) Record(ctx context.Context, entry *Entry) *rest.CustomError {
+	var err error
+	for attempt := 0; attempt < appendAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return &rest.CustomError{CodeDesc: &errorcode.FAILED_INTERNAL_ERROR, Message: ctx.Err().Error()}
+			case <-time.After(time.Duration(rand.Int63n(int64(appendBackoff) * int64(attempt)))):
+			}
+		}
+
+		last, lerr := u.repo.GetLast(ctx)
+		if lerr != nil && !errortype.IsNotFoundErr(lerr) {
+			return toCustomError(lerr)
+		}
+
+		err = u.repo.Append(ctx, newEvent(entry, last, u.now()))
+		if err == nil || !errortype.IsDuplicatedKeyErr(err) {
+			break
+		}
+	}
+	if err != nil {
+		return toCustomError(err)
+	}
+	return nil
+}
+
+func (u *usecase) Search(ctx context.Context, req *dto.GetAuditEventsRequest) (*dto.GetAuditEventsResponse, *rest.CustomError) {
+	page, size := req.Page, req.Size
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = defaultSize
+	}
+
+	filter := &Filter{
+		Action:   req.Action,
+		Outcome:  req.Outcome,
+		ActorID:  req.ActorID,
+		TargetID: req.TargetID,
+		IP:       req.IP,
+		From:     req.From,
+		To:       req.To,
+	}
+
+	found, total, err := u.repo.Find(ctx, filter, int64((page-1)*size), int64(size))
+	if err != nil && !errortype.IsNotFoundErr(err) {
+		return nil, toCustomError(err)
+	}
+
+	events := make([]dto.GetAuditEventResponse, 0, len(found))
+	for i := range found {
+		events = append(events, toResponse(&found[i]))
+	}
+
+	return &dto.GetAuditEventsResponse{Events: events, Page: page, Size: size, Total: total}, nil
+}
+
+// Verify 는 순번이 1 부터 빠짐없이 이어지는지, 각 이벤트의 PrevHash 가 이전 이벤트의 Hash 와 같은지,
+// 저장된 Hash 가 내용으로 계산한 값과 같은지 확인
+func (u *usecase) Verify(ctx context.Context) (*dto.GetAuditVerifyResponse, *rest.CustomError) {
+	result := &dto.GetAuditVerifyResponse{Valid: true}
+
+	for {
+		found, err := u.repo.GetAfter(ctx, result.LastSeq, verifyBatch)
+		if err != nil && !errortype.IsNotFoundErr(err) {
+			return nil, toCustomError(err)
+		}
+
+		for i := range found {
+			event := &found[i]
+			if reason := broken(event, result.LastSeq, result.LastHash); len(reason) > 0 {
+				result.Valid = false
+				result.BrokenAt = result.LastSeq + 1
+				result.Reason = reason
+				return result, nil
+			}
+			result.Checked++
+			result.LastSeq = event.Seq
+			result.LastHash = event.Hash
+		}
+
+		if len(found) < verifyBatch {
+			return result, nil
+		}
+	}
+}
+
+// broken 은 이전 순번 / 해시에 이어지지 않거나 내용이 바뀐 경우 이유 반환
+func broken(event *Event, prevSeq int64, prevHash string) string {
+	switch {
+	case event.Seq != prevSeq+1:
+		return fmt.Sprintf("missing event: expected seq %d, got %d", prevSeq+1, event.Seq)
+	case event.PrevHash != prevHash:
+		return "prev_hash does not match the previous event"
+	case event.Hash != event.computeHash():
+		return "hash does not match the event content"
+	default:
+		return ""
+	}
+}
+
+func toResponse(e *Event) dto.GetAuditEventResponse {
+	return dto.GetAuditEventResponse{
+		Seq:        e.Seq,
+		Action:     e.Action,
+		Outcome:    e.Outcome,
+		Reason:     e.Reason,
+		ActorType:  e.ActorType,
+		ActorID:    e.ActorID,
+		TargetType: e.TargetType,
+		TargetID:   e.TargetID,
+		Identifier: e.Identifier,
+		IP:         e.IP,
+		UserAgent:  e.UserAgent,
+		RequestID:  e.RequestID,
+		OccurredAt: e.OccurredAt,
+		PrevHash:   e.PrevHash,
+		Hash:       e.Hash,
+	}
+}
+
+func toCustomError(err error) *rest.CustomError {
+	if errortype.IsDecodeError(err) {
+		return &rest.CustomError{CodeDesc: &errorcode.FAILED_DB_PROCESSING, Message: err.Error()}
+	} else if errortype.IsNotFoundErr(err) {
+		return &rest.CustomError{CodeDesc: &errorcode.NOT_FOUND_ERROR, Message: err.Error()}
+	} else {
+		return &rest.CustomError{CodeDesc: &errorcode.FAILED_INTERNAL_ERROR, Message: err.Error()}
+	}
+}
+
+// NewUsecase returns new Usecase implementation
+func NewUsecase(repo Repository) Usecase {
+	return &usecase{repo: repo, now: time.Now}
+}
+
+var _ Usecase = &usecase{}
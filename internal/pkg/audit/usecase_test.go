@@ -0,0 +1,265 @@
+package audit
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"signupin-api/internal/app/api/dto"
+
+	"github.com/kamva/mgm/v3"
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+)
+
+// memoryRepository 는 순번 unique index 를 흉내낸 Repository
+type memoryRepository struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+var _ Repository = &memoryRepository{}
+
+func (r *memoryRepository) Append(ctx context.Context, model *Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.events {
+		if r.events[i].Seq == model.Seq {
+			return errortype.DuplicatedKeyError(mgm.CollName(model), nil, nil, model, nil)
+		}
+	}
+	r.events = append(r.events, *model)
+	return nil
+}
+
+func (r *memoryRepository) GetLast(ctx context.Context) (*Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var last *Event
+	for i := range r.events {
+		if last == nil || r.events[i].Seq > last.Seq {
+			found := r.events[i]
+			last = &found
+		}
+	}
+	if last == nil {
+		return nil, errortype.NotFoundError(mgm.CollName(&Event{}), nil, nil, nil)
+	}
+	return last, nil
+}
+
+func (r *memoryRepository) Find(ctx context.Context, filter *Filter, skip, limit int64) ([]Event, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var matched []Event
+	for _, e := range r.events {
+		if (filter.Action == "" || e.Action == filter.Action) && (filter.ActorID == "" || e.ActorID == filter.ActorID) {
+			matched = append(matched, e)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Seq > matched[j].Seq })
+
+	total := int64(len(matched))
+	if skip > total {
+		skip = total
+	}
+	end := skip + limit
+	if end > total {
+		end = total
+	}
+	return matched[skip:end], total, nil
+}
+
+func (r *memoryRepository) GetAfter(ctx context.Context, seq int64, limit int64) ([]Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var found []Event
+	for _, e := range r.events {
+		if e.Seq > seq {
+			found = append(found, e)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Seq < found[j].Seq })
+	if int64(len(found)) > limit {
+		found = found[:limit]
+	}
+	return found, nil
+}
+
+func record(t *testing.T, uc Usecase, entries ...*Entry) {
+	t.Helper()
+	for _, entry := range entries {
+		if err := uc.Record(context.Background(), entry); err != nil {
+			t.Fatalf("Record: %s %s", err.CodeDesc.Code, err.Message)
+		}
+	}
+}
+
+func signIn(actorID string, status int) *Entry {
+	return (&Entry{Action: ActionSignIn, ActorType: "user", ActorID: actorID, IP: "192.0.2.1"}).
+		Target(TargetUser, actorID).
+		Result(status, "NOT_FOUND_ERROR")
+}
+
+func TestRecordChainsHashes(t *testing.T) {
+	repo := &memoryRepository{}
+	record(t, NewUsecase(repo), signIn("kim", 200), signIn("lee", 404), signIn("kim", 200))
+
+	for i, e := range repo.events {
+		if e.Seq != int64(i+1) {
+			t.Errorf("event %d: seq %d", i, e.Seq)
+		}
+		if i == 0 && e.PrevHash != "" {
+			t.Errorf("first event prev_hash: %q", e.PrevHash)
+		}
+		if i > 0 && e.PrevHash != repo.events[i-1].Hash {
+			t.Errorf("event %d: prev_hash does not link to the previous event", i)
+		}
+		if e.Hash != e.computeHash() {
+			t.Errorf("event %d: hash mismatch", i)
+		}
+	}
+	if repo.events[1].Outcome != OutcomeFailure || repo.events[1].Reason != "NOT_FOUND_ERROR" {
+		t.Errorf("failed sign-in: got %s %q", repo.events[1].Outcome, repo.events[1].Reason)
+	}
+	if repo.events[0].Reason != "" {
+		t.Errorf("successful sign-in should have no reason: %q", repo.events[0].Reason)
+	}
+}
+
+func TestRecordFromConcurrentInstances(t *testing.T) {
+	repo := &memoryRepository{}
+	instances := []Usecase{NewUsecase(repo), NewUsecase(repo), NewUsecase(repo)}
+
+	var wg sync.WaitGroup
+	for _, uc := range instances {
+		wg.Add(1)
+		go func(uc Usecase) {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				if err := uc.Record(context.Background(), signIn("kim", 200)); err != nil {
+					t.Errorf("Record: %s", err.Message)
+				}
+			}
+		}(uc)
+	}
+	wg.Wait()
+
+	result, err := NewUsecase(repo).Verify(context.Background())
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if !result.Valid || result.Checked != 30 || result.LastSeq != 30 {
+		t.Fatalf("got %+v", result)
+	}
+}
+
+func TestRecordConcurrently(t *testing.T) {
+	repo := &memoryRepository{}
+	uc := NewUsecase(repo)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := uc.Record(context.Background(), signIn("kim", 200)); err != nil {
+				t.Errorf("Record: %s", err.Message)
+			}
+		}()
+	}
+	wg.Wait()
+
+	result, err := uc.Verify(context.Background())
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if !result.Valid || result.Checked != 20 || result.LastSeq != 20 {
+		t.Fatalf("got %+v", result)
+	}
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	setup := func(t *testing.T) (*memoryRepository, Usecase) {
+		repo := &memoryRepository{}
+		uc := NewUsecase(repo)
+		record(t, uc, signIn("kim", 200), signIn("lee", 200), signIn("kim", 404))
+		return repo, uc
+	}
+
+	tests := []struct {
+		name   string
+		tamper func(repo *memoryRepository)
+		want   int64
+	}{
+		{"modified content", func(r *memoryRepository) { r.events[1].ActorID = "admin" }, 2},
+		{"deleted event", func(r *memoryRepository) { r.events = append(r.events[:1], r.events[2:]...) }, 2},
+		{"rewritten chain", func(r *memoryRepository) {
+			r.events[0].IP = "198.51.100.1"
+			r.events[0].Hash = r.events[0].computeHash()
+		}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, uc := setup(t)
+			tt.tamper(repo)
+
+			result, err := uc.Verify(context.Background())
+			if err != nil {
+				t.Fatal(err.Message)
+			}
+			if result.Valid || result.BrokenAt != tt.want || len(result.Reason) == 0 {
+				t.Fatalf("got %+v, want broken at %d", result, tt.want)
+			}
+		})
+	}
+
+	t.Run("intact", func(t *testing.T) {
+		repo, uc := setup(t)
+		result, _ := uc.Verify(context.Background())
+		if !result.Valid || result.Checked != 3 || result.LastHash != repo.events[2].Hash {
+			t.Fatalf("got %+v", result)
+		}
+	})
+}
+
+func TestHashSurvivesStorageRoundTrip(t *testing.T) {
+	// MongoDB 는 밀리초까지만 저장
+	event := newEvent(signIn("kim", 200), nil, time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.FixedZone("KST", 9*3600)))
+	stored := *event
+	stored.OccurredAt = stored.OccurredAt.Truncate(time.Millisecond).Local()
+
+	if stored.computeHash() != event.Hash {
+		t.Fatal("hash changes after storage round trip")
+	}
+}
+
+func TestSearchPaginates(t *testing.T) {
+	repo := &memoryRepository{}
+	uc := NewUsecase(repo)
+	for i := 0; i < 5; i++ {
+		record(t, uc, signIn("kim", 200), signIn("lee", 200))
+	}
+
+	result, err := uc.Search(context.Background(), &dto.GetAuditEventsRequest{ActorID: "kim", Page: 2, Size: 2})
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if result.Total != 5 || len(result.Events) != 2 || result.Page != 2 || result.Size != 2 {
+		t.Fatalf("got total %d, %d events, page %d size %d", result.Total, len(result.Events), result.Page, result.Size)
+	}
+	// 최신순: kim 은 1, 3, 5, 7, 9 번째 → 2 페이지는 5, 3
+	if result.Events[0].Seq != 5 || result.Events[1].Seq != 3 {
+		t.Fatalf("got seq %d, %d", result.Events[0].Seq, result.Events[1].Seq)
+	}
+
+	defaults, _ := uc.Search(context.Background(), &dto.GetAuditEventsRequest{})
+	if defaults.Page != 1 || defaults.Size != defaultSize || len(defaults.Events) != 10 {
+		t.Fatalf("defaults: got page %d size %d, %d events", defaults.Page, defaults.Size, len(defaults.Events))
+	}
+}
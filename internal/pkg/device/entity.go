@@ -0,0 +1,89 @@
+package device
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"signupin-api/internal/pkg/session"
+
+	"github.com/kamva/mgm/v3"
+)
+
+const alertTTL = 7 * 24 * time.Hour // "본인이 아닙니다" 링크 유효 기간
+
+// KnownDevice 는 회원이 로그인한 적이 있는 기기와 접속 IP 목록
+type KnownDevice struct {
+	mgm.DefaultModel `bson:",inline"`
+	UserID           string    `json:"user_id" bson:"user_id"`           // 회원 아이디
+	Fingerprint      string    `json:"fingerprint" bson:"fingerprint"`   // 기기 식별값 (sha256)
+	Label            string    `json:"label" bson:"label"`               // 기기 이름 (ex. Chrome on macOS)
+	IPs              []string  `json:"ips" bson:"ips"`                   // 해당 기기로 접속한 IP 목록
+	LastSeenAt       time.Time `json:"last_seen_at" bson:"last_seen_at"` // 마지막 로그인 시각
+}
+
+// Alert 는 새 기기 / 새 IP 로그인 알림과 함께 보낸 "본인이 아닙니다" 링크 정보
+type Alert struct {
+	mgm.DefaultModel `bson:",inline"`
+	TokenHash        string    `json:"-" bson:"token_hash"`            // 링크 토큰 (sha256)
+	UserID           string    `json:"user_id" bson:"user_id"`         // 회원 아이디
+	SessionID        string    `json:"session_id" bson:"session_id"`   // 알림 대상 로그인의 세션 아이디
+	Fingerprint      string    `json:"fingerprint" bson:"fingerprint"` // 알림 대상 로그인의 기기 식별값
+	ExpiresAt        time.Time `json:"expires_at" bson:"expires_at"`   // 만료 시각
+}
+
+// Fingerprint 는 요청 메타데이터 (User-Agent, Accept-Language, 기기 이름) 로 기기 식별값을 만듦
+// IP 는 같은 기기에서도 자주 바뀌므로 식별값에 포함하지 않고 기기별로 따로 기록
+func Fingerprint(meta *session.Metadata) string {
+	source := strings.Join([]string{meta.UserAgent, meta.AcceptLanguage, meta.DeviceLabel}, "|")
+	sum := sha256.Sum256([]byte(source))
+	return hex.EncodeToString(sum[:])
+}
+
+func newKnownDevice(userID string, meta *session.Metadata, now time.Time) *KnownDevice {
+	return &KnownDevice{
+		UserID:      userID,
+		Fingerprint: Fingerprint(meta),
+		Label:       meta.Label(),
+		IPs:         []string{meta.IP},
+		LastSeenAt:  now,
+	}
+}
+
+func newAlert(userID, sessionID, fingerprint string) (*Alert, string) {
+	token := randomString(32)
+
+	return &Alert{
+		TokenHash:   HashToken(token),
+		UserID:      userID,
+		SessionID:   sessionID,
+		Fingerprint: fingerprint,
+		ExpiresAt:   time.Now().Add(alertTTL),
+	}, token
+}
+
+func (d *KnownDevice) knowsIP(ip string) bool {
+	for _, known := range d.IPs {
+		if known == ip {
+			return true
+		}
+	}
+	return false
+}
+
+// HashToken 은 저장소에 보관할 링크 토큰의 해시값을 반환
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomString(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
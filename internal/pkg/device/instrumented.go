@@ -0,0 +1,60 @@
+package device
+
+import (
+	"context"
+	"time"
+
+	"signupin-api/internal/pkg/metrics"
+)
+
+// instrumentedRepository 는 저장소 호출 시간과 실패를 지표로 기록
+type instrumentedRepository struct {
+	next Repository
+}
+
+func (r *instrumentedRepository) SaveDevice(ctx context.Context, model *KnownDevice) (_ string, err error) {
+	defer metrics.ObserveRepository("device", "SaveDevice", time.Now(), &err)
+	return r.next.SaveDevice(ctx, model)
+}
+
+func (r *instrumentedRepository) SaveAlert(ctx context.Context, model *Alert) (err error) {
+	defer metrics.ObserveRepository("device", "SaveAlert", time.Now(), &err)
+	return r.next.SaveAlert(ctx, model)
+}
+
+func (r *instrumentedRepository) GetDevice(ctx context.Context, userID, fingerprint string) (_ *KnownDevice, err error) {
+	defer metrics.ObserveRepository("device", "GetDevice", time.Now(), &err)
+	return r.next.GetDevice(ctx, userID, fingerprint)
+}
+
+func (r *instrumentedRepository) CountDevices(ctx context.Context, userID string) (_ int64, err error) {
+	defer metrics.ObserveRepository("device", "CountDevices", time.Now(), &err)
+	return r.next.CountDevices(ctx, userID)
+}
+
+func (r *instrumentedRepository) TouchDevice(ctx context.Context, ID, ip string, seenAt time.Time) (err error) {
+	defer metrics.ObserveRepository("device", "TouchDevice", time.Now(), &err)
+	return r.next.TouchDevice(ctx, ID, ip, seenAt)
+}
+
+func (r *instrumentedRepository) DeleteDevice(ctx context.Context, userID, fingerprint string) (err error) {
+	defer metrics.ObserveRepository("device", "DeleteDevice", time.Now(), &err)
+	return r.next.DeleteDevice(ctx, userID, fingerprint)
+}
+
+func (r *instrumentedRepository) DeleteDevices(ctx context.Context, userID string) (err error) {
+	defer metrics.ObserveRepository("device", "DeleteDevices", time.Now(), &err)
+	return r.next.DeleteDevices(ctx, userID)
+}
+
+func (r *instrumentedRepository) ConsumeAlert(ctx context.Context, token string) (_ *Alert, err error) {
+	defer metrics.ObserveRepository("device", "ConsumeAlert", time.Now(), &err)
+	return r.next.ConsumeAlert(ctx, token)
+}
+
+// Instrument 는 호출 시간과 실패를 지표 (/metrics) 로 기록하는 저장소 반환
+func Instrument(repo Repository) Repository {
+	return &instrumentedRepository{next: repo}
+}
+
+var _ Repository = &instrumentedRepository{}
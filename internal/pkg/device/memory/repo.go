@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"signupin-api/internal/pkg/device"
+
+	"github.com/kamva/mgm/v3"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// deviceRepo 는 DB 없이 실행하기 위한 device.Repository 구현 (테스트, 로컬 개발용)
+// persistence.deviceRepo 와 동일하게 조회 결과가 없으면 errortype.IsNotFoundErr 로 확인 가능한 오류 반환
+type deviceRepo struct {
+	mu      sync.Mutex
+	devices []device.KnownDevice
+	alerts  []device.Alert
+}
+
+var _ device.Repository = &deviceRepo{}
+
+func (r *deviceRepo) SaveDevice(ctx context.Context, model *device.KnownDevice) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prepare(&model.DefaultModel)
+	r.devices = append(r.devices, *model)
+	return utils.MapToStringID(model.ID), nil
+}
+
+func (r *deviceRepo) SaveAlert(ctx context.Context, model *device.Alert) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prepare(&model.DefaultModel)
+	r.alerts = append(r.alerts, *model)
+	return nil
+}
+
+func (r *deviceRepo) GetDevice(ctx context.Context, userID, fingerprint string) (*device.KnownDevice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.devices {
+		if r.devices[i].UserID == userID && r.devices[i].Fingerprint == fingerprint {
+			found := r.devices[i]
+			return &found, nil
+		}
+	}
+	return nil, notFound(&device.KnownDevice{})
+}
+
+func (r *deviceRepo) CountDevices(ctx context.Context, userID string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count int64
+	for i := range r.devices {
+		if r.devices[i].UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *deviceRepo) TouchDevice(ctx context.Context, ID, ip string, seenAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.devices {
+		if r.devices[i].ID.Hex() != ID {
+			continue
+		}
+		known := false
+		for _, seen := range r.devices[i].IPs {
+			known = known || seen == ip
+		}
+		if !known {
+			r.devices[i].IPs = append(r.devices[i].IPs, ip)
+		}
+		r.devices[i].LastSeenAt = seenAt
+		return nil
+	}
+	return notFound(&device.KnownDevice{})
+}
+
+func (r *deviceRepo) DeleteDevice(ctx context.Context, userID, fingerprint string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.devices {
+		if r.devices[i].UserID == userID && r.devices[i].Fingerprint == fingerprint {
+			r.devices = append(r.devices[:i], r.devices[i+1:]...)
+			return nil
+		}
+	}
+	return notFound(&device.KnownDevice{})
+}
+
+func (r *deviceRepo) DeleteDevices(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	devices := r.devices[:0]
+	for _, d := range r.devices {
+		if d.UserID != userID {
+			devices = append(devices, d)
+		}
+	}
+	r.devices = devices
+	return nil
+}
+
+func (r *deviceRepo) ConsumeAlert(ctx context.Context, token string) (*device.Alert, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hash := device.HashToken(token)
+	for i := range r.alerts {
+		if r.alerts[i].TokenHash == hash {
+			found := r.alerts[i]
+			r.alerts = append(r.alerts[:i], r.alerts[i+1:]...)
+			return &found, nil
+		}
+	}
+	return nil, notFound(&device.Alert{})
+}
+
+func prepare(model *mgm.DefaultModel) {
+	if model.ID.IsZero() {
+		model.ID = primitive.NewObjectID()
+	}
+	now := time.Now().UTC()
+	model.CreatedAt = now
+	model.UpdatedAt = now
+}
+
+func notFound(model mgm.Model) error {
+	return errortype.NotFoundError(mgm.CollName(model), nil, nil, nil)
+}
+
+func New() device.Repository {
+	return &deviceRepo{}
+}
@@ -0,0 +1,131 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"signupin-api/internal/pkg/device"
+
+	"github.com/kamva/mgm/v3"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type deviceRepo struct {
+	client *mongo.Client
+}
+
+var _ device.Repository = &deviceRepo{}
+
+func (r *deviceRepo) SaveDevice(ctx context.Context, model *device.KnownDevice) (string, error) {
+	coll := mgm.Coll(model)
+	err := coll.CreateWithCtx(ctx, model)
+	if err != nil {
+		return "", errortype.ParseAndReturnDBError(err, coll.Name(), nil, nil, nil)
+	}
+
+	insertedID := utils.MapToStringID(model.ID)
+	return insertedID, nil
+}
+
+func (r *deviceRepo) SaveAlert(ctx context.Context, model *device.Alert) error {
+	coll := mgm.Coll(model)
+	err := coll.CreateWithCtx(ctx, model)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), nil, nil, nil)
+	}
+
+	return nil
+}
+
+func (r *deviceRepo) GetDevice(ctx context.Context, userID, fingerprint string) (*device.KnownDevice, error) {
+	found := &device.KnownDevice{}
+	filter := bson.M{"user_id": userID, "fingerprint": fingerprint}
+
+	coll := mgm.Coll(found)
+	err := coll.FindOne(ctx, filter).Decode(found)
+	if err != nil {
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return found, nil
+}
+
+func (r *deviceRepo) CountDevices(ctx context.Context, userID string) (int64, error) {
+	filter := bson.M{"user_id": userID}
+
+	coll := mgm.Coll(&device.KnownDevice{})
+	count, err := coll.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return count, nil
+}
+
+// TouchDevice 는 마지막 로그인 시각을 갱신하고 처음 보는 IP 를 목록에 추가
+func (r *deviceRepo) TouchDevice(ctx context.Context, ID, ip string, seenAt time.Time) error {
+	objectID, err := utils.MapToObjectID(ID)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": objectID}
+	update := bson.M{"$set": bson.M{"last_seen_at": seenAt}, "$addToSet": bson.M{"ips": ip}}
+
+	coll := mgm.Coll(&device.KnownDevice{})
+	_, err = coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), filter, update, nil)
+	}
+
+	return nil
+}
+
+func (r *deviceRepo) DeleteDevice(ctx context.Context, userID, fingerprint string) error {
+	filter := bson.M{"user_id": userID, "fingerprint": fingerprint}
+
+	coll := mgm.Coll(&device.KnownDevice{})
+	result, err := coll.DeleteOne(ctx, filter)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+	if result.DeletedCount == 0 {
+		return errortype.NotFoundError(coll.Name(), filter, nil, nil)
+	}
+
+	return nil
+}
+
+// DeleteDevices 는 회원의 기기를 모두 삭제 (삭제할 기기가 없어도 성공)
+func (r *deviceRepo) DeleteDevices(ctx context.Context, userID string) error {
+	filter := bson.M{"user_id": userID}
+
+	coll := mgm.Coll(&device.KnownDevice{})
+	if _, err := coll.DeleteMany(ctx, filter); err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return nil
+}
+
+// ConsumeAlert 는 링크 토큰을 조회하는 동시에 삭제하여 재사용을 막음
+func (r *deviceRepo) ConsumeAlert(ctx context.Context, token string) (*device.Alert, error) {
+	found := &device.Alert{}
+	filter := bson.M{"token_hash": device.HashToken(token)}
+
+	coll := mgm.Coll(found)
+	err := coll.FindOneAndDelete(ctx, filter).Decode(found)
+	if err != nil {
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return found, nil
+}
+
+func New(client *mongo.Client) device.Repository {
+	return &deviceRepo{client}
+}
@@ -0,0 +1,24 @@
+package device
+
+import (
+	"context"
+	"time"
+)
+
+// Repository interface definition
+type Repository interface {
+	SaveDevice(ctx context.Context, model *KnownDevice) (string, error)
+	SaveAlert(ctx context.Context, model *Alert) error
+
+	// GET
+	GetDevice(ctx context.Context, userID, fingerprint string) (*KnownDevice, error)
+	CountDevices(ctx context.Context, userID string) (int64, error)
+
+	// UPDATE
+	TouchDevice(ctx context.Context, ID, ip string, seenAt time.Time) error
+
+	// DELETE
+	DeleteDevice(ctx context.Context, userID, fingerprint string) error
+	DeleteDevices(ctx context.Context, userID string) error
+	ConsumeAlert(ctx context.Context, token string) (*Alert, error)
+}
@@ -0,0 +1,174 @@
+package device
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/pkg/notify"
+	"signupin-api/internal/pkg/session"
+	"signupin-api/internal/pkg/user"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/errorcode"
+	"github.com/kkodecaffeine/go-common/rest"
+	"github.com/kkodecaffeine/go-common/utils"
+)
+
+// UseCase interface definition
+type Usecase interface {
+	// 로그인한 기기와 IP 를 기록하고 처음 보는 기기 혹은 IP 인 경우 회원에게 알림
+	Check(ctx context.Context, found *dto.GetUserWithTokenResponse, sessionID string, meta *session.Metadata) *rest.CustomError
+
+	// 회원이 로그인한 적이 있는 기기인지 확인, 기록된 기기가 없는 회원은 첫 기기로 간주하여 true
+	IsKnown(ctx context.Context, userID string, meta *session.Metadata) (bool, *rest.CustomError)
+
+	// "본인이 아닙니다" 링크 처리: 해당 세션 종료 후 비밀번호 재설정 강제 (비밀번호가 없는 회원은 세션 종료만)
+	NotMe(ctx context.Context, token string) *rest.CustomError
+
+	// 회원 탈퇴 시 회원의 기기 기록을 모두 삭제
+	DeleteAll(ctx context.Context, userID string) *rest.CustomError
+}
+
+type usecase struct {
+	repo     Repository
+	users    user.Usecase
+	sessions session.Usecase
+	notifier notify.Notifier
+	baseURL  string // 알림 링크의 API 주소 (ex. http://localhost/api)
+}
+
+func (u *usecase) Check(ctx context.Context, found *dto.GetUserWithTokenResponse, sessionID string, meta *session.Metadata) *rest.CustomError {
+	now := time.Now()
+	fingerprint := Fingerprint(meta)
+
+	known, err := u.repo.GetDevice(ctx, found.Id, fingerprint)
+	if err != nil && !errortype.IsNotFoundErr(err) {
+		return toCustomError(err)
+	}
+
+	var reason string
+	if known == nil {
+		count, err := u.repo.CountDevices(ctx, found.Id)
+		if err != nil {
+			return toCustomError(err)
+		}
+		if _, err := u.repo.SaveDevice(ctx, newKnownDevice(found.Id, meta, now)); err != nil {
+			return toCustomError(err)
+		}
+
+		// 가입 후 첫 로그인 기기는 알림 없이 기록만 함
+		if count == 0 {
+			return nil
+		}
+		reason = "새로운 기기"
+	} else {
+		if !known.knowsIP(meta.IP) {
+			reason = "새로운 IP"
+		}
+		if err := u.repo.TouchDevice(ctx, utils.MapToStringID(known.ID), meta.IP, now); err != nil {
+			return toCustomError(err)
+		}
+		if len(reason) == 0 {
+			return nil
+		}
+	}
+
+	alert, token := newAlert(found.Id, sessionID, fingerprint)
+	if err := u.repo.SaveAlert(ctx, alert); err != nil {
+		return toCustomError(err)
+	}
+
+	body := fmt.Sprintf(
+		"%s에서 로그인했습니다.\n기기: %s\nIP: %s\n시각: %s\n본인이 아니라면 아래 링크를 눌러 해당 로그인을 종료하세요. (비밀번호가 있는 회원은 비밀번호 재설정 필요)\n%s",
+		reason, meta.Label(), meta.IP, now.Format(time.RFC3339), u.notMeURL(token),
+	)
+	u.notifier.Notify(&notify.Recipient{Email: found.Email, Phone: found.Phone}, "[signupin] 새로운 로그인 알림", body)
+
+	return nil
+}
+
+func (u *usecase) IsKnown(ctx context.Context, userID string, meta *session.Metadata) (bool, *rest.CustomError) {
+	known, err := u.repo.GetDevice(ctx, userID, Fingerprint(meta))
+	if err == nil {
+		return known != nil, nil
+	}
+	if !errortype.IsNotFoundErr(err) {
+		return false, toCustomError(err)
+	}
+
+	count, err := u.repo.CountDevices(ctx, userID)
+	if err != nil {
+		return false, toCustomError(err)
+	}
+	return count == 0, nil
+}
+
+func (u *usecase) NotMe(ctx context.Context, token string) *rest.CustomError {
+	alert, err := u.repo.ConsumeAlert(ctx, token)
+	if err != nil {
+		if errortype.IsNotFoundErr(err) {
+			return &rest.CustomError{CodeDesc: &errorcode.ACCESS_DENIED, Message: "invalid or used link"}
+		}
+		return toCustomError(err)
+	}
+	if time.Now().After(alert.ExpiresAt) {
+		return &rest.CustomError{CodeDesc: &errorcode.ACCESS_DENIED, Message: "expired link"}
+	}
+
+	// 이미 종료된 세션이어도 비밀번호 재설정은 진행, 세션 없이 로그인한 경우 (OIDC 인가 요청) 는 종료할 세션 없음
+	if len(alert.SessionID) > 0 {
+		if err := u.sessions.Revoke(ctx, alert.UserID, alert.SessionID); err != nil && err.CodeDesc.Code != errorcode.NOT_FOUND_ERROR.Code {
+			return err
+		}
+	}
+
+	// 비밀번호가 없는 회원 (소셜 로그인으로 가입) 은 비밀번호를 재설정할 수 없어 로그인이 막히므로 세션 종료만 함
+	found, cerr := u.users.GetOneByID(ctx, alert.UserID)
+	if cerr != nil {
+		return cerr
+	}
+	if !found.Passwordless {
+		if err := u.users.RequirePasswordReset(ctx, alert.UserID); err != nil {
+			return err
+		}
+	}
+
+	// 본인이 아닌 기기는 다음 로그인 시 다시 알림 대상이 되도록 기록 삭제
+	if err := u.repo.DeleteDevice(ctx, alert.UserID, alert.Fingerprint); err != nil && !errortype.IsNotFoundErr(err) {
+		return toCustomError(err)
+	}
+
+	return nil
+}
+
+// DeleteAll 은 회원의 기기 기록을 모두 삭제
+func (u *usecase) DeleteAll(ctx context.Context, userID string) *rest.CustomError {
+	if err := u.repo.DeleteDevices(ctx, userID); err != nil {
+		return toCustomError(err)
+	}
+	return nil
+}
+
+func (u *usecase) notMeURL(token string) string {
+	return strings.TrimRight(u.baseURL, "/") + "/v1/auth/not-me?token=" + token
+}
+
+func toCustomError(err error) *rest.CustomError {
+	if errortype.IsDecodeError(err) {
+		return &rest.CustomError{CodeDesc: &errorcode.FAILED_DB_PROCESSING, Message: err.Error()}
+	} else if errortype.IsNotFoundErr(err) {
+		return &rest.CustomError{CodeDesc: &errorcode.NOT_FOUND_ERROR, Message: err.Error()}
+	} else {
+		return &rest.CustomError{CodeDesc: &errorcode.FAILED_INTERNAL_ERROR, Message: err.Error()}
+	}
+}
+
+// NewUsecase returns new Usecase implementation
+func NewUsecase(repo Repository, users user.Usecase, sessions session.Usecase, notifier notify.Notifier, baseURL string) Usecase {
+	return &usecase{repo: repo, users: users, sessions: sessions, notifier: notifier, baseURL: baseURL}
+}
+
+var _ Usecase = &usecase{}
@@ -0,0 +1,130 @@
+// Package health 는 /readyz 에서 사용하는 의존성 상태 확인
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"signupin-api/internal/app/api/dto"
+
+	"github.com/kkodecaffeine/go-common/errorcode"
+)
+
+// /readyz 상태
+const (
+	StatusReady    = "ready"
+	StatusNotReady = "not_ready"
+	StatusDraining = "draining" // 종료 중, 새 요청을 보내지 않아야 함
+
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// CodeNotReady 는 요청을 처리할 수 없는 상태 (의존성 실패 혹은 종료 중)
+var CodeNotReady = errorcode.CodeDescription{HttpStatusCode: 503, Code: "NOT_READY", Message: "요청을 처리할 수 없는 상태입니다. "}
+
+// Check 는 의존성 하나의 상태 확인, 정상이면 nil 반환
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker 는 등록된 의존성을 확인하고 종료 중 여부를 기록
+type Checker struct {
+	timeout time.Duration
+
+	mu       sync.RWMutex
+	checks   []namedCheck
+	draining atomic.Bool
+}
+
+// NewChecker 는 의존성마다 timeout 안에 확인하는 Checker 반환
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Register 는 name 으로 의존성 확인 추가 (같은 이름이면 교체)
+func (c *Checker) Register(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.checks {
+		if c.checks[i].name == name {
+			c.checks[i].check = check
+			return
+		}
+	}
+	c.checks = append(c.checks, namedCheck{name, check})
+}
+
+// Drain 은 종료 시작을 기록, 이후 Check 는 의존성 상태와 관계없이 준비되지 않음을 반환
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Draining 은 종료 중 여부
+func (c *Checker) Draining() bool {
+	return c.draining.Load()
+}
+
+// Check 는 등록된 의존성을 동시에 확인하고 요청 처리 가능 여부와 의존성별 상태 반환
+func (c *Checker) Check(ctx context.Context) (*dto.GetReadyResponse, bool) {
+	c.mu.RLock()
+	checks := append([]namedCheck(nil), c.checks...)
+	c.mu.RUnlock()
+
+	sort.Slice(checks, func(i, j int) bool { return checks[i].name < checks[j].name })
+
+	statuses := make([]dto.DependencyStatus, len(checks))
+	var wg sync.WaitGroup
+	for i, nc := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			statuses[i] = c.run(ctx, check)
+		}(i, nc.check)
+	}
+	wg.Wait()
+
+	result := &dto.GetReadyResponse{Status: StatusReady, Checks: make(map[string]dto.DependencyStatus, len(checks))}
+	for i, nc := range checks {
+		result.Checks[nc.name] = statuses[i]
+		if statuses[i].Status != StatusUp {
+			result.Status = StatusNotReady
+		}
+	}
+
+	if c.Draining() {
+		result.Status = StatusDraining
+	}
+	return result, result.Status == StatusReady
+}
+
+// run 은 timeout 안에 check 를 실행 (check 가 ctx 를 무시하더라도 timeout 이 지나면 실패로 처리)
+func (c *Checker) run(ctx context.Context, check Check) dto.DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- check(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	status := dto.DependencyStatus{Status: StatusUp, LatencyMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		status.Status = StatusDown
+		status.Error = err.Error()
+	}
+	return status
+}
@@ -0,0 +1,57 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheck(t *testing.T) {
+	checker := NewChecker(50 * time.Millisecond)
+	checker.Register("up", func(ctx context.Context) error { return nil })
+
+	result, ready := checker.Check(context.Background())
+	if !ready || result.Status != StatusReady || result.Checks["up"].Status != StatusUp {
+		t.Fatalf("got %v %+v, want ready", ready, result)
+	}
+
+	checker.Register("down", func(ctx context.Context) error { return errors.New("connection refused") })
+	// ctx 를 무시하는 check 도 timeout 이 지나면 실패로 처리
+	checker.Register("slow", func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	})
+
+	start := time.Now()
+	result, ready = checker.Check(context.Background())
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("checks took %s, want about the timeout", elapsed)
+	}
+	if ready || result.Status != StatusNotReady {
+		t.Fatalf("got %v %+v, want not ready", ready, result)
+	}
+	if got := result.Checks["down"]; got.Status != StatusDown || got.Error != "connection refused" {
+		t.Errorf("down: got %+v", got)
+	}
+	if got := result.Checks["slow"]; got.Status != StatusDown || got.Error != context.DeadlineExceeded.Error() {
+		t.Errorf("slow: got %+v", got)
+	}
+	if got := result.Checks["up"]; got.Status != StatusUp {
+		t.Errorf("up: got %+v", got)
+	}
+}
+
+func TestCheckWhileDraining(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Register("up", func(ctx context.Context) error { return nil })
+	checker.Drain()
+
+	result, ready := checker.Check(context.Background())
+	if ready || result.Status != StatusDraining {
+		t.Fatalf("got %v %+v, want draining", ready, result)
+	}
+	if result.Checks["up"].Status != StatusUp {
+		t.Errorf("dependency status should still be reported: %+v", result.Checks["up"])
+	}
+}
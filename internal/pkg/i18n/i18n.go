@@ -0,0 +1,105 @@
+// Package i18n 은 응답 메시지 (응답 코드, 입력 검증) 의 한국어 / 영어 번역
+//
+// 메시지는 messages.go 의 catalog 를 universal-translator 에 등록해서 사용하고,
+// 응답 코드 메시지는 "code.<응답 코드>" 키로 번역 (한국어는 응답 코드 정의의 Message 와 같음)
+package i18n
+
+import (
+	"strings"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ko"
+	ut "github.com/go-playground/universal-translator"
+	"golang.org/x/text/language"
+)
+
+// 지원하는 언어
+const (
+	Korean  = "ko"
+	English = "en"
+
+	Default = Korean // 회원 설정, Accept-Language 로 정할 수 없는 경우
+)
+
+// Locales 는 지원하는 언어 목록 (Default 가 처음)
+var Locales = []string{Korean, English}
+
+var (
+	universal = ut.New(ko.New(), ko.New(), en.New())
+	matcher   = language.NewMatcher([]language.Tag{language.Korean, language.English})
+)
+
+func init() {
+	for locale, catalog := range messages {
+		trans, _ := universal.GetTranslator(locale)
+		for key, text := range catalog {
+			if err := trans.Add(key, text, false); err != nil {
+				panic(err)
+			}
+		}
+	}
+}
+
+// IsSupported 는 지원하는 언어인지 확인
+func IsSupported(locale string) bool {
+	for _, supported := range Locales {
+		if supported == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse 는 Accept-Language 헤더에서 지원하는 언어 중 가장 선호하는 언어 반환 (없으면 Default)
+func Parse(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return Default
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return Default
+	}
+	return Locales[index]
+}
+
+// Translator 는 locale 의 translator 반환 (지원하지 않는 언어는 Default)
+func Translator(locale string) ut.Translator {
+	trans, found := universal.GetTranslator(locale)
+	if !found {
+		trans, _ = universal.GetTranslator(Default)
+	}
+	return trans
+}
+
+// T 는 key 메시지를 locale 로 번역, params 는 메시지의 {0}, {1} .. 에 대입
+// locale 에 없는 메시지는 Default 로, Default 에도 없으면 key 를 그대로 반환
+func T(locale, key string, params ...string) string {
+	if text, err := Translator(locale).T(key, params...); err == nil {
+		return text
+	}
+	if text, err := Translator(Default).T(key, params...); err == nil {
+		return text
+	}
+	return key
+}
+
+// Message 는 응답 메시지 (응답 코드 메시지 + 상세) 의 응답 코드 메시지 부분을 locale 로 번역
+// 상세 (ex. 실패한 필드 이름) 는 그대로 두고, 번역이 없거나 코드 메시지로 시작하지 않으면 message 를 그대로 반환
+func Message(locale, code, message string) string {
+	if locale == Default {
+		return message
+	}
+
+	key := "code." + code
+	original, err := Translator(Default).T(key)
+	if err != nil || !strings.HasPrefix(message, original) {
+		return message
+	}
+	translated, err := Translator(locale).T(key)
+	if err != nil {
+		return message
+	}
+	return translated + strings.TrimPrefix(message, original)
+}
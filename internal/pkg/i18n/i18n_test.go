@@ -0,0 +1,113 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"signupin-api/internal/app/api"
+	"signupin-api/internal/pkg/health"
+	"signupin-api/internal/pkg/i18n"
+	"signupin-api/internal/pkg/risk"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/kkodecaffeine/go-common/errorcode"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		acceptLanguage string
+		want           string
+	}{
+		{"", i18n.Korean},
+		{"en", i18n.English},
+		{"en-US,en;q=0.9", i18n.English},
+		{"ko-KR,ko;q=0.9,en;q=0.8", i18n.Korean},
+		{"fr-FR,en;q=0.5", i18n.English},
+		{"fr-FR", i18n.Korean},
+		{"*", i18n.Korean},
+		{"not a language;;", i18n.Korean},
+	}
+	for _, tt := range tests {
+		if got := i18n.Parse(tt.acceptLanguage); got != tt.want {
+			t.Errorf("Parse(%q) = %s, want %s", tt.acceptLanguage, got, tt.want)
+		}
+	}
+}
+
+func TestCatalogs(t *testing.T) {
+	// 응답 코드 정의가 바뀌면 한국어 catalog 도 함께 바꿔야 번역됨
+	codes := []errorcode.CodeDescription{
+		errorcode.SUCCESS, errorcode.CREATED, errorcode.NO_CONTENT,
+		errorcode.TOKEN_MISSING, errorcode.TOKEN_INVALID_FORMAT, errorcode.TOKEN_PARSING_ERROR,
+		errorcode.TOKEN_VERIFICATION_ERROR, errorcode.TOKEN_EXPIRED, errorcode.REFRESH_TOKEN_EXPIRED,
+		errorcode.BAD_REQUEST, errorcode.MISSING_PARAMETERS, errorcode.INVALID_PARAMETERS, errorcode.INVALID_OTP,
+		errorcode.ACCESS_DENIED, errorcode.ACCESS_DENIED_ACCOUNT_DISABLE, errorcode.RESOLVE_REQUIRED_ACTIONS,
+		errorcode.AUTH_EMAIL_ALREADY_EXISTS, errorcode.FORBIDDEN_REQUEST, errorcode.NOT_FOUND_ERROR,
+		errorcode.DUPLICATED_KEY, errorcode.TOO_MANY_REQUEST, errorcode.FAILED_DB_PROCESSING,
+		errorcode.FAILED_INTERNAL_ERROR, errorcode.FAILED_KEYCLOAK_HANDLING,
+		risk.CodeStepUpRequired, risk.CodeDenied, health.CodeNotReady,
+		api.CodeMalformedJSON, api.CodeInvalidType, api.CodeUnknownField, api.CodeBodyTooLarge, api.CodeUnsupportedMediaType,
+	}
+	for _, code := range codes {
+		key := "code." + code.Code
+		if got := i18n.T(i18n.Korean, key); got != code.Message {
+			t.Errorf("%s: got %q, want %q", key, got, code.Message)
+		}
+		if got := i18n.T(i18n.English, key); got == code.Message || got == key {
+			t.Errorf("%s: not translated to English", key)
+		}
+	}
+}
+
+func TestMessage(t *testing.T) {
+	message := errorcode.MISSING_PARAMETERS.Message + "email, phone"
+
+	if got := i18n.Message(i18n.English, "MISSING_PARAMETERS", message); got != "Required parameters are missing.▸ email, phone" {
+		t.Fatalf("got %q", got)
+	}
+	if got := i18n.Message(i18n.Korean, "MISSING_PARAMETERS", message); got != message {
+		t.Fatalf("Korean: got %q", got)
+	}
+
+	// 코드 메시지로 시작하지 않거나 번역이 없는 코드는 그대로
+	if got := i18n.Message(i18n.English, "MISSING_PARAMETERS", "email"); got != "email" {
+		t.Fatalf("unexpected prefix: got %q", got)
+	}
+	if got := i18n.Message(i18n.English, "UNKNOWN_CODE", message); got != message {
+		t.Fatalf("unknown code: got %q", got)
+	}
+}
+
+func TestValidationMessage(t *testing.T) {
+	v := validator.New()
+	if err := i18n.RegisterValidation(v); err != nil {
+		t.Fatal(err)
+	}
+
+	req := struct {
+		Name   string   `validate:"required"`
+		Code   string   `validate:"len=6"`
+		Scopes []string `validate:"min=1"`
+		Kind   string   `validate:"oneof=ko en"`
+		Host   string   `validate:"hostname"`
+	}{Code: "123", Kind: "fr", Host: "-"}
+
+	want := map[string][2]string{
+		"Name":   {"필수 입력 항목입니다.", "This field is required."},
+		"Code":   {"6자로 입력해야 합니다.", "Must be exactly 6 characters."},
+		"Scopes": {"1개 이상이어야 합니다.", "Must contain at least 1 items."},
+		"Kind":   {"ko, en 중 하나여야 합니다.", "Must be one of ko, en."},
+		"Host":   {"형식이 올바르지 않습니다.", "Invalid value."},
+	}
+
+	errs := v.Struct(req).(validator.ValidationErrors)
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors: %v", len(errs), errs)
+	}
+	for _, fe := range errs {
+		for i, locale := range i18n.Locales {
+			if got := i18n.ValidationMessage(locale, fe); got != want[fe.Field()][i] {
+				t.Errorf("%s (%s): got %q, want %q", fe.Field(), locale, got, want[fe.Field()][i])
+			}
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package i18n
+
+// messages 는 언어별 메시지 catalog, 모든 언어가 같은 키를 가져야 함 (i18n_test 에서 확인)
+//   - code.<응답 코드>: 응답 코드 메시지, 한국어는 응답 코드 정의의 Message 와 같아야 함 (상세를 이어 붙이는 구분자 포함)
+//   - rule.<검증 규칙>: 입력 검증 실패 설명, 길이 / 크기 규칙은 값 종류 (string, items, number) 별로 구분
+//   - decode.<이유>: 요청 본문을 해석할 수 없는 이유
+var messages = map[string]map[string]string{
+	Korean: {
+		// github.com/kkodecaffeine/go-common/errorcode
+		"code.SUCCESS":                       "성공.",
+		"code.CREATED":                       "생성 성공.",
+		"code.NO_CONTENT":                    "전송할 데이터가 없습니다.",
+		"code.TOKEN_MISSING":                 "토큰을 찾을 수 없습니다.",
+		"code.TOKEN_INVALID_FORMAT":          "올바른 형식이 아닌 토큰입니다.",
+		"code.TOKEN_PARSING_ERROR":           "올바른 형식이 아닌 토큰입니다.",
+		"code.TOKEN_VERIFICATION_ERROR":      "토큰 검증을 실패했습니다.",
+		"code.TOKEN_EXPIRED":                 "토큰이 만료되었습니다.",
+		"code.REFRESH_TOKEN_EXPIRED":         "토큰이 만료되었습니다.",
+		"code.BAD_REQUEST":                   "잘못된 요청입니다.▸ ",
+		"code.MISSING_PARAMETERS":            "필수 입력 정보가 부족합니다.▸ ",
+		"code.INVALID_PARAMETERS":            "입력 정보가 올바르지 않습니다.▸ ",
+		"code.INVALID_OTP":                   "OTP 번호가 잘못되었거나 유효만료 시간을 초과했습니다.",
+		"code.ACCESS_DENIED":                 "잘못된 IAM 키를 사용하여 접근했습니다. ",
+		"code.ACCESS_DENIED_ACCOUNT_DISABLE": "계정 잠금 조치(비활성화)가 진행되었습니다. ",
+		"code.RESOLVE_REQUIRED_ACTIONS":      "비밀번호를 재설정해주세요. 90일이 만료되었습니다.",
+		"code.AUTH_EMAIL_ALREADY_EXISTS":     "이미 다른 계정에서 동일한 이메일을 사용하고 있습니다.▸ ",
+		"code.FORBIDDEN_REQUEST":             "허용되지 않은 요청입니다. ",
+		"code.NOT_FOUND_ERROR":               "존재하지 않는 정보입니다.▸ ",
+		"code.DUPLICATED_KEY":                "중복된 요청입니다.",
+		"code.TOO_MANY_REQUEST":              "주어진 시간동안 너무 많은 요청을 보냈습니다.",
+		"code.FAILED_DB_PROCESSING":          "잘못된 요청 값으로 처리 중 DB 오류가 발생했습니다.▸ ",
+		"code.FAILED_INTERNAL_ERROR":         "확인되지 않은 오류입니다. ",
+		"code.FAILED_KEYCLOAK_HANDLING":      "인증 시스템 처리 작업이 실패했습니다. 잠시 후 다시 시도해주세요. ",
+
+		// risk, health, api (요청 본문 해석)
+		"code.STEP_UP_REQUIRED":       "추가 인증이 필요합니다.",
+		"code.RISK_DENIED":            "보안 정책에 의해 차단된 요청입니다.",
+		"code.NOT_READY":              "요청을 처리할 수 없는 상태입니다. ",
+		"code.MALFORMED_JSON":         "요청 본문이 올바른 JSON 형식이 아닙니다.▸ ",
+		"code.INVALID_TYPE":           "입력 값의 타입이 올바르지 않습니다.▸ ",
+		"code.UNKNOWN_FIELD":          "알 수 없는 입력 항목입니다.▸ ",
+		"code.BODY_TOO_LARGE":         "요청 본문이 너무 큽니다.▸ ",
+		"code.UNSUPPORTED_MEDIA_TYPE": "지원하지 않는 Content-Type 입니다.▸ ",
+
+		"rule.required":          "필수 입력 항목입니다.",
+		"rule.len.string":        "{0}자로 입력해야 합니다.",
+		"rule.len.items":         "{0}개여야 합니다.",
+		"rule.len.number":        "{0}이어야 합니다.",
+		"rule.min.string":        "{0}자 이상 입력해야 합니다.",
+		"rule.min.items":         "{0}개 이상이어야 합니다.",
+		"rule.min.number":        "{0} 이상이어야 합니다.",
+		"rule.max.string":        "{0}자 이하로 입력해야 합니다.",
+		"rule.max.items":         "{0}개 이하여야 합니다.",
+		"rule.max.number":        "{0} 이하여야 합니다.",
+		"rule.oneof":             "{0} 중 하나여야 합니다.",
+		"rule.url":               "URL 형식이 올바르지 않습니다.",
+		"rule.customEmail":       "이메일 형식이 올바르지 않습니다.",
+		"rule.customPhone":       "전화번호 형식이 올바르지 않습니다. (ex. 01012345678)",
+		"rule.type":              "{0} 이어야 합니다. (받은 값: {1})",
+		"rule.unknown":           "요청 모델에 없는 항목입니다.",
+		"rule.invalid":           "형식이 올바르지 않습니다.",
+		"decode.trailing_data":   "JSON 값 뒤에 다른 내용이 있습니다.",
+		"decode.unexpected_eof":  "본문이 중간에 끝났습니다.",
+		"decode.syntax":          "{0} 번째 byte: {1}",
+		"decode.too_large":       "최대 {0} bytes",
+		"decode.media_type_only": "{0} ({1} 만 지원)",
+	},
+	English: {
+		"code.SUCCESS":                       "Success.",
+		"code.CREATED":                       "Created.",
+		"code.NO_CONTENT":                    "There is no data to send.",
+		"code.TOKEN_MISSING":                 "Token not found.",
+		"code.TOKEN_INVALID_FORMAT":          "The token is not in a valid format.",
+		"code.TOKEN_PARSING_ERROR":           "The token is not in a valid format.",
+		"code.TOKEN_VERIFICATION_ERROR":      "Failed to verify the token.",
+		"code.TOKEN_EXPIRED":                 "The token has expired.",
+		"code.REFRESH_TOKEN_EXPIRED":         "The token has expired.",
+		"code.BAD_REQUEST":                   "Bad request.▸ ",
+		"code.MISSING_PARAMETERS":            "Required parameters are missing.▸ ",
+		"code.INVALID_PARAMETERS":            "Invalid parameters.▸ ",
+		"code.INVALID_OTP":                   "The OTP is invalid or has expired.",
+		"code.ACCESS_DENIED":                 "Access denied. ",
+		"code.ACCESS_DENIED_ACCOUNT_DISABLE": "The account has been locked (disabled). ",
+		"code.RESOLVE_REQUIRED_ACTIONS":      "Please reset your password. It has expired after 90 days.",
+		"code.AUTH_EMAIL_ALREADY_EXISTS":     "The email is already used by another account.▸ ",
+		"code.FORBIDDEN_REQUEST":             "The request is not allowed. ",
+		"code.NOT_FOUND_ERROR":               "Not found.▸ ",
+		"code.DUPLICATED_KEY":                "Duplicated request.",
+		"code.TOO_MANY_REQUEST":              "Too many requests in the given time.",
+		"code.FAILED_DB_PROCESSING":          "A database error occurred while processing the request.▸ ",
+		"code.FAILED_INTERNAL_ERROR":         "Unknown error. ",
+		"code.FAILED_KEYCLOAK_HANDLING":      "The authentication system failed. Please try again later. ",
+
+		"code.STEP_UP_REQUIRED":       "Additional verification is required.",
+		"code.RISK_DENIED":            "The request was blocked by a security policy.",
+		"code.NOT_READY":              "The service cannot handle requests right now. ",
+		"code.MALFORMED_JSON":         "The request body is not valid JSON.▸ ",
+		"code.INVALID_TYPE":           "A value has the wrong type.▸ ",
+		"code.UNKNOWN_FIELD":          "Unknown field.▸ ",
+		"code.BODY_TOO_LARGE":         "The request body is too large.▸ ",
+		"code.UNSUPPORTED_MEDIA_TYPE": "Unsupported Content-Type.▸ ",
+
+		"rule.required":          "This field is required.",
+		"rule.len.string":        "Must be exactly {0} characters.",
+		"rule.len.items":         "Must contain exactly {0} items.",
+		"rule.len.number":        "Must be {0}.",
+		"rule.min.string":        "Must be at least {0} characters.",
+		"rule.min.items":         "Must contain at least {0} items.",
+		"rule.min.number":        "Must be {0} or greater.",
+		"rule.max.string":        "Must be at most {0} characters.",
+		"rule.max.items":         "Must contain at most {0} items.",
+		"rule.max.number":        "Must be {0} or less.",
+		"rule.oneof":             "Must be one of {0}.",
+		"rule.url":               "Must be a valid URL.",
+		"rule.customEmail":       "Must be a valid email address.",
+		"rule.customPhone":       "Must be a valid phone number. (ex. 01012345678)",
+		"rule.type":              "Must be a {0}. (got {1})",
+		"rule.unknown":           "The API does not accept this field.",
+		"rule.invalid":           "Invalid value.",
+		"decode.trailing_data":   "Unexpected data after the JSON value.",
+		"decode.unexpected_eof":  "The body ended unexpectedly.",
+		"decode.syntax":          "byte {0}: {1}",
+		"decode.too_large":       "at most {0} bytes",
+		"decode.media_type_only": "{0} (only {1} is supported)",
+	},
+}
@@ -0,0 +1,18 @@
+package i18n
+
+import "testing"
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	for _, locale := range Locales {
+		for key := range messages[Default] {
+			if _, ok := messages[locale][key]; !ok {
+				t.Errorf("%s: missing %s", locale, key)
+			}
+		}
+		for key := range messages[locale] {
+			if _, ok := messages[Default][key]; !ok {
+				t.Errorf("%s: %s is not in %s", locale, key, Default)
+			}
+		}
+	}
+}
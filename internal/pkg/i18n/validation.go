@@ -0,0 +1,65 @@
+package i18n
+
+import (
+	"reflect"
+	"strings"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// rules 는 번역을 등록하는 검증 규칙, 나머지 규칙은 rule.invalid
+var rules = []string{"required", "len", "min", "max", "oneof", "url", "customEmail", "customPhone"}
+
+// RegisterValidation 은 v 의 검증 오류를 FieldError.Translate 로 번역할 수 있도록 모든 언어의 규칙 번역을 등록
+func RegisterValidation(v *validator.Validate) error {
+	for _, locale := range Locales {
+		trans := Translator(locale)
+		for _, rule := range rules {
+			// 메시지는 init 에서 이미 translator 에 추가함
+			err := v.RegisterTranslation(rule, trans, func(ut.Translator) error { return nil }, translateRule)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ValidationMessage 는 검증 오류 설명을 locale 로 번역 (RegisterValidation 으로 등록하지 않은 규칙은 rule.invalid)
+func ValidationMessage(locale string, fe validator.FieldError) string {
+	trans := Translator(locale)
+	if text := fe.Translate(trans); text != fe.Error() {
+		return text
+	}
+	return T(locale, "rule.invalid")
+}
+
+func translateRule(trans ut.Translator, fe validator.FieldError) string {
+	key := "rule." + fe.Tag()
+	param := fe.Param()
+
+	switch fe.Tag() {
+	case "len", "min", "max":
+		key += "." + sizeKind(fe.Kind())
+	case "oneof":
+		param = strings.Join(strings.Fields(param), ", ")
+	}
+
+	text, err := trans.T(key, param)
+	if err != nil {
+		return T(trans.Locale(), "rule.invalid")
+	}
+	return text
+}
+
+// sizeKind 는 길이 / 크기 규칙의 값 종류 (문자열은 글자 수, 목록은 항목 수, 나머지는 값)
+func sizeKind(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "items"
+	}
+	return "number"
+}
@@ -0,0 +1,74 @@
+// Package logging 은 JSON 형식의 구조화 로그 (slog) 설정
+//
+// 모든 로그에 요청 context 의 요청 아이디 / 회원 아이디 / 트레이스 아이디를 남기고
+// 비밀번호, 인증번호, 토큰은 가리고 전화번호, 이메일은 일부만 남김 (redact.go)
+// SetDefault 이후 표준 log 패키지로 남긴 로그도 같은 형식으로 기록
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"signupin-api/internal/pkg/reqctx"
+
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+)
+
+// ParseLevel 은 debug, info, warn, error 를 slog.Level 로 변환
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q (expected debug, info, warn or error)", s)
+	}
+}
+
+// New 는 level 이상의 로그를 w 에 JSON 한 줄로 기록하는 logger 반환
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	handler := slog.HandlerOptions{Level: level, ReplaceAttr: redactAttr}.NewJSONHandler(w)
+	return slog.New(&contextHandler{handler})
+}
+
+// SetDefault 는 New 로 만든 logger 를 기본 logger 로 지정 (slog.Info, log.Printf 등)
+func SetDefault(w io.Writer, level slog.Level) {
+	slog.SetDefault(New(w, level))
+}
+
+// contextHandler 는 요청 context 의 요청 아이디 / 회원 아이디 / 트레이스 아이디를 로그에 추가
+// (slog.InfoCtx 처럼 context 를 함께 넘긴 경우)
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		if requestID := reqctx.RequestID(ctx); len(requestID) > 0 {
+			r.AddAttrs(slog.String("request_id", requestID))
+		}
+		if userID := reqctx.UserID(ctx); len(userID) > 0 {
+			r.AddAttrs(slog.String("user_id", userID))
+		}
+		if span := trace.SpanContextFromContext(ctx); span.HasTraceID() {
+			r.AddAttrs(slog.String("trace_id", span.TraceID().String()))
+		}
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,168 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/exp/slog"
+)
+
+const redacted = "[REDACTED]"
+
+// 값 전체를 가리는 항목 (소문자, "_" / "-" 제거 후 비교)
+// "code" 는 오류 코드 로그에도 쓰이므로 제외, 인증 / 인가 코드는 값 안의 key=value 형식 (pairPattern) 으로 가림
+var sensitiveKeys = map[string]bool{
+	"password": true, "newpassword": true, "confirmation": true, "smtppassword": true,
+	"authnumber": true, "codeverifier": true,
+	"token": true, "accesstoken": true, "refreshtoken": true, "idtoken": true,
+	"authorization": true, "cookie": true, "apikey": true, "xapikey": true,
+	"secret": true, "apisecret": true, "clientsecret": true,
+}
+
+// 일부만 남기는 항목
+var (
+	emailKeys = map[string]bool{"email": true}
+	phoneKeys = map[string]bool{"phone": true, "phonenumber": true}
+)
+
+var (
+	jwtPattern    = regexp.MustCompile(`eyJ[\w-]*\.[\w-]*\.[\w-]*`)
+	bearerPattern = regexp.MustCompile(`(?i)\b(bearer\s+)\S+`)
+	apiKeyPattern = regexp.MustCompile(`\bsk_[A-Za-z0-9_]+`)
+	// key=value, "key":"value" 형식 (쿼리 문자열, JSON 본문, 로그 메시지)
+	pairPattern = regexp.MustCompile(`(?i)\b(password|new_?password|confirmation|auth_?number|code|code_verifier|token|access_?token|id_token|refresh_token|client_secret|api_?key|secret)("?\s*[:=]\s*"?)([^\s&",}]+)`)
+	// 알림 본문 (ex. "인증번호: 123456", "추가 인증 코드: 123456")
+	codePattern  = regexp.MustCompile(`(인증번호|인증 코드)(\s*:?\s*)\d{4,8}`)
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	phonePattern = regexp.MustCompile(`(\+82[- ]?)?\b0?1[016789][- ]?\d{3,4}[- ]?\d{4}\b`)
+)
+
+// Redact 는 문자열의 토큰, API 키, 비밀번호 / 인증번호를 가리고 이메일, 전화번호는 일부만 남김
+func Redact(s string) string {
+	s = jwtPattern.ReplaceAllString(s, redacted)
+	s = bearerPattern.ReplaceAllString(s, "${1}"+redacted)
+	s = apiKeyPattern.ReplaceAllString(s, redacted)
+	s = pairPattern.ReplaceAllString(s, "${1}${2}"+redacted)
+	s = codePattern.ReplaceAllString(s, "${1}${2}"+redacted)
+	s = emailPattern.ReplaceAllStringFunc(s, MaskEmail)
+	s = phonePattern.ReplaceAllStringFunc(s, MaskPhone)
+	return s
+}
+
+// MaskEmail 은 이메일의 아이디 첫 글자와 도메인만 남김 (ex. k******@example.com)
+func MaskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return strings.Repeat("*", len(email))
+	}
+	return email[:1] + strings.Repeat("*", at-1) + email[at:]
+}
+
+// MaskPhone 은 전화번호의 마지막 4자리만 남김 (ex. ***-****-5678)
+func MaskPhone(phone string) string {
+	digits := 0
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+
+	var b strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits--
+			if digits >= 4 {
+				r = '*'
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func normalizeKey(key string) string {
+	return strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToLower(key))
+}
+
+// redactAttr 는 slog.HandlerOptions.ReplaceAttr, 메시지를 포함한 모든 항목에 적용
+func redactAttr(groups []string, a slog.Attr) slog.Attr {
+	switch a.Key {
+	case slog.TimeKey, slog.LevelKey, slog.SourceKey:
+		return a
+	case slog.MessageKey:
+		return slog.String(a.Key, Redact(a.Value.String()))
+	}
+
+	key := normalizeKey(a.Key)
+	switch {
+	case sensitiveKeys[key]:
+		return slog.String(a.Key, redacted)
+	case emailKeys[key]:
+		return slog.String(a.Key, MaskEmail(a.Value.String()))
+	case phoneKeys[key]:
+		return slog.String(a.Key, MaskPhone(a.Value.String()))
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, Redact(a.Value.String()))
+	case slog.KindAny:
+		return slog.Any(a.Key, redactAny(a.Value.Any()))
+	default:
+		return a
+	}
+}
+
+// redactAny 는 error / 구조체 / map 값을 JSON 으로 변환하여 항목 이름에 따라 가림
+func redactAny(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case error:
+		return Redact(v.Error())
+	case fmt.Stringer:
+		return Redact(v.String())
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return Redact(fmt.Sprint(v))
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return Redact(string(raw))
+	}
+	return redactValue(decoded)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			key := normalizeKey(k)
+			switch {
+			case sensitiveKeys[key]:
+				v[k] = redacted
+			case emailKeys[key]:
+				v[k] = MaskEmail(fmt.Sprint(value))
+			case phoneKeys[key]:
+				v[k] = MaskPhone(fmt.Sprint(value))
+			default:
+				v[k] = redactValue(value)
+			}
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+		return v
+	case string:
+		return Redact(v)
+	default:
+		return v
+	}
+}
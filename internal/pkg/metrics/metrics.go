@@ -0,0 +1,160 @@
+// Package metrics 는 /metrics 에서 노출하는 Prometheus 지표
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/errorcode"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "signupin"
+
+// 인증 코드 종류 (CodeIssued, CodeVerified, CodeFailed)
+const (
+	CodeAuthNumber = "auth_number" // 전화번호 인증번호 (회원 가입, 비밀번호 수정)
+	CodeStepUp     = "step_up"     // 위험도 평가의 추가 인증 코드
+)
+
+// 발급한 토큰 종류 (TokenIssued)
+const (
+	TokenSession           = "session"            // 회원 로그인 (세션에 묶인 JWT)
+	TokenUser              = "user"               // 소셜 로그인 등 외부 인증 후 발급
+	TokenOIDCAccess        = "oidc_access"        // authorization_code 의 액세스 토큰
+	TokenOIDCID            = "oidc_id"            // authorization_code 의 ID 토큰
+	TokenClientCredentials = "client_credentials" // 서비스 간 호출용 클라이언트 토큰
+)
+
+// Registry 는 API 서버의 지표 (Go 런타임 / 프로세스 지표 포함)
+var Registry = prometheus.NewRegistry()
+
+var (
+	factory = promauto.With(Registry)
+
+	httpDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route, method and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	signIns = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sign_in_total",
+		Help:      "Sign-in attempts by outcome (success, failure) and reason (response code).",
+	}, []string{"outcome", "reason"})
+
+	codes = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "auth_codes_total",
+		Help:      "Verification codes by type (auth_number, step_up) and event (issued, verified, failed).",
+	}, []string{"type", "event"})
+
+	tokens = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tokens_issued_total",
+		Help:      "Issued tokens by type.",
+	}, []string{"type"})
+
+	events = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbox_events_total",
+		Help:      "Domain events relayed from the outbox by type and result (published, failed).",
+	}, []string{"type", "result"})
+
+	webhooks = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_deliveries_total",
+		Help:      "Webhook delivery attempts by event type and status (succeeded, failed, dead).",
+	}, []string{"type", "status"})
+
+	repositoryDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "repository_call_duration_seconds",
+		Help:      "Repository call latency by repository and method.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"repository", "method"})
+
+	repositoryErrors = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "repository_call_errors_total",
+		Help:      "Failed repository calls by repository and method (not found is not counted).",
+	}, []string{"repository", "method"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler 는 Registry 의 지표를 Prometheus 형식으로 응답하는 http.Handler
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// ObserveRequest 는 요청 처리 시간 기록 (route 는 등록된 경로, ex. /api/v1/users/:userID)
+func ObserveRequest(route, method string, status int, elapsed time.Duration) {
+	httpDuration.WithLabelValues(route, method, strconv.Itoa(status)).Observe(elapsed.Seconds())
+}
+
+// SignIn 은 로그인 결과 기록, code 는 응답 코드 (SUCCESS 가 아니면 실패 사유로 기록)
+func SignIn(code string) {
+	if code == errorcode.SUCCESS.Code {
+		signIns.WithLabelValues("success", "").Inc()
+		return
+	}
+	signIns.WithLabelValues("failure", code).Inc()
+}
+
+// CodeIssued 는 인증 코드 발급 기록
+func CodeIssued(kind string) {
+	codes.WithLabelValues(kind, "issued").Inc()
+}
+
+// CodeVerified 는 인증 코드 확인 성공 기록
+func CodeVerified(kind string) {
+	codes.WithLabelValues(kind, "verified").Inc()
+}
+
+// CodeFailed 는 인증 코드 확인 실패 기록 (틀린 코드, 만료, 없는 코드)
+func CodeFailed(kind string) {
+	codes.WithLabelValues(kind, "failed").Inc()
+}
+
+// TokenIssued 는 토큰 발급 기록
+func TokenIssued(kind string) {
+	tokens.WithLabelValues(kind).Inc()
+}
+
+// EventPublished 는 outbox 의 이벤트를 모든 Sink 로 전달한 경우 기록
+func EventPublished(eventType string) {
+	events.WithLabelValues(eventType, "published").Inc()
+}
+
+// EventFailed 는 outbox 의 이벤트 전달 실패 기록 (다음 시도에서 다시 전달)
+func EventFailed(eventType string) {
+	events.WithLabelValues(eventType, "failed").Inc()
+}
+
+// WebhookDelivered 는 웹훅 전달 시도 결과 기록 (status: succeeded, failed, dead)
+func WebhookDelivered(eventType, status string) {
+	webhooks.WithLabelValues(eventType, status).Inc()
+}
+
+// ObserveRepository 는 저장소 호출 시간과 실패 기록, defer 로 호출
+//
+//	defer metrics.ObserveRepository("user", "GetOne", time.Now(), &err)
+func ObserveRepository(repository, method string, start time.Time, err *error) {
+	repositoryDuration.WithLabelValues(repository, method).Observe(time.Since(start).Seconds())
+	if err != nil && *err != nil && !errortype.IsNotFoundErr(*err) {
+		repositoryErrors.WithLabelValues(repository, method).Inc()
+	}
+}
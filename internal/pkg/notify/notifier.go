@@ -0,0 +1,43 @@
+package notify
+
+import "golang.org/x/exp/slog"
+
+// Recipient 는 알림을 받을 회원의 연락처
+type Recipient struct {
+	Email string
+	Phone string
+}
+
+// Notifier 는 회원의 연락처에 따라 SMS, 이메일로 알림 발송
+type Notifier interface {
+	Notify(to *Recipient, subject, body string)
+}
+
+type notifier struct {
+	sms   Sender
+	email Sender
+}
+
+// Notify 는 응답 지연을 막기 위해 비동기로 발송하고 실패는 로그로 남김
+func (n *notifier) Notify(to *Recipient, subject, body string) {
+	send := func(sender Sender, address string) {
+		if sender == nil || len(address) == 0 {
+			return
+		}
+		go func() {
+			if err := sender.Send(&Message{To: address, Subject: subject, Body: body}); err != nil {
+				slog.Error("notify send failed", "sender", sender.Name(), "error", err)
+			}
+		}()
+	}
+
+	send(n.sms, to.Phone)
+	send(n.email, to.Email)
+}
+
+// NewNotifier returns new Notifier implementation
+func NewNotifier(sms, email Sender) Notifier {
+	return &notifier{sms: sms, email: email}
+}
+
+var _ Notifier = &notifier{}
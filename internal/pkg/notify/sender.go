@@ -0,0 +1,156 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+// Message 는 회원에게 보낼 알림 내용
+type Message struct {
+	To      string // 수신자 (전화번호 혹은 이메일)
+	Subject string // 제목 (이메일)
+	Body    string // 본문
+}
+
+// Sender 는 알림 발송 수단 (SMS, 이메일 ...)
+type Sender interface {
+	Name() string
+	Send(msg *Message) error
+}
+
+// ReadyChecker 는 발송하지 않고 발송 가능한 상태인지 확인할 수 있는 Sender (/readyz)
+type ReadyChecker interface {
+	Ready(ctx context.Context) error
+}
+
+// Ready 는 sender 가 발송 가능한 상태인지 확인, ReadyChecker 가 아닌 Sender (ex. LogSender) 는 항상 준비됨
+func Ready(ctx context.Context, sender Sender) error {
+	if checker, ok := sender.(ReadyChecker); ok {
+		return checker.Ready(ctx)
+	}
+	return nil
+}
+
+// LogSender 는 발송 수단이 설정되지 않은 경우 알림을 로그로 남김 (로컬 개발용)
+type LogSender struct {
+	Channel string
+}
+
+func (s *LogSender) Name() string { return "log:" + s.Channel }
+
+func (s *LogSender) Send(msg *Message) error {
+	// 로그의 전화번호 / 이메일, 인증번호는 logging 이 가림
+	slog.Info("notify", "channel", s.Channel, "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+	return nil
+}
+
+// SMTPSender 는 SMTP 서버로 이메일 발송
+type SMTPSender struct {
+	Addr     string // host:port
+	From     string
+	Username string
+	Password string
+}
+
+func (s *SMTPSender) Name() string { return "smtp" }
+
+func (s *SMTPSender) Send(msg *Message) error {
+	var auth smtp.Auth
+	if len(s.Username) > 0 {
+		auth = smtp.PlainAuth("", s.Username, s.Password, strings.Split(s.Addr, ":")[0])
+	}
+
+	body := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n", s.From, msg.To, msg.Subject, msg.Body)
+	return smtp.SendMail(s.Addr, auth, s.From, []string{msg.To}, []byte(body))
+}
+
+// HTTPSender 는 SMS 발송 대행 업체의 HTTP API 로 문자 발송
+// 요청 본문: {"to": "...", "text": "..."}
+type HTTPSender struct {
+	URL    string
+	APIKey string
+	Client *http.Client
+}
+
+func (s *HTTPSender) Name() string { return "http" }
+
+func (s *HTTPSender) Send(msg *Message) error {
+	payload, err := json.Marshal(map[string]string{"to": msg.To, "text": msg.Body})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(s.APIKey) > 0 {
+		req.Header.Set("Authorization", "Bearer "+s.APIKey)
+	}
+
+	res, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("sms api returned %d", res.StatusCode)
+	}
+	return nil
+}
+
+// Ready 는 SMS API 서버에 TCP 연결이 되는지 확인 (발송 대행 업체 API 에 별도 상태 확인 API 가 없음)
+func (s *HTTPSender) Ready(ctx context.Context) error {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return err
+	}
+
+	port := u.Port()
+	if len(port) == 0 {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(u.Hostname(), port))
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// NewSMSSender 는 url 이 설정된 경우 HTTPSender, 아니면 LogSender 반환
+func NewSMSSender(url, apiKey string) Sender {
+	if len(url) > 0 {
+		return &HTTPSender{URL: url, APIKey: apiKey, Client: &http.Client{Timeout: 10 * time.Second}}
+	}
+	return &LogSender{Channel: "sms"}
+}
+
+// NewEmailSender 는 addr 이 설정된 경우 SMTPSender, 아니면 LogSender 반환
+func NewEmailSender(addr, from, username, password string) Sender {
+	if len(addr) > 0 {
+		return &SMTPSender{
+			Addr:     addr,
+			From:     from,
+			Username: username,
+			Password: password,
+		}
+	}
+	return &LogSender{Channel: "email"}
+}
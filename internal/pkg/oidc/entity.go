@@ -0,0 +1,156 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/kamva/mgm/v3"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 지원하는 grant_type
+const (
+	GrantAuthorizationCode = "authorization_code"
+	GrantClientCredentials = "client_credentials"
+)
+
+const (
+	codeTTL        = 1 * time.Minute // 인가 코드 유효 시간
+	accessTokenTTL = 1 * time.Hour   // 액세스 토큰 / ID 토큰 유효 시간
+)
+
+// AllowedScopes 는 클라이언트에 부여할 수 있는 scope 목록
+var AllowedScopes = []string{"users:read", "clients:write", "audit:read", "webhooks:write"}
+
+// Client 는 본 서비스에 로그인을 위임하는 등록된 애플리케이션
+type Client struct {
+	mgm.DefaultModel `bson:",inline"`
+	ClientID         string   `json:"client_id" bson:"client_id"`         // 클라이언트 아이디
+	SecretHash       string   `json:"-" bson:"secret_hash"`               // 클라이언트 시크릿 (bcrypt), public 클라이언트는 빈 값
+	Name             string   `json:"client_name" bson:"client_name"`     // 클라이언트 이름
+	RedirectURIs     []string `json:"redirect_uris" bson:"redirect_uris"` // 허용된 redirect_uri 목록
+	GrantTypes       []string `json:"grant_types" bson:"grant_types"`     // 허용된 grant_type 목록 (빈 값은 authorization_code)
+	Scopes           []string `json:"scopes" bson:"scopes"`               // client_credentials 로 요청 가능한 scope 목록
+}
+
+// AuthorizationCode 는 /oauth/authorize 에서 발급되어 /oauth/token 에서 1회 교환되는 인가 코드
+type AuthorizationCode struct {
+	mgm.DefaultModel    `bson:",inline"`
+	CodeHash            string    `json:"-" bson:"code_hash"`                                 // 인가 코드 (sha256)
+	ClientID            string    `json:"client_id" bson:"client_id"`                         // 클라이언트 아이디
+	UserID              string    `json:"user_id" bson:"user_id"`                             // 회원 아이디
+	RedirectURI         string    `json:"redirect_uri" bson:"redirect_uri"`                   // 요청 시 사용한 redirect_uri
+	Scope               string    `json:"scope" bson:"scope"`                                 // 요청 scope
+	Nonce               string    `json:"nonce" bson:"nonce"`                                 // ID 토큰에 담을 nonce
+	CodeChallenge       string    `json:"code_challenge" bson:"code_challenge"`               // PKCE code_challenge
+	CodeChallengeMethod string    `json:"code_challenge_method" bson:"code_challenge_method"` // PKCE code_challenge_method
+	AuthTime            time.Time `json:"auth_time" bson:"auth_time"`                         // 회원 인증 시각
+	ExpiresAt           time.Time `json:"expires_at" bson:"expires_at"`                       // 만료 시각
+}
+
+func newClient(name string, redirectURIs, grantTypes, scopes []string, public bool) (*Client, string, error) {
+	client := &Client{
+		ClientID:     randomString(16),
+		Name:         name,
+		RedirectURIs: redirectURIs,
+		GrantTypes:   grantTypes,
+		Scopes:       scopes,
+	}
+	if public {
+		return client, "", nil
+	}
+
+	secret := randomString(32)
+	hashed, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, "", err
+	}
+	client.SecretHash = string(hashed)
+
+	return client, secret, nil
+}
+
+func newAuthorizationCode(clientID, userID, redirectURI, scope, nonce, challenge, method string) (*AuthorizationCode, string) {
+	code := randomString(32)
+	now := time.Now()
+
+	return &AuthorizationCode{
+		CodeHash:            HashCode(code),
+		ClientID:            clientID,
+		UserID:              userID,
+		RedirectURI:         redirectURI,
+		Scope:               scope,
+		Nonce:               nonce,
+		CodeChallenge:       challenge,
+		CodeChallengeMethod: method,
+		AuthTime:            now,
+		ExpiresAt:           now.Add(codeTTL),
+	}, code
+}
+
+func (c *Client) isPublic() bool {
+	return len(c.SecretHash) == 0
+}
+
+func (c *Client) allowsGrantType(grantType string) bool {
+	if len(c.GrantTypes) == 0 {
+		return grantType == GrantAuthorizationCode
+	}
+	return contains(c.GrantTypes, grantType)
+}
+
+// grantScopes 는 요청 scope 중 클라이언트에 허용된 scope 만 반환, 요청이 비어있으면 허용된 scope 전체를 반환
+func (c *Client) grantScopes(requested []string) ([]string, bool) {
+	if len(requested) == 0 {
+		return c.Scopes, true
+	}
+	for _, scope := range requested {
+		if !contains(c.Scopes, scope) {
+			return nil, false
+		}
+	}
+	return requested, true
+}
+
+func (c *Client) allowsRedirectURI(redirectURI string) bool {
+	return contains(c.RedirectURIs, redirectURI)
+}
+
+func (c *Client) compareSecret(secret string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(c.SecretHash), []byte(secret)) == nil
+}
+
+// verifyCodeVerifier 는 RFC 7636 4.6 에 따라 code_verifier 를 검증
+func (a *AuthorizationCode) verifyCodeVerifier(verifier string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:]) == a.CodeChallenge
+}
+
+// HashCode 는 저장소에 보관할 인가 코드의 해시값을 반환
+func HashCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+func contains(values []string, expected string) bool {
+	for _, value := range values {
+		if value == expected {
+			return true
+		}
+	}
+	return false
+}
+
+func randomString(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
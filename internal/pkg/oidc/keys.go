@@ -0,0 +1,128 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"signupin-api/internal/app/api/dto"
+
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+// KeySet 은 ID 토큰 / 액세스 토큰 서명에 사용하는 RSA 키
+type KeySet struct {
+	key *rsa.PrivateKey
+	kid string
+}
+
+// LoadKeySet 은 PEM 형식의 RSA 개인키를 읽어 KeySet 을 생성
+// 경로가 비어있는 경우 임시 키를 생성 (재시작 시 발급된 토큰은 모두 무효화됨, 설정 검증에서 STORAGE_BACKEND 가 memory 인 경우만 허용)
+func LoadKeySet(path string) (*KeySet, error) {
+	if len(path) == 0 {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, err
+		}
+		return newKeySet(key), nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in %s", path)
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return newKeySet(key), nil
+	case "PRIVATE KEY":
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key, ok := parsed.(*rsa.PrivateKey)
+		if !ok {
+			return nil, errors.New("signing key is not an RSA key")
+		}
+		return newKeySet(key), nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+}
+
+func newKeySet(key *rsa.PrivateKey) *KeySet {
+	sum := sha256.Sum256(key.PublicKey.N.Bytes())
+	return &KeySet{key: key, kid: base64.RawURLEncoding.EncodeToString(sum[:8])}
+}
+
+// Ready 는 서명 키로 서명할 수 있는지 확인 (/readyz)
+func (k *KeySet) Ready() error {
+	if k == nil || k.key == nil {
+		return errors.New("signing key is not loaded")
+	}
+
+	_, err := k.Sign(jwt.MapClaims{"probe": true})
+	return err
+}
+
+// Sign 은 RS256 으로 서명한 JWT 를 반환
+func (k *KeySet) Sign(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = k.kid
+
+	return token.SignedString(k.key)
+}
+
+// Parse 는 KeySet 으로 서명된 JWT 를 검증한 뒤 claims 를 반환
+func (k *KeySet) Parse(tokenString string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return &k.key.PublicKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
+
+// JWKS 는 공개키를 RFC 7517 형식으로 반환
+func (k *KeySet) JWKS() *dto.GetJWKSResponse {
+	pub := k.key.PublicKey
+
+	return &dto.GetJWKSResponse{
+		Keys: []dto.JWK{
+			{
+				Kty: "RSA",
+				Use: "sig",
+				Alg: "RS256",
+				Kid: k.kid,
+				N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			},
+		},
+	}
+}
@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"signupin-api/internal/pkg/oidc"
+
+	"github.com/kamva/mgm/v3"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// oidcRepo 는 DB 없이 실행하기 위한 oidc.Repository 구현 (테스트, 로컬 개발용)
+// persistence.oidcRepo 와 동일하게 조회 결과가 없으면 errortype.IsNotFoundErr 로 확인 가능한 오류 반환
+type oidcRepo struct {
+	mu      sync.Mutex
+	clients []oidc.Client
+	codes   []oidc.AuthorizationCode
+}
+
+var _ oidc.Repository = &oidcRepo{}
+
+func (r *oidcRepo) SaveClient(ctx context.Context, model *oidc.Client) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prepare(&model.DefaultModel)
+	r.clients = append(r.clients, *model)
+	return utils.MapToStringID(model.ID), nil
+}
+
+func (r *oidcRepo) SaveCode(ctx context.Context, model *oidc.AuthorizationCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prepare(&model.DefaultModel)
+	r.codes = append(r.codes, *model)
+	return nil
+}
+
+func (r *oidcRepo) GetClient(ctx context.Context, clientID string) (*oidc.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.clients {
+		if r.clients[i].ClientID == clientID {
+			found := r.clients[i]
+			return &found, nil
+		}
+	}
+	return nil, errortype.NotFoundError(mgm.CollName(&oidc.Client{}), bson.M{"client_id": clientID}, nil, nil)
+}
+
+// ConsumeCode 는 인가 코드를 조회하는 동시에 삭제하여 재사용을 막음
+func (r *oidcRepo) ConsumeCode(ctx context.Context, code string) (*oidc.AuthorizationCode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hash := oidc.HashCode(code)
+	for i := range r.codes {
+		if r.codes[i].CodeHash == hash {
+			found := r.codes[i]
+			r.codes = append(r.codes[:i], r.codes[i+1:]...)
+			return &found, nil
+		}
+	}
+	return nil, errortype.NotFoundError(mgm.CollName(&oidc.AuthorizationCode{}), bson.M{"code_hash": hash}, nil, nil)
+}
+
+func prepare(model *mgm.DefaultModel) {
+	if model.ID.IsZero() {
+		model.ID = primitive.NewObjectID()
+	}
+	now := time.Now().UTC()
+	model.CreatedAt = now
+	model.UpdatedAt = now
+}
+
+func New() oidc.Repository {
+	return &oidcRepo{}
+}
@@ -0,0 +1,71 @@
+package persistence
+
+import (
+	"context"
+	"signupin-api/internal/pkg/oidc"
+
+	"github.com/kamva/mgm/v3"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type oidcRepo struct {
+	client *mongo.Client
+}
+
+var _ oidc.Repository = &oidcRepo{}
+
+func (r *oidcRepo) SaveClient(ctx context.Context, model *oidc.Client) (string, error) {
+	coll := mgm.Coll(model)
+	err := coll.CreateWithCtx(ctx, model)
+	if err != nil {
+		return "", errortype.ParseAndReturnDBError(err, coll.Name(), nil, nil, nil)
+	}
+
+	insertedID := utils.MapToStringID(model.ID)
+	return insertedID, nil
+}
+
+func (r *oidcRepo) SaveCode(ctx context.Context, model *oidc.AuthorizationCode) error {
+	coll := mgm.Coll(model)
+	err := coll.CreateWithCtx(ctx, model)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), nil, nil, nil)
+	}
+
+	return nil
+}
+
+func (r *oidcRepo) GetClient(ctx context.Context, clientID string) (*oidc.Client, error) {
+	found := &oidc.Client{}
+	filter := bson.M{"client_id": clientID}
+
+	coll := mgm.Coll(found)
+	err := coll.FindOne(ctx, filter).Decode(found)
+	if err != nil {
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return found, nil
+}
+
+// ConsumeCode 는 인가 코드를 조회하는 동시에 삭제하여 재사용을 막음
+func (r *oidcRepo) ConsumeCode(ctx context.Context, code string) (*oidc.AuthorizationCode, error) {
+	found := &oidc.AuthorizationCode{}
+	filter := bson.M{"code_hash": oidc.HashCode(code)}
+
+	coll := mgm.Coll(found)
+	err := coll.FindOneAndDelete(ctx, filter).Decode(found)
+	if err != nil {
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return found, nil
+}
+
+func New(client *mongo.Client) oidc.Repository {
+	return &oidcRepo{client}
+}
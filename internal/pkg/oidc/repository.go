@@ -0,0 +1,15 @@
+package oidc
+
+import "context"
+
+// Repository interface definition
+type Repository interface {
+	SaveClient(ctx context.Context, model *Client) (string, error)
+	SaveCode(ctx context.Context, model *AuthorizationCode) error
+
+	// GET
+	GetClient(ctx context.Context, clientID string) (*Client, error)
+
+	// DELETE
+	ConsumeCode(ctx context.Context, code string) (*AuthorizationCode, error)
+}
@@ -0,0 +1,399 @@
+package oidc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/pkg/metrics"
+	"signupin-api/internal/pkg/user"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/errorcode"
+	"github.com/kkodecaffeine/go-common/rest"
+)
+
+// RFC 6749 / OpenID Connect Core 오류 코드 (rest.CustomError.Data 에 담아서 반환)
+const (
+	ErrInvalidRequest          = "invalid_request"
+	ErrInvalidClient           = "invalid_client"
+	ErrInvalidGrant            = "invalid_grant"
+	ErrInvalidScope            = "invalid_scope"
+	ErrUnauthorizedClient      = "unauthorized_client"
+	ErrInvalidToken            = "invalid_token"
+	ErrAccessDenied            = "access_denied"
+	ErrUnsupportedGrantType    = "unsupported_grant_type"
+	ErrUnsupportedResponseType = "unsupported_response_type"
+	ErrServerError             = "server_error"
+)
+
+// UseCase interface definition
+type Usecase interface {
+	RegisterClient(ctx context.Context, req *dto.PostClientRequest) (*dto.PostClientResponse, *rest.CustomError)
+
+	// GET
+	Discovery(ctx context.Context) *dto.GetDiscoveryResponse
+	JWKS(ctx context.Context) *dto.GetJWKSResponse
+	UserInfo(ctx context.Context, accessToken string) (*dto.GetUserInfoResponse, *rest.CustomError)
+	VerifyClientToken(ctx context.Context, accessToken string) (string, []string, *rest.CustomError)
+
+	// 인가 요청 검증
+	VerifyRedirectURI(ctx context.Context, clientID, redirectURI string) *rest.CustomError
+	ValidateAuthorizeRequest(ctx context.Context, req *dto.GetAuthorizeRequest) *rest.CustomError
+
+	// 인가 코드 발급 (회원 로그인 API 와 같은 확인을 마친 회원) / 교환
+	Authorize(ctx context.Context, req *dto.PostAuthorizeRequest, userID string) (string, *rest.CustomError)
+	Exchange(ctx context.Context, req *dto.PostTokenRequest) (*dto.PostTokenResponse, *rest.CustomError)
+}
+
+type usecase struct {
+	repo   Repository
+	users  user.Usecase
+	keys   *KeySet
+	issuer string
+}
+
+func (u *usecase) RegisterClient(ctx context.Context, req *dto.PostClientRequest) (*dto.PostClientResponse, *rest.CustomError) {
+	grantTypes := req.GrantTypes
+	if len(grantTypes) == 0 {
+		grantTypes = []string{GrantAuthorizationCode}
+	}
+
+	if contains(grantTypes, GrantAuthorizationCode) && len(req.RedirectURIs) == 0 {
+		return nil, &rest.CustomError{CodeDesc: &errorcode.MISSING_PARAMETERS, Message: "required: redirect_uris"}
+	}
+
+	// 서비스 간 호출에 사용하는 클라이언트는 반드시 시크릿으로 인증
+	if contains(grantTypes, GrantClientCredentials) && req.Public {
+		return nil, &rest.CustomError{CodeDesc: &errorcode.BAD_REQUEST, Message: "client_credentials requires a confidential client"}
+	}
+
+	scopes := strings.Fields(req.Scope)
+	for _, scope := range scopes {
+		if !contains(AllowedScopes, scope) {
+			return nil, &rest.CustomError{CodeDesc: &errorcode.INVALID_PARAMETERS, Message: "scope: " + scope}
+		}
+	}
+
+	client, secret, err := newClient(req.ClientName, req.RedirectURIs, grantTypes, scopes, req.Public)
+	if err != nil {
+		return nil, &rest.CustomError{CodeDesc: &errorcode.FAILED_INTERNAL_ERROR, Message: err.Error()}
+	}
+
+	if _, err := u.repo.SaveClient(ctx, client); err != nil {
+		return nil, toCustomError(err)
+	}
+
+	return &dto.PostClientResponse{
+		ClientID:     client.ClientID,
+		ClientSecret: secret,
+		ClientName:   client.Name,
+		RedirectURIs: client.RedirectURIs,
+		GrantTypes:   client.GrantTypes,
+		Scope:        strings.Join(client.Scopes, " "),
+	}, nil
+}
+
+func (u *usecase) Discovery(ctx context.Context) *dto.GetDiscoveryResponse {
+	return &dto.GetDiscoveryResponse{
+		Issuer:                            u.issuer,
+		AuthorizationEndpoint:             u.issuer + "/oauth/authorize",
+		TokenEndpoint:                     u.issuer + "/oauth/token",
+		UserInfoEndpoint:                  u.issuer + "/oauth/userinfo",
+		JWKSURI:                           u.issuer + "/oauth/jwks",
+		RegistrationEndpoint:              u.issuer + "/v1/oauth/clients",
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{GrantAuthorizationCode, GrantClientCredentials},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{"RS256"},
+		ScopesSupported:                   []string{"openid", "profile", "email", "phone"},
+		ClaimsSupported:                   []string{"sub", "email", "name", "nickname", "phone_number"},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+	}
+}
+
+func (u *usecase) JWKS(ctx context.Context) *dto.GetJWKSResponse {
+	return u.keys.JWKS()
+}
+
+func (u *usecase) UserInfo(ctx context.Context, accessToken string) (*dto.GetUserInfoResponse, *rest.CustomError) {
+	claims, err := u.keys.Parse(accessToken)
+	// client_credentials 로 발급된 토큰은 회원 정보가 없으므로 거부
+	if err != nil || claims["token_use"] != "access" || claims["gty"] == GrantClientCredentials {
+		return nil, oauthError(&errorcode.ACCESS_DENIED, ErrInvalidToken, "invalid access token")
+	}
+
+	userID, _ := claims["sub"].(string)
+	found, cerr := u.users.GetOneByID(ctx, userID)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	scope, _ := claims["scope"].(string)
+	return toUserInfo(found, strings.Fields(scope)), nil
+}
+
+// VerifyClientToken 은 client_credentials 로 발급된 액세스 토큰을 검증한 뒤 클라이언트 아이디와 scope 를 반환
+func (u *usecase) VerifyClientToken(ctx context.Context, accessToken string) (string, []string, *rest.CustomError) {
+	claims, err := u.keys.Parse(accessToken)
+	if err != nil || claims["token_use"] != "access" || claims["gty"] != GrantClientCredentials {
+		return "", nil, oauthError(&errorcode.ACCESS_DENIED, ErrInvalidToken, "invalid client token")
+	}
+
+	clientID, _ := claims["client_id"].(string)
+	scope, _ := claims["scope"].(string)
+
+	return clientID, strings.Fields(scope), nil
+}
+
+func (u *usecase) VerifyRedirectURI(ctx context.Context, clientID, redirectURI string) *rest.CustomError {
+	client, err := u.repo.GetClient(ctx, clientID)
+	if err != nil {
+		if errortype.IsNotFoundErr(err) {
+			return oauthError(&errorcode.BAD_REQUEST, ErrInvalidClient, "unknown client")
+		}
+		return toCustomError(err)
+	}
+
+	if !client.allowsRedirectURI(redirectURI) {
+		return oauthError(&errorcode.BAD_REQUEST, ErrInvalidRequest, "redirect_uri is not registered")
+	}
+
+	return nil
+}
+
+func (u *usecase) ValidateAuthorizeRequest(ctx context.Context, req *dto.GetAuthorizeRequest) *rest.CustomError {
+	if err := u.verifyGrantType(ctx, req.ClientID, GrantAuthorizationCode); err != nil {
+		return err
+	}
+
+	if req.ResponseType != "code" {
+		return oauthError(&errorcode.BAD_REQUEST, ErrUnsupportedResponseType, req.ResponseType)
+	}
+
+	if !contains(strings.Fields(req.Scope), "openid") {
+		return oauthError(&errorcode.BAD_REQUEST, ErrInvalidScope, "openid scope is required")
+	}
+
+	if req.CodeChallengeMethod != "S256" || len(req.CodeChallenge) != 43 {
+		return oauthError(&errorcode.BAD_REQUEST, ErrInvalidRequest, "S256 code_challenge is required")
+	}
+
+	return nil
+}
+
+// Authorize 는 인가 요청을 다시 검증한 뒤 회원에게 인가 코드 발급
+// 회원 확인 (비밀번호, 위험도 평가, 비밀번호 재설정 여부) 은 호출하는 쪽에서 회원 로그인 API 와 같은 방식으로 마친 뒤 호출
+func (u *usecase) Authorize(ctx context.Context, req *dto.PostAuthorizeRequest, userID string) (string, *rest.CustomError) {
+	if err := u.VerifyRedirectURI(ctx, req.ClientID, req.RedirectURI); err != nil {
+		return "", err
+	}
+	if err := u.ValidateAuthorizeRequest(ctx, &req.GetAuthorizeRequest); err != nil {
+		return "", err
+	}
+
+	model, code := newAuthorizationCode(req.ClientID, userID, req.RedirectURI, req.Scope, req.Nonce, req.CodeChallenge, req.CodeChallengeMethod)
+	if err := u.repo.SaveCode(ctx, model); err != nil {
+		return "", toCustomError(err)
+	}
+
+	return code, nil
+}
+
+func (u *usecase) Exchange(ctx context.Context, req *dto.PostTokenRequest) (*dto.PostTokenResponse, *rest.CustomError) {
+	if req.GrantType != GrantAuthorizationCode && req.GrantType != GrantClientCredentials {
+		return nil, oauthError(&errorcode.BAD_REQUEST, ErrUnsupportedGrantType, req.GrantType)
+	}
+
+	client, cerr := u.authenticateClient(ctx, req.ClientID, req.ClientSecret)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	if !client.allowsGrantType(req.GrantType) {
+		return nil, oauthError(&errorcode.BAD_REQUEST, ErrUnauthorizedClient, req.GrantType)
+	}
+
+	if req.GrantType == GrantClientCredentials {
+		return u.exchangeClientCredentials(client, req)
+	}
+
+	// 인가 코드는 검증 결과와 상관없이 1회만 사용 가능
+	code, err := u.repo.ConsumeCode(ctx, req.Code)
+	if err != nil {
+		if errortype.IsNotFoundErr(err) {
+			return nil, oauthError(&errorcode.BAD_REQUEST, ErrInvalidGrant, "invalid authorization code")
+		}
+		return nil, toCustomError(err)
+	}
+
+	if code.ClientID != client.ClientID || code.RedirectURI != req.RedirectURI || time.Now().After(code.ExpiresAt) {
+		return nil, oauthError(&errorcode.BAD_REQUEST, ErrInvalidGrant, "invalid authorization code")
+	}
+
+	if !code.verifyCodeVerifier(req.CodeVerifier) {
+		return nil, oauthError(&errorcode.BAD_REQUEST, ErrInvalidGrant, "code_verifier mismatch")
+	}
+
+	found, cerr := u.users.GetOneByID(ctx, code.UserID)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(accessTokenTTL)
+
+	accessToken, err := u.keys.Sign(jwt.MapClaims{
+		"iss":       u.issuer,
+		"sub":       found.Id,
+		"aud":       client.ClientID,
+		"iat":       now.Unix(),
+		"exp":       expiresAt.Unix(),
+		"scope":     code.Scope,
+		"token_use": "access",
+	})
+	if err != nil {
+		return nil, oauthError(&errorcode.FAILED_INTERNAL_ERROR, ErrServerError, err.Error())
+	}
+
+	idClaims := jwt.MapClaims{
+		"iss":       u.issuer,
+		"sub":       found.Id,
+		"aud":       client.ClientID,
+		"iat":       now.Unix(),
+		"exp":       expiresAt.Unix(),
+		"auth_time": code.AuthTime.Unix(),
+	}
+	if len(code.Nonce) > 0 {
+		idClaims["nonce"] = code.Nonce
+	}
+	info := toUserInfo(found, strings.Fields(code.Scope))
+	for key, value := range map[string]string{
+		"email":        info.Email,
+		"name":         info.Name,
+		"nickname":     info.NickName,
+		"phone_number": info.PhoneNumber,
+	} {
+		if len(value) > 0 {
+			idClaims[key] = value
+		}
+	}
+
+	idToken, err := u.keys.Sign(idClaims)
+	if err != nil {
+		return nil, oauthError(&errorcode.FAILED_INTERNAL_ERROR, ErrServerError, err.Error())
+	}
+	metrics.TokenIssued(metrics.TokenOIDCAccess)
+	metrics.TokenIssued(metrics.TokenOIDCID)
+
+	return &dto.PostTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(accessTokenTTL.Seconds()),
+		IDToken:     idToken,
+		Scope:       code.Scope,
+	}, nil
+}
+
+func (u *usecase) exchangeClientCredentials(client *Client, req *dto.PostTokenRequest) (*dto.PostTokenResponse, *rest.CustomError) {
+	scopes, ok := client.grantScopes(strings.Fields(req.Scope))
+	if !ok {
+		return nil, oauthError(&errorcode.BAD_REQUEST, ErrInvalidScope, req.Scope)
+	}
+
+	now := time.Now()
+	scope := strings.Join(scopes, " ")
+
+	accessToken, err := u.keys.Sign(jwt.MapClaims{
+		"iss":       u.issuer,
+		"sub":       client.ClientID,
+		"aud":       u.issuer,
+		"client_id": client.ClientID,
+		"iat":       now.Unix(),
+		"exp":       now.Add(accessTokenTTL).Unix(),
+		"scope":     scope,
+		"gty":       GrantClientCredentials,
+		"token_use": "access",
+	})
+	if err != nil {
+		return nil, oauthError(&errorcode.FAILED_INTERNAL_ERROR, ErrServerError, err.Error())
+	}
+	metrics.TokenIssued(metrics.TokenClientCredentials)
+
+	return &dto.PostTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(accessTokenTTL.Seconds()),
+		Scope:       scope,
+	}, nil
+}
+
+func (u *usecase) verifyGrantType(ctx context.Context, clientID, grantType string) *rest.CustomError {
+	client, err := u.repo.GetClient(ctx, clientID)
+	if err != nil {
+		return toCustomError(err)
+	}
+
+	if !client.allowsGrantType(grantType) {
+		return oauthError(&errorcode.BAD_REQUEST, ErrUnauthorizedClient, grantType)
+	}
+
+	return nil
+}
+
+func (u *usecase) authenticateClient(ctx context.Context, clientID, secret string) (*Client, *rest.CustomError) {
+	client, err := u.repo.GetClient(ctx, clientID)
+	if err != nil {
+		if errortype.IsNotFoundErr(err) {
+			return nil, oauthError(&errorcode.ACCESS_DENIED, ErrInvalidClient, "unknown client")
+		}
+		return nil, toCustomError(err)
+	}
+
+	// public 클라이언트는 PKCE 로만 검증 (client_credentials 는 등록 시 public 으로 만들 수 없음)
+	if !client.isPublic() && !client.compareSecret(secret) {
+		return nil, oauthError(&errorcode.ACCESS_DENIED, ErrInvalidClient, "client authentication failed")
+	}
+
+	return client, nil
+}
+
+func toUserInfo(found *dto.GetUserResponse, scopes []string) *dto.GetUserInfoResponse {
+	info := &dto.GetUserInfoResponse{Sub: found.Id}
+	for _, scope := range scopes {
+		switch scope {
+		case "profile":
+			info.Name = found.Name
+			info.NickName = found.NickName
+		case "email":
+			info.Email = found.Email
+		case "phone":
+			info.PhoneNumber = found.Phone
+		}
+	}
+	return info
+}
+
+func oauthError(codeDesc *errorcode.CodeDescription, code, description string) *rest.CustomError {
+	return &rest.CustomError{CodeDesc: codeDesc, Message: description, Data: code}
+}
+
+func toCustomError(err error) *rest.CustomError {
+	if errortype.IsDecodeError(err) {
+		return &rest.CustomError{CodeDesc: &errorcode.FAILED_DB_PROCESSING, Message: err.Error(), Data: ErrServerError}
+	} else if errortype.IsNotFoundErr(err) {
+		return &rest.CustomError{CodeDesc: &errorcode.NOT_FOUND_ERROR, Message: err.Error(), Data: ErrInvalidRequest}
+	} else {
+		return &rest.CustomError{CodeDesc: &errorcode.FAILED_INTERNAL_ERROR, Message: err.Error(), Data: ErrServerError}
+	}
+}
+
+// NewUsecase returns new Usecase implementation
+func NewUsecase(repo Repository, users user.Usecase, keys *KeySet, issuer string) Usecase {
+	return &usecase{repo: repo, users: users, keys: keys, issuer: strings.TrimSuffix(issuer, "/")}
+}
+
+var _ Usecase = &usecase{}
@@ -0,0 +1,188 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// 인증 방식 (components.securitySchemes)
+const (
+	BearerAuth = "bearerAuth" // 회원 JWT 혹은 클라이언트 토큰 (Authorization: Bearer)
+	APIKeyAuth = "apiKeyAuth" // API 키 (X-API-Key)
+)
+
+const (
+	mimeJSON      = "application/json"
+	mimeHTML      = "text/html"
+	errorResponse = "ErrorResponse" // data 가 없는 실패 응답
+)
+
+// Route 는 gin 라우트 하나와 요청 / 응답 모델
+type Route struct {
+	Method      string // http.MethodGet ..
+	Path        string // gin 경로 (ex. /v1/users/:userID)
+	OperationID string // 핸들러 이름 (ex. GetMe), 라우트와 문서가 같은 핸들러를 가리키는지 확인할 때 사용
+	Tag         string
+	Summary     string
+	Description string
+
+	Request    interface{} // JSON 요청 본문 모델
+	Query      interface{} // query string 모델 (form 태그)
+	Parameters []Parameter // 모델로 표현하지 않은 query / header
+	Response   interface{} // 성공 응답의 data 모델, nil 이면 data 는 null
+	Page       bool        // 성공 응답이 JSON 대신 HTML 화면인지 여부 (Response 무시)
+
+	Auth   bool     // 회원 JWT 필요 여부
+	Scopes []string // 회원 JWT 외에 허용하는 클라이언트 토큰 / API 키 scope
+
+	Errors map[int]interface{} // 라우트별 실패 응답 (상태 코드 → data 모델, 없으면 nil)
+}
+
+// Build 는 routes 로 OpenAPI 문서를 생성
+// validationError 는 입력 검증 실패 (400) 응답의 data 모델
+func Build(info Info, serverURL string, validationError interface{}, routes []Route) (*Document, error) {
+	g := &generator{schemas: map[string]*Schema{}}
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Servers: []Server{{URL: serverURL}},
+		Paths:   map[string]*PathItem{},
+		Components: Components{
+			Schemas: g.schemas,
+			SecuritySchemes: map[string]*SecurityScheme{
+				BearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT", Description: "회원 JWT 혹은 클라이언트 토큰 (client_credentials)"},
+				APIKeyAuth: {Type: "apiKey", In: "header", Name: "X-API-Key", Description: "API 키 (Authorization: Bearer sk_... 도 가능)"},
+			},
+		},
+	}
+
+	for _, route := range routes {
+		path, params := toPath(route.Path)
+
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+		if op := item.operations()[route.Method]; op != nil {
+			return nil, fmt.Errorf("duplicated route: %s %s", route.Method, route.Path)
+		}
+
+		op := g.operation(&route, params, validationError)
+		if !item.set(route.Method, op) {
+			return nil, fmt.Errorf("unsupported method: %s %s", route.Method, route.Path)
+		}
+	}
+	return doc, nil
+}
+
+func (g *generator) operation(route *Route, params []Parameter, validationError interface{}) *Operation {
+	op := &Operation{
+		OperationID: route.OperationID,
+		Summary:     route.Summary,
+		Description: route.Description,
+		Parameters:  params,
+		Responses: map[string]*Response{
+			"200":     g.response("성공", route.Response, false),
+			"default": g.response("실패 (code 로 구분)", nil, true),
+		},
+	}
+	if len(route.Tag) > 0 {
+		op.Tags = []string{route.Tag}
+	}
+	if route.Page {
+		op.Responses["200"] = &Response{
+			Description: "성공 (HTML 화면)",
+			Content:     map[string]MediaType{mimeHTML: {Schema: &Schema{Type: "string"}}},
+		}
+	}
+
+	if route.Query != nil {
+		op.Parameters = append(op.Parameters, g.parameters(reflect.TypeOf(route.Query))...)
+	}
+	op.Parameters = append(op.Parameters, route.Parameters...)
+
+	if route.Request != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{mimeJSON: {Schema: g.schema(reflect.TypeOf(route.Request))}},
+		}
+	}
+	if route.Request != nil || route.Query != nil {
+		op.Responses["400"] = g.response("입력 검증 실패", validationError, true)
+	}
+
+	if route.Auth {
+		op.Security = []map[string][]string{{BearerAuth: {}}}
+		if len(route.Scopes) > 0 {
+			op.Security = append(op.Security, map[string][]string{APIKeyAuth: {}})
+			op.Description = strings.TrimSpace(op.Description + "\n\n허용 scope (클라이언트 토큰 / API 키): " + strings.Join(route.Scopes, ", "))
+		}
+		op.Responses["401"] = g.response("인증 실패", nil, true)
+	}
+
+	for status, data := range route.Errors {
+		op.Responses[fmt.Sprint(status)] = g.response(http.StatusText(status), data, true)
+	}
+	return op
+}
+
+// response 는 rest.ApiResponse 형태의 응답 ({code, message, data})
+// data 가 없는 실패 응답은 공통 스키마 (ErrorResponse) 참조
+func (g *generator) response(description string, data interface{}, failure bool) *Response {
+	var schema *Schema
+	switch {
+	case failure && data == nil:
+		if _, ok := g.schemas[errorResponse]; !ok {
+			g.schemas[errorResponse] = envelope(&Schema{Type: "string"}, &Schema{Nullable: true})
+		}
+		schema = &Schema{Ref: "#/components/schemas/" + errorResponse}
+	case failure:
+		schema = envelope(&Schema{Type: "string"}, g.schema(reflect.TypeOf(data)))
+	case data == nil:
+		schema = envelope(&Schema{Type: "string", Enum: []interface{}{"SUCCESS"}}, &Schema{Nullable: true})
+	default:
+		schema = envelope(&Schema{Type: "string", Enum: []interface{}{"SUCCESS"}}, g.schema(reflect.TypeOf(data)))
+	}
+
+	return &Response{
+		Description: description,
+		Content:     map[string]MediaType{mimeJSON: {Schema: schema}},
+	}
+}
+
+func envelope(code, data *Schema) *Schema {
+	return &Schema{
+		Type:     "object",
+		Required: []string{"code", "data"},
+		Props: map[string]*Schema{
+			"code":    code,
+			"message": {Type: "string"},
+			"data":    data,
+		},
+	}
+}
+
+// toPath 는 gin 경로를 OpenAPI 경로로 바꾸고 경로 변수를 parameter 로 반환 (ex. /users/:userID → /users/{userID})
+func toPath(ginPath string) (string, []Parameter) {
+	var params []Parameter
+
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if len(segment) > 1 && (segment[0] == ':' || segment[0] == '*') {
+			name := segment[1:]
+			segments[i] = "{" + name + "}"
+			params = append(params, Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// ToPath 는 gin 경로에 해당하는 OpenAPI 경로
+func ToPath(ginPath string) string {
+	path, _ := toPath(ginPath)
+	return path
+}
@@ -0,0 +1,147 @@
+// Package openapi 는 라우트 목록과 요청 / 응답 모델 (dto) 로 OpenAPI 3 문서를 생성
+//
+// 요청 모델의 binding / validate 태그는 스키마 제약 조건 (required, minLength, enum ..) 으로 옮기고,
+// 응답은 모두 rest.ApiResponse 형태 ({code, message, data}) 로 감싸서 기술
+package openapi
+
+// Version 은 생성하는 문서의 OpenAPI 버전
+const Version = "3.0.3"
+
+// Document 는 OpenAPI 문서 (사용하는 항목만 정의)
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+// PathItem 은 경로 하나의 HTTP method 별 operation
+type PathItem struct {
+	Get    *Operation `json:"get,omitempty"`
+	Put    *Operation `json:"put,omitempty"`
+	Post   *Operation `json:"post,omitempty"`
+	Delete *Operation `json:"delete,omitempty"`
+	Patch  *Operation `json:"patch,omitempty"`
+}
+
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"` // path, query, header
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"` // http, apiKey
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// Schema 는 JSON Schema (OpenAPI 3.0 부분 집합), 타입이 없으면 모든 값 허용
+type Schema struct {
+	Ref string `json:"$ref,omitempty"`
+
+	Type     string             `json:"type,omitempty"`
+	Format   string             `json:"format,omitempty"`
+	Nullable bool               `json:"nullable,omitempty"`
+	Enum     []interface{}      `json:"enum,omitempty"`
+	Pattern  string             `json:"pattern,omitempty"`
+	Items    *Schema            `json:"items,omitempty"`
+	Required []string           `json:"required,omitempty"`
+	Props    map[string]*Schema `json:"properties,omitempty"`
+	Extra    *Schema            `json:"additionalProperties,omitempty"`
+
+	MinLength *int     `json:"minLength,omitempty"`
+	MaxLength *int     `json:"maxLength,omitempty"`
+	MinItems  *int     `json:"minItems,omitempty"`
+	MaxItems  *int     `json:"maxItems,omitempty"`
+	Minimum   *float64 `json:"minimum,omitempty"`
+	Maximum   *float64 `json:"maximum,omitempty"`
+}
+
+// Operations 는 문서의 모든 operation 을 "METHOD /path" 로 반환 (ex. "GET /v1/users/{userID}")
+func (d *Document) Operations() map[string]*Operation {
+	result := map[string]*Operation{}
+	for path, item := range d.Paths {
+		for method, op := range item.operations() {
+			if op != nil {
+				result[method+" "+path] = op
+			}
+		}
+	}
+	return result
+}
+
+func (p *PathItem) operations() map[string]*Operation {
+	return map[string]*Operation{
+		"GET":    p.Get,
+		"PUT":    p.Put,
+		"POST":   p.Post,
+		"DELETE": p.Delete,
+		"PATCH":  p.Patch,
+	}
+}
+
+// set 은 method 의 operation 을 지정, 지원하지 않는 method 면 false
+func (p *PathItem) set(method string, op *Operation) bool {
+	switch method {
+	case "GET":
+		p.Get = op
+	case "PUT":
+		p.Put = op
+	case "POST":
+		p.Post = op
+	case "DELETE":
+		p.Delete = op
+	case "PATCH":
+		p.Patch = op
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,216 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// phonePattern 은 go-common validator 의 customPhone 규칙과 같은 전화번호 형식
+const phonePattern = `^01([0|1|6|7|8|9])([0-9]{3,4})([0-9]{4})$`
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	rawType  = reflect.TypeOf(json.RawMessage{})
+)
+
+// generator 는 Go 타입의 스키마를 만들고 이름 있는 struct 는 components.schemas 에 등록
+type generator struct {
+	schemas map[string]*Schema
+}
+
+// schema 는 t 의 스키마, 이름 있는 struct 는 components 참조 ($ref)
+func (g *generator) schema(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", Extra: g.schema(t.Elem())}
+	case reflect.Struct:
+		if len(t.Name()) == 0 {
+			return g.object(t)
+		}
+		if _, ok := g.schemas[t.Name()]; !ok {
+			g.schemas[t.Name()] = nil // 자기 자신을 참조하는 struct
+			g.schemas[t.Name()] = g.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + t.Name()}
+	}
+	return &Schema{}
+}
+
+// object 는 struct 의 json 필드 스키마, binding / validate 태그를 제약 조건으로 옮김
+func (g *generator) object(t reflect.Type) *Schema {
+	result := &Schema{Type: "object", Props: map[string]*Schema{}}
+	for _, field := range fields(t, "json") {
+		prop := g.schema(field.Type)
+		if constrain(prop, field.Type, rules(field.StructField)) {
+			result.Required = append(result.Required, field.name)
+		}
+		result.Props[field.name] = prop
+	}
+	return result
+}
+
+// parameters 는 query 모델 (form 태그) 의 필드를 query parameter 로 반환
+func (g *generator) parameters(t reflect.Type) []Parameter {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var result []Parameter
+	for _, field := range fields(t, "form") {
+		schema := g.schema(field.Type)
+		required := constrain(schema, field.Type, rules(field.StructField))
+		result = append(result, Parameter{Name: field.name, In: "query", Required: required, Schema: schema})
+	}
+	return result
+}
+
+type namedField struct {
+	reflect.StructField
+	name string
+}
+
+// fields 는 tag 이름으로 직렬화되는 필드 (embedded struct 필드 포함, "-" 제외)
+func fields(t reflect.Type, tag string) []namedField {
+	var result []namedField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+		if name == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		if field.Anonymous && len(name) == 0 && field.Type.Kind() == reflect.Struct {
+			result = append(result, fields(field.Type, tag)...)
+			continue
+		}
+		if len(name) == 0 {
+			name = field.Name
+		}
+		result = append(result, namedField{field, name})
+	}
+	return result
+}
+
+// rules 는 binding 태그와 validate 태그의 검증 규칙
+func rules(field reflect.StructField) []string {
+	var result []string
+	for _, tag := range []string{"binding", "validate"} {
+		if value := field.Tag.Get(tag); len(value) > 0 {
+			result = append(result, strings.Split(value, ",")...)
+		}
+	}
+	return result
+}
+
+// constrain 은 검증 규칙을 schema 의 제약 조건으로 옮기고 필수 항목이면 true 반환
+// dive 뒤의 규칙은 목록 항목에 적용, 참조 ($ref) 스키마에는 제약 조건을 추가하지 않음
+func constrain(schema *Schema, t reflect.Type, rules []string) bool {
+	required := false
+	for i, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = true
+		case "dive":
+			if schema.Items != nil && t.Kind() != reflect.Ptr {
+				constrain(schema.Items, t.Elem(), rules[i+1:])
+			}
+			return required
+		}
+		if len(schema.Ref) == 0 {
+			apply(schema, name, param)
+		}
+	}
+	return required
+}
+
+func apply(schema *Schema, name, param string) {
+	switch name {
+	case "len":
+		setSize(schema, param, true, true)
+	case "min", "gte":
+		setSize(schema, param, true, false)
+	case "max", "lte":
+		setSize(schema, param, false, true)
+	case "oneof":
+		for _, value := range strings.Fields(param) {
+			if schema.Type == "integer" || schema.Type == "number" {
+				if n, err := strconv.ParseFloat(value, 64); err == nil {
+					schema.Enum = append(schema.Enum, n)
+				}
+				continue
+			}
+			schema.Enum = append(schema.Enum, value)
+		}
+	case "email", "customEmail":
+		schema.Format = "email"
+	case "url", "uri":
+		schema.Format = "uri"
+	case "customPhone":
+		schema.Pattern = phonePattern
+	}
+}
+
+// setSize 는 길이 / 크기 규칙을 값 종류 (문자열, 목록, 숫자) 에 맞는 제약 조건으로 지정
+func setSize(schema *Schema, param string, min, max bool) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	size := int(n)
+
+	switch schema.Type {
+	case "string":
+		if min {
+			schema.MinLength = &size
+		}
+		if max {
+			schema.MaxLength = &size
+		}
+	case "array":
+		if min {
+			schema.MinItems = &size
+		}
+		if max {
+			schema.MaxItems = &size
+		}
+	case "integer", "number":
+		if min {
+			schema.Minimum = &n
+		}
+		if max {
+			schema.Maximum = &n
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type embedded struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type sample struct {
+	embedded
+	Name    string         `json:"name" binding:"required" validate:"min=2,max=10"`
+	Scopes  []string       `json:"scopes" binding:"required,min=1,dive,oneof=users:read audit:read"`
+	Size    int64          `json:"size" binding:"omitempty,oneof=10 20" validate:"max=100"`
+	Phone   string         `json:"phone" binding:"omitempty,customPhone"`
+	Secret  string         `json:"-"`
+	Labels  map[string]int `json:"labels"`
+	Raw     json.RawMessage
+	private string
+}
+
+type query struct {
+	Page int    `form:"page" binding:"omitempty,min=1"`
+	Kind string `form:"kind" binding:"required,oneof=a b"`
+}
+
+func TestSchema(t *testing.T) {
+	g := &generator{schemas: map[string]*Schema{}}
+
+	ref := g.schema(reflect.TypeOf(&sample{}))
+	if ref.Ref != "#/components/schemas/sample" {
+		t.Fatalf("got ref %q", ref.Ref)
+	}
+
+	got, _ := json.Marshal(g.schemas["sample"])
+	want := `{"type":"object","required":["name","scopes"],"properties":{` +
+		`"Raw":{},` +
+		`"created_at":{"type":"string","format":"date-time"},` +
+		`"labels":{"type":"object","additionalProperties":{"type":"integer","format":"int32"}},` +
+		`"name":{"type":"string","minLength":2,"maxLength":10},` +
+		`"phone":{"type":"string","pattern":"^01([0|1|6|7|8|9])([0-9]{3,4})([0-9]{4})$"},` +
+		`"scopes":{"type":"array","items":{"type":"string","enum":["users:read","audit:read"]},"minItems":1},` +
+		`"size":{"type":"integer","format":"int64","enum":[10,20],"maximum":100}}}`
+	if string(got) != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestParameters(t *testing.T) {
+	g := &generator{schemas: map[string]*Schema{}}
+
+	got, _ := json.Marshal(g.parameters(reflect.TypeOf(query{})))
+	want := `[{"name":"page","in":"query","schema":{"type":"integer","format":"int32","minimum":1}},` +
+		`{"name":"kind","in":"query","required":true,"schema":{"type":"string","enum":["a","b"]}}]`
+	if string(got) != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	routes := []Route{
+		{Method: "GET", Path: "/v1/items/:id", OperationID: "GetOne", Response: sample{}, Auth: true, Scopes: []string{"items:read"}},
+		{Method: "POST", Path: "/v1/items", OperationID: "SaveOne", Request: sample{}},
+		{Method: "GET", Path: "/v1/items", OperationID: "ListPage", Page: true},
+	}
+
+	doc, err := Build(Info{Title: "test", Version: "1"}, "/api", nil, routes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ops := doc.Operations()
+	if len(ops) != 3 || ops["GET /v1/items/{id}"] == nil || ops["POST /v1/items"] == nil {
+		t.Fatalf("unexpected operations: %v", ops)
+	}
+
+	get := ops["GET /v1/items/{id}"]
+	if len(get.Parameters) != 1 || get.Parameters[0].In != "path" || get.Parameters[0].Name != "id" {
+		t.Fatalf("unexpected parameters: %+v", get.Parameters)
+	}
+	if len(get.Security) != 2 || get.Responses["401"] == nil {
+		t.Fatalf("unexpected security: %+v", get.Security)
+	}
+	if ops["POST /v1/items"].Responses["400"] == nil {
+		t.Fatal("request body without validation failure response")
+	}
+	if _, ok := ops["GET /v1/items"].Responses["200"].Content["text/html"]; !ok {
+		t.Fatalf("page without HTML response: %+v", ops["GET /v1/items"].Responses["200"])
+	}
+
+	if _, err := Build(Info{}, "/api", nil, append(routes, routes[0])); err == nil {
+		t.Fatal("duplicated route: want error")
+	}
+}
@@ -0,0 +1,25 @@
+package openapi
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+)
+
+// uiVersion 은 Swagger UI (swagger-ui-dist) 버전, 정적 파일은 unpkg 에서 불러옴
+const uiVersion = "5.9.0"
+
+//go:embed ui.html
+var uiTemplate string
+
+var ui = template.Must(template.New("ui").Parse(uiTemplate))
+
+// UI 는 specURL 의 문서를 보여주는 Swagger UI 페이지
+func UI(title, specURL string) ([]byte, error) {
+	var buf bytes.Buffer
+	err := ui.Execute(&buf, struct{ Title, SpecURL, UIVersion string }{title, specURL, uiVersion})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
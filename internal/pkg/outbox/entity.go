@@ -0,0 +1,75 @@
+// Package outbox 는 도메인 이벤트를 상태 변경과 같은 쓰기로 저장하고 (transactional outbox)
+// Relay 가 저장된 이벤트를 Sink (로그, 웹훅 등) 로 전달
+//
+// 전달은 최소 한번 (at-least-once) 이므로 Sink 를 받는 쪽은 Message.ID 로 중복을 확인
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/kamva/mgm/v3"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Event 는 outbox 에 저장할 도메인 이벤트 (ex. user.UserRegistered)
+type Event interface {
+	EventType() string
+}
+
+// Message 는 outbox 에 저장된 이벤트 하나
+type Message struct {
+	mgm.DefaultModel `bson:",inline"`
+	Type             string     `json:"type" bson:"type"`                           // 이벤트 종류 (ex. user.registered)
+	AggregateID      string     `json:"aggregate_id" bson:"aggregate_id"`           // 이벤트가 일어난 대상 (ex. 회원 아이디)
+	Payload          string     `json:"payload" bson:"payload"`                     // 이벤트 내용 (JSON)
+	OccurredAt       time.Time  `json:"occurred_at" bson:"occurred_at"`             // 이벤트 발생 시각
+	Attempts         int        `json:"attempts" bson:"attempts"`                   // 전달 시도 횟수
+	NextAttemptAt    time.Time  `json:"next_attempt_at" bson:"next_attempt_at"`     // 이 시각 이후 전달 시도 (전달 중에는 Relay 가 가져간 기간의 끝)
+	LastError        string     `json:"last_error,omitempty" bson:"last_error"`     // 마지막 전달 실패 사유
+	PublishedAt      *time.Time `json:"published_at,omitempty" bson:"published_at"` // 모든 Sink 로 전달한 시각
+}
+
+func (m *Message) CollectionName() string {
+	return "outbox"
+}
+
+// Envelope 는 Sink 가 외부로 보내는 메시지 형식
+type Envelope struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	AggregateID string          `json:"aggregate_id"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// Envelope 는 메시지를 외부로 보낼 형식으로 변환
+func (m *Message) Envelope() *Envelope {
+	return &Envelope{
+		ID:          m.ID.Hex(),
+		Type:        m.Type,
+		AggregateID: m.AggregateID,
+		OccurredAt:  m.OccurredAt,
+		Data:        json.RawMessage(m.Payload),
+	}
+}
+
+// NewMessage 는 event 를 저장할 메시지 생성, 아이디는 저장 전에 정해지므로 Sink 에서 중복 확인에 사용
+// 시각은 저장소 (MongoDB) 정밀도에 맞춰 밀리초 단위로 자름
+func NewMessage(aggregateID string, event Event, now time.Time) (*Message, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	occurredAt := now.UTC().Truncate(time.Millisecond)
+	message := &Message{
+		Type:          event.EventType(),
+		AggregateID:   aggregateID,
+		Payload:       string(payload),
+		OccurredAt:    occurredAt,
+		NextAttemptAt: occurredAt,
+	}
+	message.ID = primitive.NewObjectID()
+	return message, nil
+}
@@ -0,0 +1,40 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"signupin-api/internal/pkg/metrics"
+)
+
+// instrumentedRepository 는 저장소 호출 시간과 실패를 지표로 기록
+type instrumentedRepository struct {
+	next Repository
+}
+
+func (r *instrumentedRepository) GetPending(ctx context.Context, now time.Time, limit int64) (_ []Message, err error) {
+	defer metrics.ObserveRepository("outbox", "GetPending", time.Now(), &err)
+	return r.next.GetPending(ctx, now, limit)
+}
+
+func (r *instrumentedRepository) Claim(ctx context.Context, ID string, attempts int, until time.Time) (_ bool, err error) {
+	defer metrics.ObserveRepository("outbox", "Claim", time.Now(), &err)
+	return r.next.Claim(ctx, ID, attempts, until)
+}
+
+func (r *instrumentedRepository) MarkPublished(ctx context.Context, ID string, publishedAt time.Time) (err error) {
+	defer metrics.ObserveRepository("outbox", "MarkPublished", time.Now(), &err)
+	return r.next.MarkPublished(ctx, ID, publishedAt)
+}
+
+func (r *instrumentedRepository) MarkFailed(ctx context.Context, ID string, nextAttemptAt time.Time, reason string) (err error) {
+	defer metrics.ObserveRepository("outbox", "MarkFailed", time.Now(), &err)
+	return r.next.MarkFailed(ctx, ID, nextAttemptAt, reason)
+}
+
+// Instrument 는 호출 시간과 실패를 지표 (/metrics) 로 기록하는 저장소 반환
+func Instrument(repo Repository) Repository {
+	return &instrumentedRepository{next: repo}
+}
+
+var _ Repository = &instrumentedRepository{}
@@ -0,0 +1,119 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"signupin-api/internal/pkg/outbox"
+
+	"github.com/kamva/mgm/v3"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type outboxRepo struct {
+	client *mongo.Client
+}
+
+var _ outbox.Repository = &outboxRepo{}
+
+func (r *outboxRepo) GetPending(ctx context.Context, now time.Time, limit int64) ([]outbox.Message, error) {
+	filter := bson.M{"published_at": nil, "next_attempt_at": bson.M{"$lte": now}}
+	opts := options.Find().SetSort(bson.D{{Key: "occurred_at", Value: 1}}).SetLimit(limit)
+
+	found := []outbox.Message{}
+	coll := mgm.Coll(&outbox.Message{})
+	err := coll.SimpleFindWithCtx(ctx, &found, filter, opts)
+	if err != nil {
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return found, nil
+}
+
+func (r *outboxRepo) Claim(ctx context.Context, ID string, attempts int, until time.Time) (bool, error) {
+	objectID, err := utils.MapToObjectID(ID)
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{"_id": objectID, "attempts": attempts, "published_at": nil}
+	update := bson.M{
+		"$inc": bson.M{"attempts": 1},
+		"$set": bson.M{"next_attempt_at": until, "updated_at": time.Now().UTC()},
+	}
+
+	coll := mgm.Coll(&outbox.Message{})
+	result, err := coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, errortype.ParseAndReturnDBError(err, coll.Name(), filter, update, nil)
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *outboxRepo) MarkPublished(ctx context.Context, ID string, publishedAt time.Time) error {
+	return r.update(ctx, ID, bson.M{"published_at": publishedAt, "last_error": ""})
+}
+
+func (r *outboxRepo) MarkFailed(ctx context.Context, ID string, nextAttemptAt time.Time, reason string) error {
+	return r.update(ctx, ID, bson.M{"next_attempt_at": nextAttemptAt, "last_error": reason})
+}
+
+func (r *outboxRepo) update(ctx context.Context, ID string, set bson.M) error {
+	objectID, err := utils.MapToObjectID(ID)
+	if err != nil {
+		return err
+	}
+
+	set["updated_at"] = time.Now().UTC()
+	filter := bson.M{"_id": objectID}
+	update := bson.M{"$set": set}
+
+	coll := mgm.Coll(&outbox.Message{})
+	result, err := coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), filter, update, nil)
+	}
+	if result.MatchedCount == 0 {
+		return errortype.NotFoundError(coll.Name(), filter, update, nil)
+	}
+
+	return nil
+}
+
+// Insert 는 메시지 저장, 회원 저장소가 상태 변경과 같은 트랜잭션 (ctx 는 mongo.SessionContext) 에서 호출
+// 오류는 트랜잭션을 실행한 저장소가 변환하므로 그대로 반환
+func Insert(ctx context.Context, messages []*outbox.Message) error {
+	coll := mgm.Coll(&outbox.Message{})
+	for _, message := range messages {
+		if err := coll.CreateWithCtx(ctx, message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EnsureIndexes 는 전달할 메시지 조회용 index 생성 (이미 있으면 무시)
+func EnsureIndexes(ctx context.Context) error {
+	index := mongo.IndexModel{
+		Keys: bson.D{{Key: "published_at", Value: 1}, {Key: "next_attempt_at", Value: 1}},
+	}
+
+	coll := mgm.Coll(&outbox.Message{})
+	_, err := coll.Indexes().CreateOne(ctx, index)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), nil, nil, nil)
+	}
+
+	return nil
+}
+
+func New(client *mongo.Client) outbox.Repository {
+	return &outboxRepo{client}
+}
@@ -0,0 +1,162 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"signupin-api/internal/pkg/metrics"
+
+	"golang.org/x/exp/slog"
+)
+
+// 기본 전달 설정 (Options 의 빈 값)
+const (
+	defaultInterval   = time.Second
+	defaultBatchSize  = 100
+	defaultLease      = 30 * time.Second
+	defaultMaxBackoff = 10 * time.Minute
+)
+
+// Sink 는 outbox 의 메시지를 전달받는 곳 (ex. 로그, 웹훅)
+// 같은 메시지를 두번 이상 받을 수 있으므로 Message.ID 로 중복 확인
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, message *Message) error
+}
+
+// Options 는 Relay 의 전달 설정
+type Options struct {
+	Interval   time.Duration // 전달할 메시지를 찾는 주기
+	BatchSize  int64         // 한번에 가져올 메시지 수
+	Lease      time.Duration // 가져간 메시지의 전달 제한 시간, 지나면 다른 Relay 가 다시 가져감
+	MaxBackoff time.Duration // 실패한 메시지를 다시 전달하기까지 최대 대기 시간
+}
+
+// Relay 는 outbox 의 메시지를 모든 Sink 로 전달
+// 여러 인스턴스에서 실행해도 Claim 으로 메시지 하나는 한 Relay 만 가져감
+// 하나라도 실패한 Sink 가 있으면 Interval 부터 두배씩 늘린 간격으로 모든 Sink 에 다시 전달 (순서는 보장하지 않음)
+type Relay struct {
+	repo  Repository
+	sinks []Sink
+	opts  Options
+	now   func() time.Time
+}
+
+// NewRelay 는 repo 의 메시지를 sinks 로 전달하는 Relay 반환
+func NewRelay(repo Repository, sinks []Sink, opts Options) *Relay {
+	if opts.Interval <= 0 {
+		opts.Interval = defaultInterval
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = defaultLease
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = defaultMaxBackoff
+	}
+	return &Relay{repo: repo, sinks: sinks, opts: opts, now: time.Now}
+}
+
+// Run 은 ctx 가 취소될 때까지 Interval 마다 Flush
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.Flush(ctx); err != nil && ctx.Err() == nil {
+			slog.WarnCtx(ctx, "relaying outbox failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush 는 지금 전달할 수 있는 메시지를 한번 전달하고 전달을 마친 메시지 수 반환
+// 전달에 실패한 메시지는 오류가 아니라 다음 시도 시각을 기록
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	now := r.now()
+
+	pending, err := r.repo.GetPending(ctx, now, r.opts.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for i := range pending {
+		message := &pending[i]
+		ID := message.ID.Hex()
+
+		claimed, err := r.repo.Claim(ctx, ID, message.Attempts, now.Add(r.opts.Lease))
+		if err != nil {
+			return published, err
+		}
+		if !claimed {
+			continue
+		}
+		message.Attempts++
+
+		if err := r.publish(ctx, message); err != nil {
+			metrics.EventFailed(message.Type)
+			slog.WarnCtx(ctx, "publishing domain event failed",
+				"event_id", ID, "event_type", message.Type, "attempts", message.Attempts, "error", err)
+
+			if err := r.repo.MarkFailed(ctx, ID, r.now().Add(r.backoff(message.Attempts)), err.Error()); err != nil {
+				return published, err
+			}
+			continue
+		}
+
+		if err := r.repo.MarkPublished(ctx, ID, r.now()); err != nil {
+			return published, err
+		}
+		metrics.EventPublished(message.Type)
+		published++
+	}
+	return published, nil
+}
+
+// publish 는 Lease 안에 모든 Sink 로 전달
+func (r *Relay) publish(ctx context.Context, message *Message) error {
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Lease)
+	defer cancel()
+
+	for _, sink := range r.sinks {
+		if err := sink.Publish(ctx, message); err != nil {
+			return fmt.Errorf("%s: %w", sink.Name(), err)
+		}
+	}
+	return nil
+}
+
+// backoff 는 attempts 번째 실패 후 다시 전달하기까지의 대기 시간
+func (r *Relay) backoff(attempts int) time.Duration {
+	wait := r.opts.Interval
+	for i := 1; i < attempts && wait < r.opts.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > r.opts.MaxBackoff {
+		wait = r.opts.MaxBackoff
+	}
+	return wait
+}
+
+// LogSink 는 이벤트 종류와 대상만 로그로 남기는 Sink (연동할 서비스가 없는 경우, 로컬 개발용)
+type LogSink struct{}
+
+func (LogSink) Name() string {
+	return "log"
+}
+
+func (LogSink) Publish(ctx context.Context, message *Message) error {
+	slog.InfoCtx(ctx, "domain event", "event_id", message.ID.Hex(), "event_type", message.Type, "aggregate_id", message.AggregateID)
+	return nil
+}
+
+var _ Sink = LogSink{}
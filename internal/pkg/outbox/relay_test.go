@@ -0,0 +1,281 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kamva/mgm/v3"
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+)
+
+// memoryRepository 는 Claim 의 시도 횟수 비교를 흉내낸 Repository
+type memoryRepository struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+var _ Repository = &memoryRepository{}
+
+func (r *memoryRepository) add(t *testing.T, count int, now time.Time) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		message, err := NewMessage(fmt.Sprint("user-", i), testEvent{Number: i}, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.messages = append(r.messages, *message)
+	}
+}
+
+func (r *memoryRepository) GetPending(ctx context.Context, now time.Time, limit int64) ([]Message, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var found []Message
+	for _, m := range r.messages {
+		if m.PublishedAt == nil && !m.NextAttemptAt.After(now) && int64(len(found)) < limit {
+			found = append(found, m)
+		}
+	}
+	return found, nil
+}
+
+func (r *memoryRepository) Claim(ctx context.Context, ID string, attempts int, until time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m := r.find(ID)
+	if m == nil || m.Attempts != attempts || m.PublishedAt != nil {
+		return false, nil
+	}
+	m.Attempts++
+	m.NextAttemptAt = until
+	return true, nil
+}
+
+func (r *memoryRepository) MarkPublished(ctx context.Context, ID string, publishedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m := r.find(ID)
+	if m == nil {
+		return errortype.NotFoundError(mgm.CollName(&Message{}), nil, nil, nil)
+	}
+	m.PublishedAt = &publishedAt
+	return nil
+}
+
+func (r *memoryRepository) MarkFailed(ctx context.Context, ID string, nextAttemptAt time.Time, reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m := r.find(ID)
+	if m == nil {
+		return errortype.NotFoundError(mgm.CollName(&Message{}), nil, nil, nil)
+	}
+	m.NextAttemptAt, m.LastError = nextAttemptAt, reason
+	return nil
+}
+
+func (r *memoryRepository) find(ID string) *Message {
+	for i := range r.messages {
+		if r.messages[i].ID.Hex() == ID {
+			return &r.messages[i]
+		}
+	}
+	return nil
+}
+
+type testEvent struct {
+	Number int `json:"number"`
+}
+
+func (testEvent) EventType() string { return "test.happened" }
+
+// recordingSink 는 전달받은 메시지 아이디를 기록, fail 이 남아있으면 실패
+type recordingSink struct {
+	mu       sync.Mutex
+	name     string
+	fail     int
+	received []string
+}
+
+func (s *recordingSink) Name() string { return s.name }
+
+func (s *recordingSink) Publish(ctx context.Context, message *Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fail > 0 {
+		s.fail--
+		return errors.New("unavailable")
+	}
+	s.received = append(s.received, message.ID.Hex())
+	return nil
+}
+
+func (s *recordingSink) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.received)
+}
+
+// fixedClock 은 테스트에서 Relay 의 현재 시각을 정함
+type fixedClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fixedClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fixedClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestRelay(repo Repository, clock *fixedClock, sinks ...Sink) *Relay {
+	relay := NewRelay(repo, sinks, Options{Interval: time.Second, BatchSize: 10, Lease: time.Minute, MaxBackoff: 8 * time.Second})
+	relay.now = clock.Now
+	return relay
+}
+
+func TestRelayPublishesToAllSinks(t *testing.T) {
+	clock := &fixedClock{now: time.Now()}
+	repo := &memoryRepository{}
+	repo.add(t, 3, clock.Now())
+	first, second := &recordingSink{name: "first"}, &recordingSink{name: "second"}
+
+	relay := newTestRelay(repo, clock, first, second)
+	published, err := relay.Flush(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if published != 3 || first.count() != 3 || second.count() != 3 {
+		t.Fatalf("published %d, sinks received %d and %d, want 3", published, first.count(), second.count())
+	}
+
+	// 이미 전달한 메시지는 다시 전달하지 않음
+	clock.Advance(time.Hour)
+	if published, _ := relay.Flush(context.Background()); published != 0 || first.count() != 3 {
+		t.Fatalf("published %d again", published)
+	}
+}
+
+func TestRelayRetriesWithBackoff(t *testing.T) {
+	clock := &fixedClock{now: time.Now()}
+	repo := &memoryRepository{}
+	repo.add(t, 1, clock.Now())
+	healthy, flaky := &recordingSink{name: "healthy"}, &recordingSink{name: "flaky", fail: 2}
+
+	relay := newTestRelay(repo, clock, healthy, flaky)
+	ctx := context.Background()
+
+	for i, wait := range []time.Duration{time.Second, 2 * time.Second} {
+		if published, err := relay.Flush(ctx); err != nil || published != 0 {
+			t.Fatalf("attempt %d: published %d, err %v", i+1, published, err)
+		}
+		message := repo.messages[0]
+		if message.Attempts != i+1 || message.LastError != "flaky: unavailable" || !message.NextAttemptAt.Equal(clock.Now().Add(wait)) {
+			t.Fatalf("attempt %d: unexpected message %+v", i+1, message)
+		}
+
+		// 다음 시도 시각 전에는 전달하지 않음
+		clock.Advance(wait - time.Millisecond)
+		if published, _ := relay.Flush(ctx); published != 0 || repo.messages[0].Attempts != i+1 {
+			t.Fatalf("attempt %d: retried before backoff", i+1)
+		}
+		clock.Advance(time.Millisecond)
+	}
+
+	if published, err := relay.Flush(ctx); err != nil || published != 1 {
+		t.Fatalf("published %d, err %v", published, err)
+	}
+	// 실패한 Sink 가 있으면 모든 Sink 에 다시 전달 (최소 한번)
+	if healthy.count() != 3 || flaky.count() != 1 {
+		t.Fatalf("healthy received %d, flaky received %d", healthy.count(), flaky.count())
+	}
+}
+
+func TestRelayBackoffIsCapped(t *testing.T) {
+	relay := NewRelay(&memoryRepository{}, nil, Options{Interval: time.Second, MaxBackoff: 8 * time.Second})
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 8 * time.Second}
+	for i, expected := range want {
+		if got := relay.backoff(i + 1); got != expected {
+			t.Errorf("attempt %d: got %s, want %s", i+1, got, expected)
+		}
+	}
+	if got := relay.backoff(100); got != 8*time.Second {
+		t.Errorf("attempt 100: got %s", got)
+	}
+}
+
+func TestConcurrentRelaysPublishOnce(t *testing.T) {
+	clock := &fixedClock{now: time.Now()}
+	repo := &memoryRepository{}
+	repo.add(t, 50, clock.Now())
+	sink := &recordingSink{name: "sink"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			relay := newTestRelay(repo, clock, sink)
+			relay.opts.BatchSize = 50
+			if _, err := relay.Flush(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if sink.count() != 50 {
+		t.Fatalf("sink received %d messages, want 50", sink.count())
+	}
+	seen := map[string]bool{}
+	for _, ID := range sink.received {
+		if seen[ID] {
+			t.Fatalf("message %s published twice", ID)
+		}
+		seen[ID] = true
+	}
+}
+
+func TestRunStopsWhenCancelled(t *testing.T) {
+	repo := &memoryRepository{}
+	repo.add(t, 1, time.Now())
+	sink := &recordingSink{name: "sink"}
+	relay := NewRelay(repo, []Sink{sink}, Options{Interval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		relay.Run(ctx)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for sink.count() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not stop after cancel")
+	}
+	if sink.count() != 1 {
+		t.Fatalf("sink received %d messages, want 1", sink.count())
+	}
+}
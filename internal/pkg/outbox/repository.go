@@ -0,0 +1,20 @@
+package outbox
+
+import (
+	"context"
+	"time"
+)
+
+// Repository interface definition
+// 메시지 저장은 회원 저장소가 상태 변경과 같은 쓰기로 처리하므로 Relay 에 필요한 메소드만 포함
+type Repository interface {
+	// GET
+	GetPending(ctx context.Context, now time.Time, limit int64) ([]Message, error) // 전달 전이고 NextAttemptAt 이 now 이전인 메시지, 오래된 순
+
+	// UPDATE
+	// Claim 은 전달 시도 횟수가 attempts 그대로인 경우에만 횟수를 늘리고 NextAttemptAt 을 until 로 바꿈
+	// 다른 Relay 가 먼저 가져간 경우 false
+	Claim(ctx context.Context, ID string, attempts int, until time.Time) (bool, error)
+	MarkPublished(ctx context.Context, ID string, publishedAt time.Time) error
+	MarkFailed(ctx context.Context, ID string, nextAttemptAt time.Time, reason string) error
+}
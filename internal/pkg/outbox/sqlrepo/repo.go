@@ -0,0 +1,139 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"signupin-api/internal/pkg/outbox"
+	"signupin-api/internal/pkg/sqlstore"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	outboxTable = "outbox"
+
+	outboxColumns = "id, type, aggregate_id, payload, occurred_at, attempts, next_attempt_at, last_error, published_at, created_at, updated_at"
+)
+
+// outboxRepo 는 Postgres / SQLite 에 저장하는 outbox.Repository 구현
+// 메시지는 회원 저장소 (user/sqlrepo) 가 같은 DB 의 트랜잭션에서 Insert 로 저장
+type outboxRepo struct {
+	db *sqlstore.DB
+}
+
+var _ outbox.Repository = &outboxRepo{}
+
+func (r *outboxRepo) GetPending(ctx context.Context, now time.Time, limit int64) ([]outbox.Message, error) {
+	query := r.db.Rebind("SELECT " + outboxColumns + " FROM outbox WHERE published_at IS NULL AND next_attempt_at <= ? ORDER BY occurred_at LIMIT ?")
+	rows, err := r.db.QueryContext(ctx, query, now.UTC(), limit)
+	if err != nil {
+		return nil, sqlstore.MapError(err, outboxTable, nil, nil, nil)
+	}
+	defer rows.Close()
+
+	found := []outbox.Message{}
+	for rows.Next() {
+		message, err := scanMessage(rows)
+		if err != nil {
+			return nil, sqlstore.MapError(err, outboxTable, nil, nil, nil)
+		}
+		found = append(found, *message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqlstore.MapError(err, outboxTable, nil, nil, nil)
+	}
+
+	return found, nil
+}
+
+func (r *outboxRepo) Claim(ctx context.Context, ID string, attempts int, until time.Time) (bool, error) {
+	query := r.db.Rebind("UPDATE outbox SET attempts = attempts + 1, next_attempt_at = ?, updated_at = ? WHERE id = ? AND attempts = ? AND published_at IS NULL")
+	result, err := r.db.ExecContext(ctx, query, until.UTC(), sqlstore.Now(), ID, attempts)
+	if err != nil {
+		return false, sqlstore.MapError(err, outboxTable, bson.M{"_id": ID}, nil, nil)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, sqlstore.MapError(err, outboxTable, bson.M{"_id": ID}, nil, nil)
+	}
+	return count == 1, nil
+}
+
+func (r *outboxRepo) MarkPublished(ctx context.Context, ID string, publishedAt time.Time) error {
+	query := r.db.Rebind("UPDATE outbox SET published_at = ?, last_error = '', updated_at = ? WHERE id = ?")
+	result, err := r.db.ExecContext(ctx, query, publishedAt.UTC(), sqlstore.Now(), ID)
+	return affected(result, err, ID)
+}
+
+func (r *outboxRepo) MarkFailed(ctx context.Context, ID string, nextAttemptAt time.Time, reason string) error {
+	query := r.db.Rebind("UPDATE outbox SET next_attempt_at = ?, last_error = ?, updated_at = ? WHERE id = ?")
+	result, err := r.db.ExecContext(ctx, query, nextAttemptAt.UTC(), reason, sqlstore.Now(), ID)
+	return affected(result, err, ID)
+}
+
+// Insert 는 메시지 저장, 회원 저장소가 상태 변경과 같은 트랜잭션 (tx) 에서 호출
+// 오류는 트랜잭션을 실행한 저장소가 변환하므로 그대로 반환
+func Insert(ctx context.Context, db *sqlstore.DB, tx *sql.Tx, messages []*outbox.Message) error {
+	query := db.Rebind("INSERT INTO outbox (" + outboxColumns + ") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	for _, message := range messages {
+		now := sqlstore.Now()
+		message.CreatedAt = now
+		message.UpdatedAt = now
+
+		_, err := tx.ExecContext(ctx, query,
+			message.ID.Hex(), message.Type, message.AggregateID, message.Payload, message.OccurredAt,
+			message.Attempts, message.NextAttemptAt, message.LastError, message.PublishedAt, message.CreatedAt, message.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanMessage(rows *sql.Rows) (*outbox.Message, error) {
+	found := &outbox.Message{}
+	var id string
+	var publishedAt sql.NullTime
+
+	err := rows.Scan(&id, &found.Type, &found.AggregateID, &found.Payload, &found.OccurredAt,
+		&found.Attempts, &found.NextAttemptAt, &found.LastError, &publishedAt, &found.CreatedAt, &found.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	found.ID, err = primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	if publishedAt.Valid {
+		found.PublishedAt = &publishedAt.Time
+	}
+	return found, nil
+}
+
+// affected 는 UPDATE 결과 변경된 메시지가 없으면 not found 오류 반환
+func affected(result sql.Result, err error, ID string) error {
+	filter := bson.M{"_id": ID}
+	if err != nil {
+		return sqlstore.MapError(err, outboxTable, filter, nil, nil)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return sqlstore.MapError(err, outboxTable, filter, nil, nil)
+	}
+	if count == 0 {
+		return sqlstore.MapError(sql.ErrNoRows, outboxTable, filter, nil, nil)
+	}
+	return nil
+}
+
+func New(db *sqlstore.DB) outbox.Repository {
+	return &outboxRepo{db}
+}
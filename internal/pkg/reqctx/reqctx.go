@@ -0,0 +1,48 @@
+package reqctx
+
+import (
+	"context"
+	"strings"
+)
+
+type key int
+
+const (
+	requestIDKey key = iota
+	userIDKey
+)
+
+// WithRequestID 는 요청 아이디 (X-Request-ID) 를 담은 context 반환
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID 는 context 에 담긴 요청 아이디 반환, 없으면 빈 문자열
+func RequestID(ctx context.Context) string {
+	value, _ := ctx.Value(requestIDKey).(string)
+	return value
+}
+
+// WithUserID 는 인증된 회원 아이디를 담은 context 반환
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// UserID 는 context 에 담긴 회원 아이디 반환, 없으면 빈 문자열
+func UserID(ctx context.Context) string {
+	value, _ := ctx.Value(userIDKey).(string)
+	return value
+}
+
+// Comment 는 DB 쿼리에 남길 요청 정보 (ex. request_id=...,user_id=...)
+// MongoDB profiler / slow query 로그에서 API 요청과 쿼리를 연결할 때 사용
+func Comment(ctx context.Context) string {
+	var fields []string
+	if requestID := RequestID(ctx); len(requestID) > 0 {
+		fields = append(fields, "request_id="+requestID)
+	}
+	if userID := UserID(ctx); len(userID) > 0 {
+		fields = append(fields, "user_id="+userID)
+	}
+	return strings.Join(fields, ",")
+}
@@ -0,0 +1,245 @@
+package risk
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // RISK_TIMEZONE 을 OS 의 timezone 정보 없이도 읽기 위함
+)
+
+// Rule 은 요청 정보를 보고 위험 점수와 규칙 이름을 반환, 해당 없으면 0
+type Rule func(s *Signal, country string) (int, string)
+
+// Engine 은 규칙별 위험 점수를 합산하여 처리 방식을 결정
+type Engine struct {
+	rules       []Rule
+	geo         *GeoDB
+	stepUpScore int // 이 점수 이상이면 추가 인증
+	denyScore   int // 이 점수 이상이면 거부
+}
+
+// Evaluate 는 위험 점수를 합산한 평가 결과를 반환
+// 추가 인증 대상이어도 이미 추가 인증을 통과했다면 허용
+func (e *Engine) Evaluate(s *Signal) *Assessment {
+	country := e.geo.Country(s.IP)
+
+	result := &Assessment{
+		Action:         s.Action,
+		Identifier:     s.Identifier,
+		UserID:         s.UserID,
+		IP:             s.IP,
+		Country:        country,
+		Reasons:        []string{},
+		StepUpVerified: s.StepUpVerified,
+	}
+	for _, rule := range e.rules {
+		if score, reason := rule(s, country); score > 0 {
+			result.Score += score
+			result.Reasons = append(result.Reasons, reason)
+		}
+	}
+
+	switch {
+	case result.Score >= e.denyScore:
+		result.Decision = DecisionDeny
+	case result.Score >= e.stepUpScore && !s.StepUpVerified:
+		result.Decision = DecisionStepUp
+	default:
+		result.Decision = DecisionAllow
+	}
+
+	return result
+}
+
+// NewEngine returns new Engine with given rules
+func NewEngine(geo *GeoDB, stepUpScore, denyScore int, rules ...Rule) *Engine {
+	return &Engine{rules: rules, geo: geo, stepUpScore: stepUpScore, denyScore: denyScore}
+}
+
+// Settings 는 LoadEngine 으로 구성할 규칙 설정 (RISK_*)
+type Settings struct {
+	Blocklist        []string // 차단할 IP 혹은 CIDR
+	BlocklistFile    string   // 차단 목록 파일 (한 줄에 하나, # 은 주석)
+	GeoDB            string   // 한 줄에 "CIDR,국가코드" 형식의 파일
+	AllowedCountries []string // 허용 국가 (ex. KR, JP), 비어있으면 국가 규칙 없음
+	UnusualHours     string   // 평소와 다른 로그인 시간대 (ex. 1-6), 비어있으면 시간대 규칙 없음
+	Timezone         string   // UnusualHours 의 기준 시간대 (ex. Asia/Seoul)
+	StepUpScore      int      // 이 점수 이상이면 추가 인증
+	DenyScore        int      // 이 점수 이상이면 거부
+}
+
+// LoadEngine 은 settings 로 규칙을 구성
+func LoadEngine(settings Settings) (*Engine, error) {
+	blocklist, err := loadBlocklist(settings.Blocklist, settings.BlocklistFile)
+	if err != nil {
+		return nil, err
+	}
+
+	geo, err := LoadGeoDB(settings.GeoDB)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := parseHours(settings.UnusualHours)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := time.LoadLocation(settings.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	var allowed []string
+	for _, country := range settings.AllowedCountries {
+		if country = strings.ToUpper(strings.TrimSpace(country)); len(country) > 0 {
+			allowed = append(allowed, country)
+		}
+	}
+
+	return NewEngine(geo, settings.StepUpScore, settings.DenyScore,
+		FailedAttempts(3, 10),
+		NewDevice(),
+		UnusualHour(from, to, location),
+		Blocklist(blocklist),
+		Geo(allowed),
+	), nil
+}
+
+// FailedAttempts 는 최근 실패 횟수가 많을수록 높은 점수를 부여
+// identifier 기준 실패는 누구나 늘릴 수 있으므로 (회원 잠금 공격) 추가 인증까지만, 같은 IP 의 실패가 deny 이상이면 거부
+func FailedAttempts(stepUp, deny int64) Rule {
+	return func(s *Signal, _ string) (int, string) {
+		switch {
+		case s.IPFailures >= deny:
+			return 80, "ip_failed_attempts"
+		case s.IPFailures >= stepUp || s.IdentifierFailures >= stepUp:
+			return 40, "failed_attempts"
+		}
+		return 0, ""
+	}
+}
+
+// NewDevice 는 처음 보는 기기에 점수를 부여
+func NewDevice() Rule {
+	return func(s *Signal, _ string) (int, string) {
+		if s.NewDevice {
+			return 30, "new_device"
+		}
+		return 0, ""
+	}
+}
+
+// UnusualHour 는 [from, to) 시간대 (location 기준) 의 요청에 점수를 부여
+func UnusualHour(from, to int, location *time.Location) Rule {
+	return func(s *Signal, _ string) (int, string) {
+		hour := s.Time.In(location).Hour()
+		unusual := hour >= from && hour < to
+		if from > to { // ex. 22-5
+			unusual = hour >= from || hour < to
+		}
+		if unusual {
+			return 20, "unusual_hour"
+		}
+		return 0, ""
+	}
+}
+
+// Blocklist 는 차단된 IP 대역의 요청을 거부
+func Blocklist(networks []*net.IPNet) Rule {
+	return func(s *Signal, _ string) (int, string) {
+		ip := net.ParseIP(s.IP)
+		if ip == nil {
+			return 0, ""
+		}
+		for _, network := range networks {
+			if network.Contains(ip) {
+				return 100, "ip_blocklist"
+			}
+		}
+		return 0, ""
+	}
+}
+
+// Geo 는 허용 국가 목록 밖의 요청에 점수를 부여, 허용 국가 목록이 없거나 국가를 모르면 해당 없음
+func Geo(allowed []string) Rule {
+	return func(_ *Signal, country string) (int, string) {
+		if len(allowed) == 0 || len(country) == 0 {
+			return 0, ""
+		}
+		for _, a := range allowed {
+			if a == country {
+				return 0, ""
+			}
+		}
+		return 40, "foreign_country"
+	}
+}
+
+func loadBlocklist(list []string, path string) ([]*net.IPNet, error) {
+	entries := append([]string{}, list...)
+
+	if len(path) > 0 {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			entries = append(entries, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	networks := []*net.IPNet{}
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			if strings.Contains(entry, ":") {
+				entry += "/128"
+			} else {
+				entry += "/32"
+			}
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
+
+func parseHours(value string) (int, int, error) {
+	if len(strings.TrimSpace(value)) == 0 || value == "-" {
+		return 0, 0, nil
+	}
+
+	parts := strings.Split(value, "-")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("RISK_UNUSUAL_HOURS must be <from>-<to>: %q", value)
+	}
+
+	from, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, err
+	}
+	to, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return 0, 0, err
+	}
+	return from, to, nil
+}
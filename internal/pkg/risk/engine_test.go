@@ -0,0 +1,32 @@
+package risk
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFailedAttempts(t *testing.T) {
+	engine := NewEngine(&GeoDB{}, 40, 80, FailedAttempts(3, 10))
+
+	tests := []struct {
+		name         string
+		byIdentifier int64
+		byIP         int64
+		want         Decision
+	}{
+		{"few failures", 2, 2, DecisionAllow},
+		{"identifier velocity", 3, 0, DecisionStepUp},
+		// 다른 IP 들에서 회원 한 명을 계속 실패시켜도 거부 (잠금) 되지 않음
+		{"identifier velocity over deny threshold", 50, 1, DecisionStepUp},
+		{"ip velocity", 0, 3, DecisionStepUp},
+		{"ip velocity over deny threshold", 0, 10, DecisionDeny},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := engine.Evaluate(&Signal{IdentifierFailures: tt.byIdentifier, IPFailures: tt.byIP, Time: time.Now()})
+			if got.Decision != tt.want {
+				t.Fatalf("got %s (score %d, %v), want %s", got.Decision, got.Score, got.Reasons, tt.want)
+			}
+		})
+	}
+}
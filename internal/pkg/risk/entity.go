@@ -0,0 +1,111 @@
+package risk
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/kamva/mgm/v3"
+)
+
+const (
+	failureWindow      = 15 * time.Minute  // 실패 횟수를 집계하는 기간
+	challengeTTL       = 5 * time.Minute   // 추가 인증 코드 유효 시간
+	challengeAttempts  = 5                 // 추가 인증 코드 입력 허용 횟수
+	MethodSMS          = "sms"             // 추가 인증 수단: 전화번호로 보낸 6자리 코드
+	ActionSignIn       = "sign_in"         // 평가 대상: 회원 로그인
+	ActionPasswordEdit = "password_change" // 평가 대상: 비밀번호 수정
+)
+
+// Decision 은 위험도 평가 결과에 따른 처리 방식
+type Decision string
+
+const (
+	DecisionAllow  Decision = "allow"   // 허용
+	DecisionStepUp Decision = "step_up" // 추가 인증 후 허용
+	DecisionDeny   Decision = "deny"    // 거부
+)
+
+// Signal 은 위험도 평가에 사용하는 요청 정보
+type Signal struct {
+	Action         string    // ActionSignIn, ActionPasswordEdit
+	Identifier     string    // 이메일 혹은 전화번호
+	UserID         string    // 회원 아이디
+	IP             string    // 접속 IP
+	NewDevice      bool      // 처음 보는 기기 여부
+	Time           time.Time // 요청 시각
+	StepUpVerified bool      // 추가 인증 통과 여부
+
+	IdentifierFailures int64 // 최근 같은 identifier 로 실패한 횟수
+	IPFailures         int64 // 최근 같은 IP 에서 실패한 횟수
+}
+
+// Attempt 는 실패한 인증 시도 기록
+type Attempt struct {
+	mgm.DefaultModel `bson:",inline"`
+	Action           string `json:"action" bson:"action"`         // 평가 대상
+	Identifier       string `json:"identifier" bson:"identifier"` // 이메일 혹은 전화번호
+	IP               string `json:"ip" bson:"ip"`                 // 접속 IP
+}
+
+// Assessment 는 위험도 평가 결과, 이후 검토를 위해 모두 기록
+type Assessment struct {
+	mgm.DefaultModel `bson:",inline"`
+	Action           string   `json:"action" bson:"action"`                     // 평가 대상
+	Identifier       string   `json:"identifier" bson:"identifier"`             // 이메일 혹은 전화번호
+	UserID           string   `json:"user_id" bson:"user_id"`                   // 회원 아이디
+	IP               string   `json:"ip" bson:"ip"`                             // 접속 IP
+	Country          string   `json:"country" bson:"country"`                   // IP 국가 코드 (geo DB)
+	Score            int      `json:"score" bson:"score"`                       // 위험 점수
+	Reasons          []string `json:"reasons" bson:"reasons"`                   // 점수가 부여된 규칙
+	StepUpVerified   bool     `json:"step_up_verified" bson:"step_up_verified"` // 추가 인증 통과 여부
+	Decision         Decision `json:"decision" bson:"decision"`                 // 처리 방식
+}
+
+// Challenge 는 추가 인증 요청 (SMS 코드)
+type Challenge struct {
+	mgm.DefaultModel `bson:",inline"`
+	UserID           string    `json:"user_id" bson:"user_id"`       // 회원 아이디
+	Action           string    `json:"action" bson:"action"`         // 평가 대상
+	Method           string    `json:"method" bson:"method"`         // 추가 인증 수단
+	CodeHash         string    `json:"-" bson:"code_hash"`           // 인증 코드 (sha256)
+	Attempts         int       `json:"attempts" bson:"attempts"`     // 입력 실패 횟수
+	ExpiresAt        time.Time `json:"expires_at" bson:"expires_at"` // 만료 시각
+}
+
+func newAttempt(action, identifier, ip string) *Attempt {
+	return &Attempt{Action: action, Identifier: identifier, IP: ip}
+}
+
+func newChallenge(userID, action string) (*Challenge, string) {
+	code := randomCode()
+
+	return &Challenge{
+		UserID:    userID,
+		Action:    action,
+		Method:    MethodSMS,
+		CodeHash:  hashCode(code),
+		ExpiresAt: time.Now().Add(challengeTTL),
+	}, code
+}
+
+func (c *Challenge) verify(userID, action, code string, now time.Time) bool {
+	return c.UserID == userID && c.Action == action && now.Before(c.ExpiresAt) &&
+		c.Attempts < challengeAttempts && c.CodeHash == hashCode(code)
+}
+
+func hashCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomCode() string {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf("%06d", n.Int64())
+}
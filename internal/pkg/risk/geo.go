@@ -0,0 +1,74 @@
+package risk
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"strings"
+)
+
+// GeoDB 는 로컬 파일에서 읽은 IP 대역별 국가 코드
+// 파일 형식: 한 줄에 "CIDR,국가코드" (ex. 1.208.0.0/12,KR), # 으로 시작하는 줄은 무시
+type GeoDB struct {
+	networks []geoNetwork
+}
+
+type geoNetwork struct {
+	network *net.IPNet
+	country string
+}
+
+// LoadGeoDB 는 path 의 geo DB 파일을 읽음, path 가 비어있으면 빈 DB 반환
+func LoadGeoDB(path string) (*GeoDB, error) {
+	db := &GeoDB{}
+	if len(path) == 0 {
+		return db, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, ",")
+		if len(fields) < 2 {
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(strings.TrimSpace(fields[0]))
+		if err != nil {
+			return nil, err
+		}
+		db.networks = append(db.networks, geoNetwork{network, strings.ToUpper(strings.TrimSpace(fields[1]))})
+	}
+
+	return db, scanner.Err()
+}
+
+// Country 는 IP 의 국가 코드를 반환, 찾지 못하면 빈 문자열
+func (db *GeoDB) Country(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ""
+	}
+
+	for _, n := range db.networks {
+		if n.network.Contains(parsed) {
+			return n.country
+		}
+	}
+	return ""
+}
+
+// Loaded 는 geo DB 에 IP 대역이 하나 이상 있는지 확인
+func (db *GeoDB) Loaded() bool {
+	return len(db.networks) > 0
+}
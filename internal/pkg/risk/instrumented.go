@@ -0,0 +1,55 @@
+package risk
+
+import (
+	"context"
+	"time"
+
+	"signupin-api/internal/pkg/metrics"
+)
+
+// instrumentedRepository 는 저장소 호출 시간과 실패를 지표로 기록
+type instrumentedRepository struct {
+	next Repository
+}
+
+func (r *instrumentedRepository) SaveAttempt(ctx context.Context, model *Attempt) (err error) {
+	defer metrics.ObserveRepository("risk", "SaveAttempt", time.Now(), &err)
+	return r.next.SaveAttempt(ctx, model)
+}
+
+func (r *instrumentedRepository) SaveAssessment(ctx context.Context, model *Assessment) (err error) {
+	defer metrics.ObserveRepository("risk", "SaveAssessment", time.Now(), &err)
+	return r.next.SaveAssessment(ctx, model)
+}
+
+func (r *instrumentedRepository) SaveChallenge(ctx context.Context, model *Challenge) (_ string, err error) {
+	defer metrics.ObserveRepository("risk", "SaveChallenge", time.Now(), &err)
+	return r.next.SaveChallenge(ctx, model)
+}
+
+func (r *instrumentedRepository) CountFailures(ctx context.Context, identifier, ip string, since time.Time) (_, _ int64, err error) {
+	defer metrics.ObserveRepository("risk", "CountFailures", time.Now(), &err)
+	return r.next.CountFailures(ctx, identifier, ip, since)
+}
+
+func (r *instrumentedRepository) GetChallenge(ctx context.Context, ID string) (_ *Challenge, err error) {
+	defer metrics.ObserveRepository("risk", "GetChallenge", time.Now(), &err)
+	return r.next.GetChallenge(ctx, ID)
+}
+
+func (r *instrumentedRepository) IncreaseChallengeAttempts(ctx context.Context, ID string) (err error) {
+	defer metrics.ObserveRepository("risk", "IncreaseChallengeAttempts", time.Now(), &err)
+	return r.next.IncreaseChallengeAttempts(ctx, ID)
+}
+
+func (r *instrumentedRepository) DeleteChallenge(ctx context.Context, ID string) (err error) {
+	defer metrics.ObserveRepository("risk", "DeleteChallenge", time.Now(), &err)
+	return r.next.DeleteChallenge(ctx, ID)
+}
+
+// Instrument 는 호출 시간과 실패를 지표 (/metrics) 로 기록하는 저장소 반환
+func Instrument(repo Repository) Repository {
+	return &instrumentedRepository{next: repo}
+}
+
+var _ Repository = &instrumentedRepository{}
@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"signupin-api/internal/pkg/risk"
+
+	"github.com/kamva/mgm/v3"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// riskRepo 는 DB 없이 실행하기 위한 risk.Repository 구현 (테스트, 로컬 개발용)
+// persistence.riskRepo 와 동일하게 조회 결과가 없으면 errortype.IsNotFoundErr 로 확인 가능한 오류 반환
+type riskRepo struct {
+	mu          sync.Mutex
+	attempts    []risk.Attempt
+	assessments []risk.Assessment
+	challenges  []risk.Challenge
+}
+
+var _ risk.Repository = &riskRepo{}
+
+func (r *riskRepo) SaveAttempt(ctx context.Context, model *risk.Attempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prepare(&model.DefaultModel)
+	r.attempts = append(r.attempts, *model)
+	return nil
+}
+
+func (r *riskRepo) SaveAssessment(ctx context.Context, model *risk.Assessment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prepare(&model.DefaultModel)
+	r.assessments = append(r.assessments, *model)
+	return nil
+}
+
+func (r *riskRepo) SaveChallenge(ctx context.Context, model *risk.Challenge) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prepare(&model.DefaultModel)
+	r.challenges = append(r.challenges, *model)
+	return utils.MapToStringID(model.ID), nil
+}
+
+func (r *riskRepo) CountFailures(ctx context.Context, identifier, ip string, since time.Time) (int64, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var byIdentifier, byIP int64
+	for _, attempt := range r.attempts {
+		if attempt.CreatedAt.Before(since) {
+			continue
+		}
+		if attempt.Identifier == identifier {
+			byIdentifier++
+		}
+		if attempt.IP == ip {
+			byIP++
+		}
+	}
+	return byIdentifier, byIP, nil
+}
+
+func (r *riskRepo) GetChallenge(ctx context.Context, ID string) (*risk.Challenge, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.challenges {
+		if r.challenges[i].ID.Hex() == ID {
+			found := r.challenges[i]
+			return &found, nil
+		}
+	}
+	return nil, notFound(&risk.Challenge{})
+}
+
+func (r *riskRepo) IncreaseChallengeAttempts(ctx context.Context, ID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.challenges {
+		if r.challenges[i].ID.Hex() == ID {
+			r.challenges[i].Attempts++
+			return nil
+		}
+	}
+	return notFound(&risk.Challenge{})
+}
+
+func (r *riskRepo) DeleteChallenge(ctx context.Context, ID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.challenges {
+		if r.challenges[i].ID.Hex() == ID {
+			r.challenges = append(r.challenges[:i], r.challenges[i+1:]...)
+			return nil
+		}
+	}
+	return notFound(&risk.Challenge{})
+}
+
+func prepare(model *mgm.DefaultModel) {
+	if model.ID.IsZero() {
+		model.ID = primitive.NewObjectID()
+	}
+	now := time.Now().UTC()
+	model.CreatedAt = now
+	model.UpdatedAt = now
+}
+
+func notFound(model mgm.Model) error {
+	return errortype.NotFoundError(mgm.CollName(model), nil, nil, nil)
+}
+
+func New() risk.Repository {
+	return &riskRepo{}
+}
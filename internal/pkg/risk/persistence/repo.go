@@ -0,0 +1,128 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"signupin-api/internal/pkg/risk"
+
+	"github.com/kamva/mgm/v3"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type riskRepo struct {
+	client *mongo.Client
+}
+
+var _ risk.Repository = &riskRepo{}
+
+func (r *riskRepo) SaveAttempt(ctx context.Context, model *risk.Attempt) error {
+	coll := mgm.Coll(model)
+	err := coll.CreateWithCtx(ctx, model)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), nil, nil, nil)
+	}
+
+	return nil
+}
+
+func (r *riskRepo) SaveAssessment(ctx context.Context, model *risk.Assessment) error {
+	coll := mgm.Coll(model)
+	err := coll.CreateWithCtx(ctx, model)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), nil, nil, nil)
+	}
+
+	return nil
+}
+
+func (r *riskRepo) SaveChallenge(ctx context.Context, model *risk.Challenge) (string, error) {
+	coll := mgm.Coll(model)
+	err := coll.CreateWithCtx(ctx, model)
+	if err != nil {
+		return "", errortype.ParseAndReturnDBError(err, coll.Name(), nil, nil, nil)
+	}
+
+	insertedID := utils.MapToStringID(model.ID)
+	return insertedID, nil
+}
+
+// CountFailures 는 since 이후 identifier 로 실패한 횟수와 IP 로 실패한 횟수를 각각 집계
+func (r *riskRepo) CountFailures(ctx context.Context, identifier, ip string, since time.Time) (int64, int64, error) {
+	coll := mgm.Coll(&risk.Attempt{})
+
+	var counts [2]int64
+	for i, filter := range []bson.M{
+		{"created_at": bson.M{"$gte": since}, "identifier": identifier},
+		{"created_at": bson.M{"$gte": since}, "ip": ip},
+	} {
+		count, err := coll.CountDocuments(ctx, filter)
+		if err != nil {
+			return 0, 0, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+		}
+		counts[i] = count
+	}
+
+	return counts[0], counts[1], nil
+}
+
+func (r *riskRepo) GetChallenge(ctx context.Context, ID string) (*risk.Challenge, error) {
+	objectID, err := utils.MapToObjectID(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	found := &risk.Challenge{}
+	filter := bson.M{"_id": objectID}
+
+	coll := mgm.Coll(found)
+	err = coll.FindOne(ctx, filter).Decode(found)
+	if err != nil {
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return found, nil
+}
+
+func (r *riskRepo) IncreaseChallengeAttempts(ctx context.Context, ID string) error {
+	objectID, err := utils.MapToObjectID(ID)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": objectID}
+	update := bson.M{"$inc": bson.M{"attempts": 1}}
+
+	coll := mgm.Coll(&risk.Challenge{})
+	_, err = coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), filter, update, nil)
+	}
+
+	return nil
+}
+
+func (r *riskRepo) DeleteChallenge(ctx context.Context, ID string) error {
+	objectID, err := utils.MapToObjectID(ID)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": objectID}
+
+	coll := mgm.Coll(&risk.Challenge{})
+	_, err = coll.DeleteOne(ctx, filter)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return nil
+}
+
+func New(client *mongo.Client) risk.Repository {
+	return &riskRepo{client}
+}
@@ -0,0 +1,23 @@
+package risk
+
+import (
+	"context"
+	"time"
+)
+
+// Repository interface definition
+type Repository interface {
+	SaveAttempt(ctx context.Context, model *Attempt) error
+	SaveAssessment(ctx context.Context, model *Assessment) error
+	SaveChallenge(ctx context.Context, model *Challenge) (string, error)
+
+	// GET
+	CountFailures(ctx context.Context, identifier, ip string, since time.Time) (byIdentifier, byIP int64, err error)
+	GetChallenge(ctx context.Context, ID string) (*Challenge, error)
+
+	// UPDATE
+	IncreaseChallengeAttempts(ctx context.Context, ID string) error
+
+	// DELETE
+	DeleteChallenge(ctx context.Context, ID string) error
+}
@@ -0,0 +1,137 @@
+package risk
+
+import (
+	"context"
+	"time"
+
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/pkg/metrics"
+	"signupin-api/internal/pkg/notify"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/errorcode"
+	"github.com/kkodecaffeine/go-common/rest"
+	"golang.org/x/exp/slog"
+)
+
+// 위험도 평가 결과 응답 코드
+var (
+	CodeStepUpRequired = errorcode.CodeDescription{HttpStatusCode: 401, Code: "STEP_UP_REQUIRED", Message: "추가 인증이 필요합니다."}
+	CodeDenied         = errorcode.CodeDescription{HttpStatusCode: 403, Code: "RISK_DENIED", Message: "보안 정책에 의해 차단된 요청입니다."}
+)
+
+// UseCase interface definition
+type Usecase interface {
+	// 위험도를 평가하고 결과를 기록
+	Assess(ctx context.Context, signal *Signal) (*Assessment, *rest.CustomError)
+
+	// 실패한 인증 시도 기록 (실패 횟수 규칙에 사용)
+	RecordFailure(ctx context.Context, action, identifier, ip string) *rest.CustomError
+
+	// 추가 인증 코드를 회원 전화번호로 발송
+	StartChallenge(ctx context.Context, userID, action, phone string) (*dto.StepUpResponse, *rest.CustomError)
+
+	// 추가 인증 코드 확인
+	VerifyChallenge(ctx context.Context, ID, userID, action, code string) *rest.CustomError
+}
+
+type usecase struct {
+	repo     Repository
+	engine   *Engine
+	notifier notify.Notifier
+}
+
+func (u *usecase) Assess(ctx context.Context, signal *Signal) (*Assessment, *rest.CustomError) {
+	byIdentifier, byIP, err := u.repo.CountFailures(ctx, signal.Identifier, signal.IP, signal.Time.Add(-failureWindow))
+	if err != nil {
+		return nil, toCustomError(err)
+	}
+	signal.IdentifierFailures, signal.IPFailures = byIdentifier, byIP
+
+	result := u.engine.Evaluate(signal)
+	if err := u.repo.SaveAssessment(ctx, result); err != nil {
+		return nil, toCustomError(err)
+	}
+
+	if result.Decision != DecisionAllow {
+		slog.WarnCtx(ctx, "risk assessment",
+			"action", result.Action,
+			"decision", result.Decision,
+			"assessed_user_id", result.UserID,
+			"ip", result.IP,
+			"score", result.Score,
+			"reasons", result.Reasons,
+		)
+	}
+
+	return result, nil
+}
+
+func (u *usecase) RecordFailure(ctx context.Context, action, identifier, ip string) *rest.CustomError {
+	if err := u.repo.SaveAttempt(ctx, newAttempt(action, identifier, ip)); err != nil {
+		return toCustomError(err)
+	}
+	return nil
+}
+
+func (u *usecase) StartChallenge(ctx context.Context, userID, action, phone string) (*dto.StepUpResponse, *rest.CustomError) {
+	if len(phone) == 0 {
+		return nil, &rest.CustomError{CodeDesc: &errorcode.FORBIDDEN_REQUEST, Message: "no step-up method available"}
+	}
+
+	model, code := newChallenge(userID, action)
+	insertedID, err := u.repo.SaveChallenge(ctx, model)
+	if err != nil {
+		return nil, toCustomError(err)
+	}
+
+	u.notifier.Notify(&notify.Recipient{Phone: phone}, "", "[signupin] 추가 인증 코드: "+code)
+	metrics.CodeIssued(metrics.CodeStepUp)
+
+	return &dto.StepUpResponse{
+		ChallengeID: insertedID,
+		Methods:     []string{model.Method},
+		ExpiresAt:   model.ExpiresAt,
+	}, nil
+}
+
+// VerifyChallenge 는 코드가 맞으면 추가 인증 요청을 삭제하여 재사용을 막고, 틀리면 입력 실패 횟수 증가
+func (u *usecase) VerifyChallenge(ctx context.Context, ID, userID, action, code string) *rest.CustomError {
+	found, err := u.repo.GetChallenge(ctx, ID)
+	if err != nil {
+		if errortype.IsNotFoundErr(err) {
+			metrics.CodeFailed(metrics.CodeStepUp)
+			return &rest.CustomError{CodeDesc: &errorcode.INVALID_OTP, Message: "challenge not found"}
+		}
+		return toCustomError(err)
+	}
+
+	if !found.verify(userID, action, code, time.Now()) {
+		_ = u.repo.IncreaseChallengeAttempts(ctx, ID)
+		metrics.CodeFailed(metrics.CodeStepUp)
+		return &rest.CustomError{CodeDesc: &errorcode.INVALID_OTP, Message: "invalid or expired code"}
+	}
+
+	if err := u.repo.DeleteChallenge(ctx, ID); err != nil {
+		return toCustomError(err)
+	}
+	metrics.CodeVerified(metrics.CodeStepUp)
+	return nil
+}
+
+func toCustomError(err error) *rest.CustomError {
+	if errortype.IsDecodeError(err) {
+		return &rest.CustomError{CodeDesc: &errorcode.FAILED_DB_PROCESSING, Message: err.Error()}
+	} else if errortype.IsNotFoundErr(err) {
+		return &rest.CustomError{CodeDesc: &errorcode.NOT_FOUND_ERROR, Message: err.Error()}
+	} else {
+		return &rest.CustomError{CodeDesc: &errorcode.FAILED_INTERNAL_ERROR, Message: err.Error()}
+	}
+}
+
+// NewUsecase returns new Usecase implementation
+func NewUsecase(repo Repository, engine *Engine, notifier notify.Notifier) Usecase {
+	return &usecase{repo: repo, engine: engine, notifier: notifier}
+}
+
+var _ Usecase = &usecase{}
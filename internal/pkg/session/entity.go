@@ -0,0 +1,104 @@
+package session
+
+import (
+	"strings"
+	"time"
+
+	"github.com/kamva/mgm/v3"
+)
+
+const touchInterval = 10 * time.Second
+
+// Session 은 회원 로그인 1회에 해당하는 접속 정보
+type Session struct {
+	mgm.DefaultModel `bson:",inline"`
+	UserID           string     `json:"user_id" bson:"user_id"`           // 회원 아이디
+	UserAgent        string     `json:"user_agent" bson:"user_agent"`     // 접속 시 User-Agent
+	IP               string     `json:"ip" bson:"ip"`                     // 접속 IP
+	DeviceLabel      string     `json:"device_label" bson:"device_label"` // 기기 이름 (ex. Chrome on macOS)
+	Restricted       bool       `json:"restricted" bson:"restricted"`     // 비밀번호 재설정 전까지 비밀번호 수정 API 만 허용
+	LastSeenAt       time.Time  `json:"last_seen_at" bson:"last_seen_at"` // 마지막 사용 시각
+	ExpiresAt        time.Time  `json:"expires_at" bson:"expires_at"`     // 만료 시각
+	RevokedAt        *time.Time `json:"revoked_at" bson:"revoked_at"`     // 종료 시각
+}
+
+// Metadata 는 로그인 요청에서 추출한 접속 정보
+type Metadata struct {
+	UserAgent      string
+	AcceptLanguage string
+	IP             string
+	DeviceLabel    string // 클라이언트가 직접 전달한 기기 이름 (X-Device-Label), 비어있으면 User-Agent 로 추정
+
+	PasswordResetRequired bool   // 비밀번호 재설정이 필요한 회원의 로그인 여부
+	Locale                string // 회원이 설정한 응답 메시지 언어, 토큰에 담아 요청마다 사용
+}
+
+func newSession(userID string, meta *Metadata, ttl time.Duration) *Session {
+	now := time.Now()
+
+	label := meta.Label()
+
+	return &Session{
+		UserID:      userID,
+		UserAgent:   meta.UserAgent,
+		IP:          meta.IP,
+		DeviceLabel: label,
+		Restricted:  meta.PasswordResetRequired,
+		LastSeenAt:  now,
+		ExpiresAt:   now.Add(ttl),
+	}
+}
+
+func (s *Session) isActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+func (s *Session) needsTouch(now time.Time) bool {
+	return now.Sub(s.LastSeenAt) > touchInterval
+}
+
+// Label 은 클라이언트가 전달한 기기 이름, 없으면 User-Agent 로 추정한 기기 이름을 반환
+func (m *Metadata) Label() string {
+	if len(m.DeviceLabel) > 0 {
+		return m.DeviceLabel
+	}
+	return deviceLabel(m.UserAgent)
+}
+
+// deviceLabel 은 User-Agent 로 브라우저와 운영체제를 추정
+func deviceLabel(userAgent string) string {
+	browsers := []struct{ token, name string }{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"SamsungBrowser/", "Samsung Internet"},
+		{"Chrome/", "Chrome"},
+		{"Firefox/", "Firefox"},
+		{"Safari/", "Safari"},
+		{"curl/", "curl"},
+		{"okhttp/", "okhttp"},
+		{"PostmanRuntime/", "Postman"},
+	}
+	systems := []struct{ token, name string }{
+		{"Android", "Android"},
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Mac OS X", "macOS"},
+		{"Windows", "Windows"},
+		{"Linux", "Linux"},
+	}
+
+	browser := "Unknown browser"
+	for _, b := range browsers {
+		if strings.Contains(userAgent, b.token) {
+			browser = b.name
+			break
+		}
+	}
+
+	for _, s := range systems {
+		if strings.Contains(userAgent, s.token) {
+			return browser + " on " + s.name
+		}
+	}
+	return browser
+}
@@ -0,0 +1,50 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"signupin-api/internal/pkg/metrics"
+)
+
+// instrumentedRepository 는 저장소 호출 시간과 실패를 지표로 기록
+type instrumentedRepository struct {
+	next Repository
+}
+
+func (r *instrumentedRepository) SaveOne(ctx context.Context, model *Session) (_ string, err error) {
+	defer metrics.ObserveRepository("session", "SaveOne", time.Now(), &err)
+	return r.next.SaveOne(ctx, model)
+}
+
+func (r *instrumentedRepository) GetOne(ctx context.Context, ID string) (_ *Session, err error) {
+	defer metrics.ObserveRepository("session", "GetOne", time.Now(), &err)
+	return r.next.GetOne(ctx, ID)
+}
+
+func (r *instrumentedRepository) GetAll(ctx context.Context, userID string, now time.Time) (_ []Session, err error) {
+	defer metrics.ObserveRepository("session", "GetAll", time.Now(), &err)
+	return r.next.GetAll(ctx, userID, now)
+}
+
+func (r *instrumentedRepository) Revoke(ctx context.Context, userID, ID string, revokedAt time.Time) (err error) {
+	defer metrics.ObserveRepository("session", "Revoke", time.Now(), &err)
+	return r.next.Revoke(ctx, userID, ID, revokedAt)
+}
+
+func (r *instrumentedRepository) RevokeAll(ctx context.Context, userID string, revokedAt time.Time) (err error) {
+	defer metrics.ObserveRepository("session", "RevokeAll", time.Now(), &err)
+	return r.next.RevokeAll(ctx, userID, revokedAt)
+}
+
+func (r *instrumentedRepository) Touch(ctx context.Context, ID string, seenAt time.Time) (err error) {
+	defer metrics.ObserveRepository("session", "Touch", time.Now(), &err)
+	return r.next.Touch(ctx, ID, seenAt)
+}
+
+// Instrument 는 호출 시간과 실패를 지표 (/metrics) 로 기록하는 저장소 반환
+func Instrument(repo Repository) Repository {
+	return &instrumentedRepository{next: repo}
+}
+
+var _ Repository = &instrumentedRepository{}
@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"signupin-api/internal/pkg/session"
+
+	"github.com/kamva/mgm/v3"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// sessionRepo 는 DB 없이 실행하기 위한 session.Repository 구현 (테스트, 로컬 개발용)
+// persistence.sessionRepo 와 동일하게 조회 결과가 없으면 errortype.IsNotFoundErr 로 확인 가능한 오류 반환
+type sessionRepo struct {
+	mu       sync.Mutex
+	sessions []session.Session
+}
+
+var _ session.Repository = &sessionRepo{}
+
+func (r *sessionRepo) SaveOne(ctx context.Context, model *session.Session) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prepare(&model.DefaultModel)
+	r.sessions = append(r.sessions, *model)
+	return utils.MapToStringID(model.ID), nil
+}
+
+func (r *sessionRepo) GetOne(ctx context.Context, ID string) (*session.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if i := r.indexOf(ID); i >= 0 {
+		found := r.sessions[i]
+		return &found, nil
+	}
+	return nil, notFound(bson.M{"_id": ID})
+}
+
+// GetAll 은 종료되지 않았고 만료되지 않은 세션만 최근 사용 순으로 조회
+func (r *sessionRepo) GetAll(ctx context.Context, userID string, now time.Time) ([]session.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	found := []session.Session{}
+	for _, s := range r.sessions {
+		if s.UserID == userID && s.RevokedAt == nil && s.ExpiresAt.After(now) {
+			found = append(found, s)
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].LastSeenAt.After(found[j].LastSeenAt) })
+	return found, nil
+}
+
+func (r *sessionRepo) Revoke(ctx context.Context, userID, ID string, revokedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.indexOf(ID)
+	if i < 0 || r.sessions[i].UserID != userID || r.sessions[i].RevokedAt != nil {
+		return notFound(bson.M{"_id": ID, "user_id": userID, "revoked_at": nil})
+	}
+	r.sessions[i].RevokedAt = &revokedAt
+	return nil
+}
+
+func (r *sessionRepo) RevokeAll(ctx context.Context, userID string, revokedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.sessions {
+		if r.sessions[i].UserID == userID && r.sessions[i].RevokedAt == nil {
+			r.sessions[i].RevokedAt = &revokedAt
+		}
+	}
+	return nil
+}
+
+func (r *sessionRepo) Touch(ctx context.Context, ID string, seenAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if i := r.indexOf(ID); i >= 0 {
+		r.sessions[i].LastSeenAt = seenAt
+	}
+	return nil
+}
+
+func (r *sessionRepo) indexOf(ID string) int {
+	for i := range r.sessions {
+		if r.sessions[i].ID.Hex() == ID {
+			return i
+		}
+	}
+	return -1
+}
+
+func prepare(model *mgm.DefaultModel) {
+	if model.ID.IsZero() {
+		model.ID = primitive.NewObjectID()
+	}
+	now := time.Now().UTC()
+	model.CreatedAt = now
+	model.UpdatedAt = now
+}
+
+func notFound(filter bson.M) error {
+	return errortype.NotFoundError(mgm.CollName(&session.Session{}), filter, nil, nil)
+}
+
+func New() session.Repository {
+	return &sessionRepo{}
+}
@@ -0,0 +1,121 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"signupin-api/internal/pkg/session"
+
+	"github.com/kamva/mgm/v3"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type sessionRepo struct {
+	client *mongo.Client
+}
+
+var _ session.Repository = &sessionRepo{}
+
+func (r *sessionRepo) SaveOne(ctx context.Context, model *session.Session) (string, error) {
+	coll := mgm.Coll(model)
+	err := coll.CreateWithCtx(ctx, model)
+	if err != nil {
+		return "", errortype.ParseAndReturnDBError(err, coll.Name(), nil, nil, nil)
+	}
+
+	insertedID := utils.MapToStringID(model.ID)
+	return insertedID, nil
+}
+
+func (r *sessionRepo) GetOne(ctx context.Context, ID string) (*session.Session, error) {
+	objectID, err := utils.MapToObjectID(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	found := &session.Session{}
+	filter := bson.M{"_id": objectID}
+
+	coll := mgm.Coll(found)
+	err = coll.FindOne(ctx, filter).Decode(found)
+	if err != nil {
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return found, nil
+}
+
+// GetAll 은 종료되지 않았고 만료되지 않은 세션만 조회
+func (r *sessionRepo) GetAll(ctx context.Context, userID string, now time.Time) ([]session.Session, error) {
+	found := []session.Session{}
+	filter := bson.M{"user_id": userID, "revoked_at": nil, "expires_at": bson.M{"$gt": now}}
+
+	coll := mgm.Coll(&session.Session{})
+	err := coll.SimpleFindWithCtx(ctx, &found, filter, options.Find().SetSort(bson.M{"last_seen_at": -1}))
+	if err != nil {
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return found, nil
+}
+
+func (r *sessionRepo) Revoke(ctx context.Context, userID, ID string, revokedAt time.Time) error {
+	objectID, err := utils.MapToObjectID(ID)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": objectID, "user_id": userID, "revoked_at": nil}
+	update := bson.M{"$set": bson.M{"revoked_at": revokedAt}}
+
+	coll := mgm.Coll(&session.Session{})
+	result, err := coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), filter, update, nil)
+	}
+	if result.MatchedCount == 0 {
+		return errortype.NotFoundError(coll.Name(), filter, update, nil)
+	}
+
+	return nil
+}
+
+// RevokeAll 은 회원의 종료되지 않은 세션을 모두 종료 (종료할 세션이 없어도 성공)
+func (r *sessionRepo) RevokeAll(ctx context.Context, userID string, revokedAt time.Time) error {
+	filter := bson.M{"user_id": userID, "revoked_at": nil}
+	update := bson.M{"$set": bson.M{"revoked_at": revokedAt}}
+
+	coll := mgm.Coll(&session.Session{})
+	if _, err := coll.UpdateMany(ctx, filter, update); err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), filter, update, nil)
+	}
+
+	return nil
+}
+
+func (r *sessionRepo) Touch(ctx context.Context, ID string, seenAt time.Time) error {
+	objectID, err := utils.MapToObjectID(ID)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": objectID}
+	update := bson.M{"$set": bson.M{"last_seen_at": seenAt}}
+
+	coll := mgm.Coll(&session.Session{})
+	_, err = coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), filter, update, nil)
+	}
+
+	return nil
+}
+
+func New(client *mongo.Client) session.Repository {
+	return &sessionRepo{client}
+}
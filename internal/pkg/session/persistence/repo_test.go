@@ -0,0 +1,17 @@
+package persistence_test
+
+import (
+	"testing"
+
+	"signupin-api/internal/pkg/session"
+	"signupin-api/internal/pkg/session/persistence"
+	"signupin-api/internal/pkg/session/sessiontest"
+	"signupin-api/internal/pkg/storetest"
+)
+
+func TestMongoRepository(t *testing.T) {
+	sessiontest.RunRepositoryTests(t, func(t *testing.T) session.Repository {
+		storetest.Mongo(t)
+		return persistence.New(nil)
+	})
+}
@@ -0,0 +1,20 @@
+package session
+
+import (
+	"context"
+	"time"
+)
+
+// Repository interface definition
+type Repository interface {
+	SaveOne(ctx context.Context, model *Session) (string, error)
+
+	// GET
+	GetOne(ctx context.Context, ID string) (*Session, error)
+	GetAll(ctx context.Context, userID string, now time.Time) ([]Session, error)
+
+	// UPDATE
+	Revoke(ctx context.Context, userID, ID string, revokedAt time.Time) error
+	RevokeAll(ctx context.Context, userID string, revokedAt time.Time) error
+	Touch(ctx context.Context, ID string, seenAt time.Time) error
+}
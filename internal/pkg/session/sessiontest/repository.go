@@ -0,0 +1,171 @@
+// Package sessiontest 는 session.Repository 구현체가 공통으로 통과해야 하는 테스트
+package sessiontest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"signupin-api/internal/pkg/session"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NewRepository 는 테스트마다 비어있는 저장소 반환
+type NewRepository func(t *testing.T) session.Repository
+
+// RunRepositoryTests 는 저장소가 persistence.sessionRepo (MongoDB) 와 같은 동작을 하는지 확인
+func RunRepositoryTests(t *testing.T, newRepo NewRepository) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("SaveOne and GetOne", func(t *testing.T) {
+		repo := newRepo(t)
+		model := newSession("user-1", now, now.Add(time.Minute))
+		model.Restricted = true
+
+		insertedID := save(t, repo, model)
+
+		found, err := repo.GetOne(ctx, insertedID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found.ID.Hex() != insertedID || found.UserID != "user-1" || found.UserAgent != model.UserAgent ||
+			found.IP != model.IP || found.DeviceLabel != model.DeviceLabel || !found.Restricted || found.RevokedAt != nil {
+			t.Fatalf("unexpected session: %+v", found)
+		}
+		if !sameTime(found.ExpiresAt, model.ExpiresAt) {
+			t.Fatalf("expires_at: got %v, want %v", found.ExpiresAt, model.ExpiresAt)
+		}
+	})
+
+	t.Run("GetOne returns not found for unknown ID", func(t *testing.T) {
+		repo := newRepo(t)
+
+		if _, err := repo.GetOne(ctx, primitive.NewObjectID().Hex()); !errortype.IsNotFoundErr(err) {
+			t.Fatalf("got %v, want not found", err)
+		}
+	})
+
+	t.Run("GetAll returns active sessions of the user ordered by last use", func(t *testing.T) {
+		repo := newRepo(t)
+
+		older := save(t, repo, newSession("user-1", now.Add(-2*time.Second), now.Add(time.Minute)))
+		newer := save(t, repo, newSession("user-1", now.Add(-time.Second), now.Add(time.Minute)))
+		save(t, repo, newSession("user-1", now.Add(-time.Hour), now.Add(-time.Minute))) // 만료
+		save(t, repo, newSession("user-2", now, now.Add(time.Minute)))                  // 다른 회원
+		revoked := save(t, repo, newSession("user-1", now, now.Add(time.Minute)))
+		if err := repo.Revoke(ctx, "user-1", revoked, now); err != nil {
+			t.Fatal(err)
+		}
+
+		found, err := repo.GetAll(ctx, "user-1", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(found) != 2 || found[0].ID.Hex() != newer || found[1].ID.Hex() != older {
+			t.Fatalf("unexpected sessions: %+v", found)
+		}
+	})
+
+	t.Run("Revoke only ends an active session of the same user", func(t *testing.T) {
+		repo := newRepo(t)
+		insertedID := save(t, repo, newSession("user-1", now, now.Add(time.Minute)))
+
+		if err := repo.Revoke(ctx, "user-2", insertedID, now); !errortype.IsNotFoundErr(err) {
+			t.Fatalf("other user: got %v, want not found", err)
+		}
+		if err := repo.Revoke(ctx, "user-1", insertedID, now); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Revoke(ctx, "user-1", insertedID, now); !errortype.IsNotFoundErr(err) {
+			t.Fatalf("already revoked: got %v, want not found", err)
+		}
+
+		found, err := repo.GetOne(ctx, insertedID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found.RevokedAt == nil || !sameTime(*found.RevokedAt, now) {
+			t.Fatalf("revoked_at: got %v, want %v", found.RevokedAt, now)
+		}
+	})
+
+	t.Run("RevokeAll ends every active session of the user", func(t *testing.T) {
+		repo := newRepo(t)
+		first := save(t, repo, newSession("user-1", now, now.Add(time.Minute)))
+		second := save(t, repo, newSession("user-1", now, now.Add(time.Minute)))
+		other := save(t, repo, newSession("user-2", now, now.Add(time.Minute)))
+
+		if err := repo.RevokeAll(ctx, "user-1", now); err != nil {
+			t.Fatal(err)
+		}
+		// 종료할 세션이 없어도 성공
+		if err := repo.RevokeAll(ctx, "user-1", now); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, ID := range []string{first, second} {
+			found, err := repo.GetOne(ctx, ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if found.RevokedAt == nil || !sameTime(*found.RevokedAt, now) {
+				t.Fatalf("revoked_at: got %v, want %v", found.RevokedAt, now)
+			}
+		}
+
+		found, err := repo.GetOne(ctx, other)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found.RevokedAt != nil {
+			t.Fatalf("other user's session was revoked")
+		}
+	})
+
+	t.Run("Touch updates last use", func(t *testing.T) {
+		repo := newRepo(t)
+		insertedID := save(t, repo, newSession("user-1", now.Add(-time.Minute), now.Add(time.Minute)))
+
+		if err := repo.Touch(ctx, insertedID, now); err != nil {
+			t.Fatal(err)
+		}
+
+		found, err := repo.GetOne(ctx, insertedID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !sameTime(found.LastSeenAt, now) {
+			t.Fatalf("last_seen_at: got %v, want %v", found.LastSeenAt, now)
+		}
+	})
+}
+
+func newSession(userID string, lastSeenAt, expiresAt time.Time) *session.Session {
+	return &session.Session{
+		UserID:      userID,
+		UserAgent:   "Mozilla/5.0 (Macintosh)",
+		IP:          "127.0.0.1",
+		DeviceLabel: "Chrome on macOS",
+		LastSeenAt:  lastSeenAt,
+		ExpiresAt:   expiresAt,
+	}
+}
+
+func save(t *testing.T, repo session.Repository, model *session.Session) string {
+	t.Helper()
+
+	insertedID, err := repo.SaveOne(context.Background(), model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return insertedID
+}
+
+// sameTime 은 저장소의 시각 정밀도 (MongoDB: 밀리초) 차이를 무시하고 비교
+func sameTime(a, b time.Time) bool {
+	diff := a.Sub(b)
+	return diff > -time.Millisecond && diff < time.Millisecond
+}
@@ -0,0 +1,182 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"signupin-api/internal/pkg/session"
+	"signupin-api/internal/pkg/sqlstore"
+
+	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	sessionsTable = "sessions"
+
+	sessionColumns = "id, user_id, user_agent, ip, device_label, restricted, last_seen_at, expires_at, revoked_at, created_at, updated_at"
+)
+
+// sessionRepo 는 Postgres / SQLite 에 저장하는 session.Repository 구현
+type sessionRepo struct {
+	db *sqlstore.DB
+}
+
+var _ session.Repository = &sessionRepo{}
+
+func (r *sessionRepo) SaveOne(ctx context.Context, model *session.Session) (string, error) {
+	if model.ID.IsZero() {
+		model.ID = primitive.NewObjectID()
+	}
+	now := sqlstore.Now()
+	model.CreatedAt = now
+	model.UpdatedAt = now
+	model.LastSeenAt = normalize(model.LastSeenAt)
+	model.ExpiresAt = normalize(model.ExpiresAt)
+
+	query := r.db.Rebind("INSERT INTO sessions (" + sessionColumns + ") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	_, err := r.db.ExecContext(ctx, query,
+		model.ID.Hex(), model.UserID, model.UserAgent, model.IP, model.DeviceLabel, model.Restricted,
+		model.LastSeenAt, model.ExpiresAt, model.RevokedAt, model.CreatedAt, model.UpdatedAt,
+	)
+	if err != nil {
+		return "", sqlstore.MapError(err, sessionsTable, nil, nil, model)
+	}
+
+	insertedID := utils.MapToStringID(model.ID)
+	return insertedID, nil
+}
+
+func (r *sessionRepo) GetOne(ctx context.Context, ID string) (*session.Session, error) {
+	objectID, err := utils.MapToObjectID(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := r.db.Rebind("SELECT " + sessionColumns + " FROM sessions WHERE id = ?")
+	found, err := scanSession(r.db.QueryRowContext(ctx, query, objectID.Hex()))
+	if err != nil {
+		return nil, sqlstore.MapError(err, sessionsTable, bson.M{"_id": objectID}, nil, nil)
+	}
+
+	return found, nil
+}
+
+// GetAll 은 종료되지 않았고 만료되지 않은 세션만 조회
+func (r *sessionRepo) GetAll(ctx context.Context, userID string, now time.Time) ([]session.Session, error) {
+	filter := bson.M{"user_id": userID, "revoked_at": nil, "expires_at": bson.M{"$gt": now}}
+
+	query := r.db.Rebind("SELECT " + sessionColumns + " FROM sessions WHERE user_id = ? AND revoked_at IS NULL AND expires_at > ? ORDER BY last_seen_at DESC")
+	rows, err := r.db.QueryContext(ctx, query, userID, normalize(now))
+	if err != nil {
+		return nil, sqlstore.MapError(err, sessionsTable, filter, nil, nil)
+	}
+	defer rows.Close()
+
+	found := []session.Session{}
+	for rows.Next() {
+		s, err := scanSession(rows)
+		if err != nil {
+			return nil, sqlstore.MapError(err, sessionsTable, filter, nil, nil)
+		}
+		found = append(found, *s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqlstore.MapError(err, sessionsTable, filter, nil, nil)
+	}
+
+	return found, nil
+}
+
+func (r *sessionRepo) Revoke(ctx context.Context, userID, ID string, revokedAt time.Time) error {
+	objectID, err := utils.MapToObjectID(ID)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": objectID, "user_id": userID, "revoked_at": nil}
+	update := bson.M{"$set": bson.M{"revoked_at": revokedAt}}
+
+	query := r.db.Rebind("UPDATE sessions SET revoked_at = ?, updated_at = ? WHERE id = ? AND user_id = ? AND revoked_at IS NULL")
+	result, err := r.db.ExecContext(ctx, query, normalize(revokedAt), sqlstore.Now(), objectID.Hex(), userID)
+	if err != nil {
+		return sqlstore.MapError(err, sessionsTable, filter, update, nil)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return sqlstore.MapError(err, sessionsTable, filter, update, nil)
+	}
+	if count == 0 {
+		return sqlstore.MapError(sql.ErrNoRows, sessionsTable, filter, update, nil)
+	}
+
+	return nil
+}
+
+// RevokeAll 은 회원의 종료되지 않은 세션을 모두 종료 (종료할 세션이 없어도 성공)
+func (r *sessionRepo) RevokeAll(ctx context.Context, userID string, revokedAt time.Time) error {
+	filter := bson.M{"user_id": userID, "revoked_at": nil}
+	update := bson.M{"$set": bson.M{"revoked_at": revokedAt}}
+
+	query := r.db.Rebind("UPDATE sessions SET revoked_at = ?, updated_at = ? WHERE user_id = ? AND revoked_at IS NULL")
+	if _, err := r.db.ExecContext(ctx, query, normalize(revokedAt), sqlstore.Now(), userID); err != nil {
+		return sqlstore.MapError(err, sessionsTable, filter, update, nil)
+	}
+
+	return nil
+}
+
+func (r *sessionRepo) Touch(ctx context.Context, ID string, seenAt time.Time) error {
+	objectID, err := utils.MapToObjectID(ID)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": objectID}
+	update := bson.M{"$set": bson.M{"last_seen_at": seenAt}}
+
+	query := r.db.Rebind("UPDATE sessions SET last_seen_at = ?, updated_at = ? WHERE id = ?")
+	_, err = r.db.ExecContext(ctx, query, normalize(seenAt), sqlstore.Now(), objectID.Hex())
+	if err != nil {
+		return sqlstore.MapError(err, sessionsTable, filter, update, nil)
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSession(row scanner) (*session.Session, error) {
+	found := &session.Session{}
+	var id string
+	var revokedAt sql.NullTime
+
+	err := row.Scan(&id, &found.UserID, &found.UserAgent, &found.IP, &found.DeviceLabel, &found.Restricted,
+		&found.LastSeenAt, &found.ExpiresAt, &revokedAt, &found.CreatedAt, &found.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	found.ID, err = primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	if revokedAt.Valid {
+		found.RevokedAt = &revokedAt.Time
+	}
+	return found, nil
+}
+
+// normalize 는 SQLite 에서도 시각 비교가 가능하도록 UTC, 마이크로초 단위로 맞춤
+func normalize(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Microsecond)
+}
+
+func New(db *sqlstore.DB) session.Repository {
+	return &sessionRepo{db}
+}
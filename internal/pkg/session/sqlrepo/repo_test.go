@@ -0,0 +1,23 @@
+package sqlrepo_test
+
+import (
+	"testing"
+
+	"signupin-api/internal/pkg/session"
+	"signupin-api/internal/pkg/session/sessiontest"
+	"signupin-api/internal/pkg/session/sqlrepo"
+	"signupin-api/internal/pkg/sqlstore"
+	"signupin-api/internal/pkg/storetest"
+)
+
+func TestSQLiteRepository(t *testing.T) {
+	sessiontest.RunRepositoryTests(t, func(t *testing.T) session.Repository {
+		return sqlrepo.New(storetest.SQL(t, sqlstore.SQLite))
+	})
+}
+
+func TestPostgresRepository(t *testing.T) {
+	sessiontest.RunRepositoryTests(t, func(t *testing.T) session.Repository {
+		return sqlrepo.New(storetest.SQL(t, sqlstore.Postgres))
+	})
+}
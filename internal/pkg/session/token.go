@@ -0,0 +1,66 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+// Claims 는 회원 JWT 에 담긴 정보
+type Claims struct {
+	UserID                string // 회원 아이디
+	SessionID             string // 세션 아이디
+	PasswordResetRequired bool   // 비밀번호 재설정 전까지 비밀번호 수정 API 만 허용
+	Locale                string // 회원이 설정한 응답 메시지 언어 (설정하지 않았으면 빈 값)
+}
+
+// generateToken 은 회원 JWT 에 세션 아이디 (sid) 와 회원이 설정한 언어 (locale) 를 담아 발급
+// 세션이 종료되면 만료 전이라도 토큰은 더 이상 사용할 수 없음
+func generateToken(model *Session, sessionID, locale string, secret []byte) (string, error) {
+	claims := jwt.MapClaims{}
+
+	claims["authorized"] = true
+	claims["user_id"] = model.UserID
+	claims["sid"] = sessionID
+	claims["exp"] = model.ExpiresAt.Unix()
+	if model.Restricted {
+		claims["pwd_reset"] = true
+	}
+	if len(locale) > 0 {
+		claims["locale"] = locale
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	return token.SignedString(secret)
+}
+
+// parseToken 은 회원 JWT 를 검증한 뒤 claims 를 반환
+func parseToken(tokenString string, secret []byte) (*Claims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return secret, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	result := &Claims{}
+	result.UserID, _ = claims["user_id"].(string)
+	result.SessionID, _ = claims["sid"].(string)
+	result.PasswordResetRequired, _ = claims["pwd_reset"].(bool)
+	result.Locale, _ = claims["locale"].(string)
+	if len(result.UserID) == 0 || len(result.SessionID) == 0 {
+		return nil, errors.New("token is not bound to a session")
+	}
+
+	return result, nil
+}
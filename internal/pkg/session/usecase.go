@@ -0,0 +1,140 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/pkg/metrics"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/errorcode"
+	"github.com/kkodecaffeine/go-common/rest"
+	"github.com/kkodecaffeine/go-common/utils"
+)
+
+// UseCase interface definition
+type Usecase interface {
+	// 로그인 성공 시 세션을 기록하고 세션에 묶인 토큰과 세션 아이디 반환
+	Start(ctx context.Context, userID string, meta *Metadata) (string, string, *rest.CustomError)
+
+	// GET
+	GetAll(ctx context.Context, userID, currentID string) ([]dto.GetSessionResponse, *rest.CustomError)
+
+	// UPDATE
+	Revoke(ctx context.Context, userID, ID string) *rest.CustomError
+
+	// 회원 탈퇴 시 회원의 세션을 모두 종료
+	RevokeAll(ctx context.Context, userID string) *rest.CustomError
+
+	// 회원 JWT 를 검증한 뒤 claims 반환
+	ParseToken(tokenString string) (*Claims, error)
+
+	// 토큰의 세션이 유효한지 확인
+	Verify(ctx context.Context, userID, ID string) *rest.CustomError
+}
+
+type usecase struct {
+	repo   Repository
+	ttl    time.Duration // 세션 (토큰) 유효 시간
+	secret []byte        // 회원 JWT 서명 키 (API_SECRET)
+}
+
+func (u *usecase) Start(ctx context.Context, userID string, meta *Metadata) (string, string, *rest.CustomError) {
+	model := newSession(userID, meta, u.ttl)
+
+	insertedID, err := u.repo.SaveOne(ctx, model)
+	if err != nil {
+		return "", "", toCustomError(err)
+	}
+
+	token, err := generateToken(model, insertedID, meta.Locale, u.secret)
+	if err != nil {
+		return "", "", &rest.CustomError{CodeDesc: &errorcode.ACCESS_DENIED, Message: err.Error()}
+	}
+	metrics.TokenIssued(metrics.TokenSession)
+
+	return token, insertedID, nil
+}
+
+func (u *usecase) GetAll(ctx context.Context, userID, currentID string) ([]dto.GetSessionResponse, *rest.CustomError) {
+	found, err := u.repo.GetAll(ctx, userID, time.Now())
+	if err != nil && !errortype.IsNotFoundErr(err) {
+		return nil, toCustomError(err)
+	}
+
+	result := make([]dto.GetSessionResponse, 0, len(found))
+	for _, s := range found {
+		id := utils.MapToStringID(s.ID)
+		result = append(result, dto.GetSessionResponse{
+			Id:          id,
+			DeviceLabel: s.DeviceLabel,
+			UserAgent:   s.UserAgent,
+			IP:          s.IP,
+			Current:     id == currentID,
+			CreatedAt:   s.CreatedAt,
+			LastSeenAt:  s.LastSeenAt,
+			ExpiresAt:   s.ExpiresAt,
+		})
+	}
+	return result, nil
+}
+
+// Revoke 는 세션을 종료하여 세션에 묶인 토큰을 더 이상 사용할 수 없도록 함
+func (u *usecase) Revoke(ctx context.Context, userID, ID string) *rest.CustomError {
+	if err := u.repo.Revoke(ctx, userID, ID, time.Now()); err != nil {
+		return toCustomError(err)
+	}
+	return nil
+}
+
+// RevokeAll 은 회원의 세션을 모두 종료하여 발급된 회원 JWT 를 더 이상 사용할 수 없게 함
+func (u *usecase) RevokeAll(ctx context.Context, userID string) *rest.CustomError {
+	if err := u.repo.RevokeAll(ctx, userID, time.Now()); err != nil {
+		return toCustomError(err)
+	}
+	return nil
+}
+
+func (u *usecase) ParseToken(tokenString string) (*Claims, error) {
+	return parseToken(tokenString, u.secret)
+}
+
+func (u *usecase) Verify(ctx context.Context, userID, ID string) *rest.CustomError {
+	found, err := u.repo.GetOne(ctx, ID)
+	if err != nil {
+		if errortype.IsNotFoundErr(err) {
+			return &rest.CustomError{CodeDesc: &errorcode.ACCESS_DENIED, Message: "session not found"}
+		}
+		return toCustomError(err)
+	}
+
+	now := time.Now()
+	if found.UserID != userID || !found.isActive(now) {
+		return &rest.CustomError{CodeDesc: &errorcode.ACCESS_DENIED, Message: "session terminated"}
+	}
+
+	if found.needsTouch(now) {
+		_ = u.repo.Touch(ctx, ID, now)
+	}
+
+	return nil
+}
+
+func toCustomError(err error) *rest.CustomError {
+	if errortype.IsDecodeError(err) {
+		return &rest.CustomError{CodeDesc: &errorcode.FAILED_DB_PROCESSING, Message: err.Error()}
+	} else if errortype.IsNotFoundErr(err) {
+		return &rest.CustomError{CodeDesc: &errorcode.NOT_FOUND_ERROR, Message: err.Error()}
+	} else {
+		return &rest.CustomError{CodeDesc: &errorcode.FAILED_INTERNAL_ERROR, Message: err.Error()}
+	}
+}
+
+// NewUsecase returns new Usecase implementation
+// ttl 은 로그인 세션과 세션에 묶인 회원 JWT 의 유효 시간 (SESSION_TTL), secret 은 회원 JWT 서명 키 (API_SECRET)
+func NewUsecase(repo Repository, ttl time.Duration, secret string) Usecase {
+	return &usecase{repo: repo, ttl: ttl, secret: []byte(secret)}
+}
+
+var _ Usecase = &usecase{}
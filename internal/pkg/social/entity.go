@@ -0,0 +1,78 @@
+package social
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/kamva/mgm/v3"
+)
+
+const stateTTL = 10 * time.Minute // 외부 인증 화면에서 돌아오기까지 허용하는 시간
+
+// Identity 는 외부 인증 제공자의 계정 (provider + subject) 과 회원의 연결 정보
+type Identity struct {
+	mgm.DefaultModel `bson:",inline"`
+	Provider         string `json:"provider" bson:"provider"`                 // 인증 제공자 (ex. google, kakao)
+	Subject          string `json:"subject" bson:"subject"`                   // 인증 제공자의 계정 아이디
+	UserID           string `json:"user_id" bson:"user_id"`                   // 회원 아이디
+	Email            string `json:"email" bson:"email"`                       // 인증 제공자가 전달한 이메일
+	AutoProvisioned  bool   `json:"auto_provisioned" bson:"auto_provisioned"` // 최초 로그인 시 회원 가입이 함께 처리되었는지 여부
+}
+
+// LoginState 는 인증 제공자로 이동하기 전에 저장하는 요청 정보 (state, nonce, PKCE)
+type LoginState struct {
+	mgm.DefaultModel `bson:",inline"`
+	StateHash        string    `json:"-" bson:"state_hash"`          // state (sha256)
+	Provider         string    `json:"provider" bson:"provider"`     // 인증 제공자
+	Nonce            string    `json:"-" bson:"nonce"`               // ID 토큰 nonce
+	CodeVerifier     string    `json:"-" bson:"code_verifier"`       // PKCE code_verifier
+	UserID           string    `json:"user_id" bson:"user_id"`       // 계정 연결 요청인 경우 회원 아이디
+	ChallengeID      string    `json:"-" bson:"challenge_id"`        // 추가 인증 요청을 받은 후 다시 시작한 경우 추가 인증 아이디
+	StepUpCode       string    `json:"-" bson:"step_up_code"`        // 추가 인증 코드
+	ExpiresAt        time.Time `json:"expires_at" bson:"expires_at"` // 만료 시각
+}
+
+func newIdentity(provider string, profile *Profile, userID string, provisioned bool) *Identity {
+	return &Identity{
+		Provider:        provider,
+		Subject:         profile.Subject,
+		UserID:          userID,
+		Email:           profile.Email,
+		AutoProvisioned: provisioned,
+	}
+}
+
+func newLoginState(provider, userID string) (*LoginState, string) {
+	state := randomString(32)
+
+	return &LoginState{
+		StateHash:    HashState(state),
+		Provider:     provider,
+		Nonce:        randomString(16),
+		CodeVerifier: randomString(32),
+		UserID:       userID,
+		ExpiresAt:    time.Now().Add(stateTTL),
+	}, state
+}
+
+func (s *LoginState) codeChallenge() string {
+	sum := sha256.Sum256([]byte(s.CodeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// HashState 는 저장소에 보관할 state 의 해시값을 반환
+func HashState(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomString(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"signupin-api/internal/pkg/social"
+
+	"github.com/kamva/mgm/v3"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// socialRepo 는 DB 없이 실행하기 위한 social.Repository 구현 (테스트, 로컬 개발용)
+// persistence.socialRepo 와 동일하게 조회 결과가 없으면 errortype.IsNotFoundErr 로 확인 가능한 오류 반환
+type socialRepo struct {
+	mu         sync.Mutex
+	identities []social.Identity
+	states     []social.LoginState
+}
+
+var _ social.Repository = &socialRepo{}
+
+func (r *socialRepo) SaveIdentity(ctx context.Context, model *social.Identity) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prepare(&model.DefaultModel)
+	r.identities = append(r.identities, *model)
+	return utils.MapToStringID(model.ID), nil
+}
+
+func (r *socialRepo) SaveState(ctx context.Context, model *social.LoginState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prepare(&model.DefaultModel)
+	r.states = append(r.states, *model)
+	return nil
+}
+
+func (r *socialRepo) GetIdentity(ctx context.Context, provider, subject string) (*social.Identity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.identities {
+		if r.identities[i].Provider == provider && r.identities[i].Subject == subject {
+			found := r.identities[i]
+			return &found, nil
+		}
+	}
+	return nil, notFound(&social.Identity{}, bson.M{"provider": provider, "subject": subject})
+}
+
+func (r *socialRepo) GetIdentities(ctx context.Context, userID string) ([]social.Identity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	found := []social.Identity{}
+	for _, identity := range r.identities {
+		if identity.UserID == userID {
+			found = append(found, identity)
+		}
+	}
+	return found, nil
+}
+
+func (r *socialRepo) DeleteIdentity(ctx context.Context, userID, provider string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.identities {
+		if r.identities[i].UserID == userID && r.identities[i].Provider == provider {
+			r.identities = append(r.identities[:i], r.identities[i+1:]...)
+			return nil
+		}
+	}
+	return notFound(&social.Identity{}, bson.M{"user_id": userID, "provider": provider})
+}
+
+func (r *socialRepo) DeleteIdentities(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	identities := r.identities[:0]
+	for _, identity := range r.identities {
+		if identity.UserID != userID {
+			identities = append(identities, identity)
+		}
+	}
+	r.identities = identities
+	return nil
+}
+
+// ConsumeState 는 state 를 조회하는 동시에 삭제하여 재사용을 막음
+func (r *socialRepo) ConsumeState(ctx context.Context, state string) (*social.LoginState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hash := social.HashState(state)
+	for i := range r.states {
+		if r.states[i].StateHash == hash {
+			found := r.states[i]
+			r.states = append(r.states[:i], r.states[i+1:]...)
+			return &found, nil
+		}
+	}
+	return nil, notFound(&social.LoginState{}, bson.M{"state_hash": hash})
+}
+
+func prepare(model *mgm.DefaultModel) {
+	if model.ID.IsZero() {
+		model.ID = primitive.NewObjectID()
+	}
+	now := time.Now().UTC()
+	model.CreatedAt = now
+	model.UpdatedAt = now
+}
+
+func notFound(model mgm.Model, filter bson.M) error {
+	return errortype.NotFoundError(mgm.CollName(model), filter, nil, nil)
+}
+
+func New() social.Repository {
+	return &socialRepo{}
+}
@@ -0,0 +1,111 @@
+package persistence
+
+import (
+	"context"
+	"signupin-api/internal/pkg/social"
+
+	"github.com/kamva/mgm/v3"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type socialRepo struct {
+	client *mongo.Client
+}
+
+var _ social.Repository = &socialRepo{}
+
+func (r *socialRepo) SaveIdentity(ctx context.Context, model *social.Identity) (string, error) {
+	coll := mgm.Coll(model)
+	err := coll.CreateWithCtx(ctx, model)
+	if err != nil {
+		return "", errortype.ParseAndReturnDBError(err, coll.Name(), nil, nil, nil)
+	}
+
+	insertedID := utils.MapToStringID(model.ID)
+	return insertedID, nil
+}
+
+func (r *socialRepo) SaveState(ctx context.Context, model *social.LoginState) error {
+	coll := mgm.Coll(model)
+	err := coll.CreateWithCtx(ctx, model)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), nil, nil, nil)
+	}
+
+	return nil
+}
+
+func (r *socialRepo) GetIdentity(ctx context.Context, provider, subject string) (*social.Identity, error) {
+	found := &social.Identity{}
+	filter := bson.M{"provider": provider, "subject": subject}
+
+	coll := mgm.Coll(found)
+	err := coll.FindOne(ctx, filter).Decode(found)
+	if err != nil {
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return found, nil
+}
+
+func (r *socialRepo) GetIdentities(ctx context.Context, userID string) ([]social.Identity, error) {
+	found := []social.Identity{}
+	filter := bson.M{"user_id": userID}
+
+	coll := mgm.Coll(&social.Identity{})
+	err := coll.SimpleFindWithCtx(ctx, &found, filter)
+	if err != nil {
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return found, nil
+}
+
+func (r *socialRepo) DeleteIdentity(ctx context.Context, userID, provider string) error {
+	filter := bson.M{"user_id": userID, "provider": provider}
+
+	coll := mgm.Coll(&social.Identity{})
+	result, err := coll.DeleteOne(ctx, filter)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+	if result.DeletedCount == 0 {
+		return errortype.NotFoundError(coll.Name(), filter, nil, nil)
+	}
+
+	return nil
+}
+
+// DeleteIdentities 는 회원에게 연결된 외부 계정을 모두 삭제 (삭제할 계정이 없어도 성공)
+func (r *socialRepo) DeleteIdentities(ctx context.Context, userID string) error {
+	filter := bson.M{"user_id": userID}
+
+	coll := mgm.Coll(&social.Identity{})
+	if _, err := coll.DeleteMany(ctx, filter); err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return nil
+}
+
+// ConsumeState 는 state 를 조회하는 동시에 삭제하여 재사용을 막음
+func (r *socialRepo) ConsumeState(ctx context.Context, state string) (*social.LoginState, error) {
+	found := &social.LoginState{}
+	filter := bson.M{"state_hash": social.HashState(state)}
+
+	coll := mgm.Coll(found)
+	err := coll.FindOneAndDelete(ctx, filter).Decode(found)
+	if err != nil {
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return found, nil
+}
+
+func New(client *mongo.Client) social.Repository {
+	return &socialRepo{client}
+}
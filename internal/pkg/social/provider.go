@@ -0,0 +1,340 @@
+package social
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Provider 는 외부 OAuth2 / OIDC 인증 제공자 설정
+type Provider struct {
+	Name               string            // 인증 제공자 이름 (ex. google, kakao)
+	ClientID           string            // 발급받은 클라이언트 아이디
+	ClientSecret       string            // 발급받은 클라이언트 시크릿
+	AuthURL            string            // authorization endpoint
+	TokenURL           string            // token endpoint
+	UserInfoURL        string            // userinfo endpoint (비어있으면 ID 토큰의 claims 사용)
+	RedirectURL        string            // 본 서비스의 callback 주소
+	Issuer             string            // ID 토큰 iss 검증값 (선택)
+	Scopes             []string          // 요청 scope (openid 가 있으면 ID 토큰 필수)
+	SubjectClaim       string            // 계정 아이디 claim 경로 (ex. sub, id, response.id)
+	EmailClaim         string            // 이메일 claim 경로
+	EmailVerifiedClaim string            // 이메일 확인 여부 claim 경로 (비어있으면 확인되지 않은 이메일로 간주)
+	NameClaim          string            // 이름 claim 경로
+	AuthParams         map[string]string // authorization endpoint 추가 파라미터
+}
+
+// Profile 은 인증 제공자로부터 받은 회원 정보
+type Profile struct {
+	Subject       string
+	Email         string
+	EmailVerified bool // 인증 제공자가 이메일 소유를 확인했는지 여부
+	Name          string
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	IDToken     string `json:"id_token"`
+}
+
+// 알려진 인증 제공자의 기본 설정, LoadProviders 의 settings 로 덮어쓸 수 있음
+var presets = map[string]Provider{
+	"google": {
+		AuthURL:            "https://accounts.google.com/o/oauth2/v2/auth",
+		TokenURL:           "https://oauth2.googleapis.com/token",
+		UserInfoURL:        "https://openidconnect.googleapis.com/v1/userinfo",
+		Issuer:             "https://accounts.google.com",
+		Scopes:             []string{"openid", "email", "profile"},
+		SubjectClaim:       "sub",
+		EmailClaim:         "email",
+		EmailVerifiedClaim: "email_verified",
+		NameClaim:          "name",
+	},
+	"kakao": {
+		AuthURL:            "https://kauth.kakao.com/oauth/authorize",
+		TokenURL:           "https://kauth.kakao.com/oauth/token",
+		UserInfoURL:        "https://kapi.kakao.com/v2/user/me",
+		Scopes:             []string{"account_email", "profile_nickname"},
+		SubjectClaim:       "id",
+		EmailClaim:         "kakao_account.email",
+		EmailVerifiedClaim: "kakao_account.is_email_verified",
+		NameClaim:          "kakao_account.profile.nickname",
+	},
+	// naver 는 이메일 확인 여부를 전달하지 않으므로 최초 로그인으로 회원 가입할 수 없음 (가입한 회원의 계정 연결만 가능)
+	"naver": {
+		AuthURL:      "https://nid.naver.com/oauth2.0/authorize",
+		TokenURL:     "https://nid.naver.com/oauth2.0/token",
+		UserInfoURL:  "https://openapi.naver.com/v1/nid/me",
+		SubjectClaim: "response.id",
+		EmailClaim:   "response.email",
+		NameClaim:    "response.name",
+	},
+	"apple": {
+		AuthURL:            "https://appleid.apple.com/auth/authorize",
+		TokenURL:           "https://appleid.apple.com/auth/token",
+		Issuer:             "https://appleid.apple.com",
+		Scopes:             []string{"openid", "email", "name"},
+		SubjectClaim:       "sub",
+		EmailClaim:         "email",
+		EmailVerifiedClaim: "email_verified",
+		NameClaim:          "name",
+		AuthParams:         map[string]string{"response_mode": "form_post"},
+	},
+}
+
+// LoadProviders 는 names 에 나열된 인증 제공자 설정을 기본 설정에 settings 의 값을 덮어써서 구성
+// settings 에서 비어있는 항목은 기본 설정을 그대로 사용
+func LoadProviders(names []string, settings map[string]Provider) (map[string]*Provider, error) {
+	providers := map[string]*Provider{}
+
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if len(name) == 0 {
+			continue
+		}
+
+		provider := presets[name]
+		provider.Name = name
+
+		setting := settings[name]
+		override := func(target *string, value string) {
+			if len(value) > 0 {
+				*target = value
+			}
+		}
+		override(&provider.ClientID, setting.ClientID)
+		override(&provider.ClientSecret, setting.ClientSecret)
+		override(&provider.AuthURL, setting.AuthURL)
+		override(&provider.TokenURL, setting.TokenURL)
+		override(&provider.UserInfoURL, setting.UserInfoURL)
+		override(&provider.RedirectURL, setting.RedirectURL)
+		override(&provider.Issuer, setting.Issuer)
+		override(&provider.SubjectClaim, setting.SubjectClaim)
+		override(&provider.EmailClaim, setting.EmailClaim)
+		override(&provider.EmailVerifiedClaim, setting.EmailVerifiedClaim)
+		override(&provider.NameClaim, setting.NameClaim)
+		if len(setting.Scopes) > 0 {
+			provider.Scopes = setting.Scopes
+		}
+		if len(provider.SubjectClaim) == 0 {
+			provider.SubjectClaim = "sub"
+		}
+
+		if len(provider.ClientID) == 0 || len(provider.AuthURL) == 0 || len(provider.TokenURL) == 0 || len(provider.RedirectURL) == 0 {
+			prefix := "SOCIAL_" + strings.ToUpper(name) + "_"
+			return nil, fmt.Errorf("social provider %q requires %sCLIENT_ID, %sAUTH_URL, %sTOKEN_URL and %sREDIRECT_URL", name, prefix, prefix, prefix, prefix)
+		}
+
+		providers[name] = &provider
+	}
+
+	return providers, nil
+}
+
+func (p *Provider) authorizationURL(state *LoginState, rawState string) string {
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.ClientID},
+		"redirect_uri":          {p.RedirectURL},
+		"state":                 {rawState},
+		"nonce":                 {state.Nonce},
+		"code_challenge":        {state.codeChallenge()},
+		"code_challenge_method": {"S256"},
+	}
+	if len(p.Scopes) > 0 {
+		query.Set("scope", strings.Join(p.Scopes, " "))
+	}
+	for key, value := range p.AuthParams {
+		query.Set(key, value)
+	}
+
+	separator := "?"
+	if strings.Contains(p.AuthURL, "?") {
+		separator = "&"
+	}
+	return p.AuthURL + separator + query.Encode()
+}
+
+func (p *Provider) exchange(ctx context.Context, client *http.Client, code string, state *LoginState) (*tokenResponse, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.RedirectURL},
+		"client_id":     {p.ClientID},
+		"client_secret": {p.ClientSecret},
+		"code_verifier": {state.CodeVerifier},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	token := &tokenResponse{}
+	if err := doJSON(client, req, token); err != nil {
+		return nil, err
+	}
+	if len(token.AccessToken) == 0 && len(token.IDToken) == 0 {
+		return nil, errors.New("token endpoint returned no token")
+	}
+
+	return token, nil
+}
+
+// profile 은 ID 토큰 (openid scope 를 요청했거나 token endpoint 가 발급한 경우 필수로 검증) 혹은 userinfo endpoint 의 claims 로 회원 정보 구성
+func (p *Provider) profile(ctx context.Context, client *http.Client, token *tokenResponse, state *LoginState) (*Profile, error) {
+	var idClaims map[string]interface{}
+	if len(token.IDToken) > 0 || p.requestsOpenID() {
+		// token endpoint 와 직접 통신하여 받은 ID 토큰은 TLS 로 발급자를 검증 (OpenID Connect Core 3.1.3.7)
+		parsed, err := p.idTokenClaims(token.IDToken, state.Nonce)
+		if err != nil {
+			return nil, err
+		}
+		idClaims = parsed
+	}
+
+	claims := idClaims
+	if len(p.UserInfoURL) > 0 {
+		claims = map[string]interface{}{}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.UserInfoURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+		req.Header.Set("Accept", "application/json")
+
+		if err := doJSON(client, req, &claims); err != nil {
+			return nil, err
+		}
+
+		// userinfo 의 sub 는 ID 토큰의 sub 와 같아야 함 (OpenID Connect Core 5.3.2)
+		if idClaims != nil && p.SubjectClaim == "sub" && lookupClaim(claims, "sub") != lookupClaim(idClaims, "sub") {
+			return nil, errors.New("userinfo sub does not match id_token")
+		}
+	}
+
+	profile := &Profile{
+		Subject:       lookupClaim(claims, p.SubjectClaim),
+		Email:         lookupClaim(claims, p.EmailClaim),
+		EmailVerified: lookupClaim(claims, p.EmailVerifiedClaim) == "true",
+		Name:          lookupClaim(claims, p.NameClaim),
+	}
+	if len(profile.Subject) == 0 {
+		return nil, fmt.Errorf("claim %q not found in %s profile", p.SubjectClaim, p.Name)
+	}
+
+	return profile, nil
+}
+
+// requestsOpenID 는 openid scope 를 요청하는지 여부 (OpenID Connect 인증 제공자)
+func (p *Provider) requestsOpenID() bool {
+	for _, scope := range p.Scopes {
+		if scope == "openid" {
+			return true
+		}
+	}
+	return false
+}
+
+// idTokenClaims 는 ID 토큰의 발급자, 대상, nonce (인가 요청 시 전달한 값과 같아야 함), 만료 시각을 확인하고 claims 반환
+func (p *Provider) idTokenClaims(idToken, nonce string) (map[string]interface{}, error) {
+	if len(idToken) == 0 {
+		return nil, errors.New("token endpoint returned no id_token")
+	}
+
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed id_token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	claims := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&claims); err != nil {
+		return nil, err
+	}
+
+	if len(p.Issuer) > 0 && lookupClaim(claims, "iss") != p.Issuer {
+		return nil, errors.New("id_token issuer mismatch")
+	}
+	if !audienceContains(claims["aud"], p.ClientID) {
+		return nil, errors.New("id_token audience mismatch")
+	}
+	if len(nonce) == 0 || lookupClaim(claims, "nonce") != nonce {
+		return nil, errors.New("id_token nonce mismatch")
+	}
+	if exp, err := json.Number(lookupClaim(claims, "exp")).Int64(); err != nil || time.Now().Unix() > exp {
+		return nil, errors.New("id_token expired")
+	}
+
+	return claims, nil
+}
+
+func doJSON(client *http.Client, req *http.Request, target interface{}) error {
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("%s %s returned %d", req.Method, req.URL.Host, res.StatusCode)
+	}
+
+	decoder := json.NewDecoder(res.Body)
+	decoder.UseNumber()
+	return decoder.Decode(target)
+}
+
+// lookupClaim 은 점(.)으로 구분된 경로로 claim 값을 찾아 문자열로 반환
+func lookupClaim(claims map[string]interface{}, path string) string {
+	if len(path) == 0 {
+		return ""
+	}
+
+	var current interface{} = claims
+	for _, key := range strings.Split(path, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		current = object[key]
+	}
+
+	switch value := current.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func audienceContains(aud interface{}, clientID string) bool {
+	switch value := aud.(type) {
+	case string:
+		return value == clientID
+	case []interface{}:
+		for _, item := range value {
+			if item == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
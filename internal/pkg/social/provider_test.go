@@ -0,0 +1,104 @@
+package social
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// idToken 은 서명하지 않은 ID 토큰 (token endpoint 와 직접 통신하여 받은 경우 서명을 확인하지 않음)
+func idToken(claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": "none"})
+	payload, _ := json.Marshal(claims)
+	return base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload) + "."
+}
+
+func TestProfile(t *testing.T) {
+	ctx := context.Background()
+	state := &LoginState{Nonce: "n-1"}
+
+	provider := &Provider{
+		Name:               "mock",
+		ClientID:           "client",
+		Issuer:             "https://idp.example.com",
+		Scopes:             []string{"openid", "email"},
+		SubjectClaim:       "sub",
+		EmailClaim:         "email",
+		EmailVerifiedClaim: "email_verified",
+	}
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		result := map[string]interface{}{
+			"iss":            "https://idp.example.com",
+			"aud":            "client",
+			"sub":            "s-1",
+			"email":          "kim@example.com",
+			"email_verified": true,
+			"nonce":          "n-1",
+			"exp":            time.Now().Add(time.Hour).Unix(),
+		}
+		for key, value := range overrides {
+			if value == nil {
+				delete(result, key)
+			} else {
+				result[key] = value
+			}
+		}
+		return result
+	}
+
+	t.Run("reads verified email from id_token", func(t *testing.T) {
+		profile, err := provider.profile(ctx, nil, &tokenResponse{IDToken: idToken(claims(nil))}, state)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if profile.Subject != "s-1" || profile.Email != "kim@example.com" || !profile.EmailVerified {
+			t.Fatalf("unexpected profile: %+v", profile)
+		}
+	})
+
+	t.Run("treats missing or false email_verified as unverified", func(t *testing.T) {
+		for _, verified := range []interface{}{false, "false", nil} {
+			profile, err := provider.profile(ctx, nil, &tokenResponse{IDToken: idToken(claims(map[string]interface{}{"email_verified": verified}))}, state)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if profile.EmailVerified {
+				t.Errorf("email_verified %v: got verified", verified)
+			}
+		}
+	})
+
+	t.Run("requires nonce", func(t *testing.T) {
+		for _, nonce := range []interface{}{nil, "other"} {
+			_, err := provider.profile(ctx, nil, &tokenResponse{IDToken: idToken(claims(map[string]interface{}{"nonce": nonce}))}, state)
+			if err == nil || !strings.Contains(err.Error(), "nonce") {
+				t.Errorf("nonce %v: got %v, want nonce mismatch", nonce, err)
+			}
+		}
+	})
+
+	t.Run("requires id_token for openid scope", func(t *testing.T) {
+		if _, err := provider.profile(ctx, nil, &tokenResponse{AccessToken: "at"}, state); err == nil {
+			t.Fatal("want error without id_token")
+		}
+	})
+
+	t.Run("checks userinfo subject against id_token", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"sub": "s-2", "email": "lee@example.com", "email_verified": true})
+		}))
+		defer server.Close()
+
+		withUserInfo := *provider
+		withUserInfo.UserInfoURL = server.URL
+		_, err := withUserInfo.profile(ctx, server.Client(), &tokenResponse{AccessToken: "at", IDToken: idToken(claims(nil))}, state)
+		if err == nil || !strings.Contains(err.Error(), "sub") {
+			t.Fatalf("got %v, want sub mismatch", err)
+		}
+	})
+}
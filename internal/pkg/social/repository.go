@@ -0,0 +1,18 @@
+package social
+
+import "context"
+
+// Repository interface definition
+type Repository interface {
+	SaveIdentity(ctx context.Context, model *Identity) (string, error)
+	SaveState(ctx context.Context, model *LoginState) error
+
+	// GET
+	GetIdentity(ctx context.Context, provider, subject string) (*Identity, error)
+	GetIdentities(ctx context.Context, userID string) ([]Identity, error)
+
+	// DELETE
+	DeleteIdentity(ctx context.Context, userID, provider string) error
+	DeleteIdentities(ctx context.Context, userID string) error
+	ConsumeState(ctx context.Context, state string) (*LoginState, error)
+}
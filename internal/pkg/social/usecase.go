@@ -0,0 +1,263 @@
+package social
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/pkg/user"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/errorcode"
+	"github.com/kkodecaffeine/go-common/rest"
+)
+
+// UseCase interface definition
+type Usecase interface {
+	// 인증 제공자 로그인 화면 주소 (userID 가 있으면 계정 연결 요청, stepUp 은 callback 결과로 전달)
+	AuthorizationURL(ctx context.Context, provider, userID string, stepUp *dto.GetSocialSignInRequest) (string, *rest.CustomError)
+	Callback(ctx context.Context, provider string, req *dto.GetSocialCallbackRequest) (*dto.GetSocialCallbackResponse, *rest.CustomError)
+
+	// GET
+	GetIdentities(ctx context.Context, userID string) ([]dto.GetIdentityResponse, *rest.CustomError)
+
+	// DELETE
+	Unlink(ctx context.Context, userID, provider string) *rest.CustomError
+
+	// 회원 탈퇴 시 회원에게 연결된 외부 계정을 모두 삭제
+	DeleteAll(ctx context.Context, userID string) *rest.CustomError
+}
+
+type usecase struct {
+	repo      Repository
+	users     user.Usecase
+	providers map[string]*Provider
+	client    *http.Client
+}
+
+func (u *usecase) AuthorizationURL(ctx context.Context, name, userID string, stepUp *dto.GetSocialSignInRequest) (string, *rest.CustomError) {
+	provider, ok := u.providers[name]
+	if !ok {
+		return "", &rest.CustomError{CodeDesc: &errorcode.NOT_FOUND_ERROR, Message: name}
+	}
+
+	state, rawState := newLoginState(name, userID)
+	if stepUp != nil {
+		state.ChallengeID, state.StepUpCode = stepUp.ChallengeID, stepUp.Code
+	}
+	if err := u.repo.SaveState(ctx, state); err != nil {
+		return "", toCustomError(err)
+	}
+
+	return provider.authorizationURL(state, rawState), nil
+}
+
+func (u *usecase) Callback(ctx context.Context, name string, req *dto.GetSocialCallbackRequest) (*dto.GetSocialCallbackResponse, *rest.CustomError) {
+	provider, ok := u.providers[name]
+	if !ok {
+		return nil, &rest.CustomError{CodeDesc: &errorcode.NOT_FOUND_ERROR, Message: name}
+	}
+
+	if len(req.Error) > 0 {
+		return nil, &rest.CustomError{CodeDesc: &errorcode.ACCESS_DENIED, Message: req.Error + " " + req.ErrorDescription}
+	}
+
+	// state 는 검증 결과와 상관없이 1회만 사용 가능
+	state, err := u.repo.ConsumeState(ctx, req.State)
+	if err != nil {
+		if errortype.IsNotFoundErr(err) {
+			return nil, &rest.CustomError{CodeDesc: &errorcode.BAD_REQUEST, Message: "invalid state"}
+		}
+		return nil, toCustomError(err)
+	}
+	if state.Provider != name || time.Now().After(state.ExpiresAt) {
+		return nil, &rest.CustomError{CodeDesc: &errorcode.BAD_REQUEST, Message: "invalid state"}
+	}
+
+	token, err := provider.exchange(ctx, u.client, req.Code, state)
+	if err != nil {
+		return nil, &rest.CustomError{CodeDesc: &errorcode.ACCESS_DENIED, Message: err.Error()}
+	}
+
+	profile, err := provider.profile(ctx, u.client, token, state)
+	if err != nil {
+		return nil, &rest.CustomError{CodeDesc: &errorcode.ACCESS_DENIED, Message: err.Error()}
+	}
+
+	found, err := u.repo.GetIdentity(ctx, name, profile.Subject)
+	if err != nil && !errortype.IsNotFoundErr(err) {
+		return nil, toCustomError(err)
+	}
+
+	if len(state.UserID) > 0 {
+		return u.link(ctx, name, profile, found, state.UserID)
+	}
+
+	result, cerr := u.signIn(ctx, name, profile, found)
+	if cerr != nil {
+		return nil, cerr
+	}
+	result.ChallengeID, result.StepUpCode = state.ChallengeID, state.StepUpCode
+	return result, nil
+}
+
+// link 는 로그인한 회원에게 외부 계정을 연결
+func (u *usecase) link(ctx context.Context, name string, profile *Profile, found *Identity, userID string) (*dto.GetSocialCallbackResponse, *rest.CustomError) {
+	if found != nil {
+		if found.UserID != userID {
+			return nil, &rest.CustomError{CodeDesc: &errorcode.DUPLICATED_KEY, Message: "already linked to another user"}
+		}
+		return &dto.GetSocialCallbackResponse{Identity: toIdentityResponse(found)}, nil
+	}
+
+	identities, err := u.repo.GetIdentities(ctx, userID)
+	if err != nil && !errortype.IsNotFoundErr(err) {
+		return nil, toCustomError(err)
+	}
+	for _, identity := range identities {
+		if identity.Provider == name {
+			return nil, &rest.CustomError{CodeDesc: &errorcode.DUPLICATED_KEY, Message: "another " + name + " account is already linked"}
+		}
+	}
+
+	identity := newIdentity(name, profile, userID, false)
+	if _, err := u.repo.SaveIdentity(ctx, identity); err != nil {
+		return nil, toCustomError(err)
+	}
+
+	return &dto.GetSocialCallbackResponse{Identity: toIdentityResponse(identity)}, nil
+}
+
+// signIn 은 연결된 회원으로 로그인, 연결된 회원이 없으면 최초 로그인으로 간주하여 회원 가입 처리
+func (u *usecase) signIn(ctx context.Context, name string, profile *Profile, found *Identity) (*dto.GetSocialCallbackResponse, *rest.CustomError) {
+	created := false
+
+	if found == nil {
+		// 동일한 이메일로 가입한 회원이 있는 경우 자동으로 연결하지 않음 (로그인 후 계정 연결 API 사용)
+		if len(profile.Email) == 0 {
+			return nil, &rest.CustomError{CodeDesc: &errorcode.MISSING_PARAMETERS, Message: "email is not provided by " + name}
+		}
+		// 인증 제공자가 소유를 확인하지 않은 이메일로는 가입하거나 가입한 회원과 비교하지 않음
+		if !profile.EmailVerified {
+			return nil, &rest.CustomError{CodeDesc: &errorcode.FORBIDDEN_REQUEST, Message: "email is not verified by " + name}
+		}
+
+		userID, cerr := u.users.Provision(ctx, profile.Email, profile.Name)
+		if cerr != nil {
+			return nil, cerr
+		}
+
+		found = newIdentity(name, profile, userID, true)
+		if _, err := u.repo.SaveIdentity(ctx, found); err != nil {
+			return nil, toCustomError(err)
+		}
+		created = true
+	}
+
+	signedIn, cerr := u.users.IssueToken(ctx, found.UserID)
+	if cerr != nil && !created && cerr.CodeDesc.Code == errorcode.NOT_FOUND_ERROR.Code {
+		// 탈퇴한 회원의 외부 계정이 남아있는 경우 삭제하고 새로 가입 처리
+		if err := u.repo.DeleteIdentities(ctx, found.UserID); err != nil {
+			return nil, toCustomError(err)
+		}
+		return u.signIn(ctx, name, profile, nil)
+	}
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	return &dto.GetSocialCallbackResponse{Created: created, User: signedIn, Identity: toIdentityResponse(found)}, nil
+}
+
+func (u *usecase) GetIdentities(ctx context.Context, userID string) ([]dto.GetIdentityResponse, *rest.CustomError) {
+	identities, err := u.repo.GetIdentities(ctx, userID)
+	if err != nil && !errortype.IsNotFoundErr(err) {
+		return nil, toCustomError(err)
+	}
+
+	result := make([]dto.GetIdentityResponse, 0, len(identities))
+	for i := range identities {
+		result = append(result, *toIdentityResponse(&identities[i]))
+	}
+	return result, nil
+}
+
+func (u *usecase) Unlink(ctx context.Context, userID, name string) *rest.CustomError {
+	identities, err := u.repo.GetIdentities(ctx, userID)
+	if err != nil && !errortype.IsNotFoundErr(err) {
+		return toCustomError(err)
+	}
+
+	for _, identity := range identities {
+		if identity.Provider != name {
+			continue
+		}
+
+		// 해제 후에도 다른 외부 계정 혹은 회원이 정한 비밀번호로 로그인할 수 있어야 함
+		if len(identities) == 1 {
+			hasPassword, cerr := u.hasPassword(ctx, userID, &identity)
+			if cerr != nil {
+				return cerr
+			}
+			if !hasPassword {
+				return &rest.CustomError{CodeDesc: &errorcode.BAD_REQUEST, Message: "cannot unlink the only sign-in method"}
+			}
+		}
+
+		if err := u.repo.DeleteIdentity(ctx, userID, name); err != nil {
+			return toCustomError(err)
+		}
+		return nil
+	}
+
+	return &rest.CustomError{CodeDesc: &errorcode.NOT_FOUND_ERROR, Message: name}
+}
+
+// hasPassword 는 회원이 직접 정한 비밀번호가 있는지 확인
+// passwordless 필드가 없던 회원은 최초 로그인으로 가입한 외부 계정 (AutoProvisioned) 으로 판단
+// DeleteAll 은 회원에게 연결된 외부 계정을 모두 삭제 (탈퇴 후 같은 외부 계정으로 다시 가입할 수 있도록)
+func (u *usecase) DeleteAll(ctx context.Context, userID string) *rest.CustomError {
+	if err := u.repo.DeleteIdentities(ctx, userID); err != nil {
+		return toCustomError(err)
+	}
+	return nil
+}
+
+func (u *usecase) hasPassword(ctx context.Context, userID string, identity *Identity) (bool, *rest.CustomError) {
+	found, cerr := u.users.GetOneByID(ctx, userID)
+	if cerr != nil {
+		return false, cerr
+	}
+	return !found.Passwordless && !identity.AutoProvisioned, nil
+}
+
+func toIdentityResponse(identity *Identity) *dto.GetIdentityResponse {
+	return &dto.GetIdentityResponse{
+		Provider:  identity.Provider,
+		Subject:   identity.Subject,
+		Email:     identity.Email,
+		CreatedAt: identity.CreatedAt,
+	}
+}
+
+func toCustomError(err error) *rest.CustomError {
+	if errortype.IsDecodeError(err) {
+		return &rest.CustomError{CodeDesc: &errorcode.FAILED_DB_PROCESSING, Message: err.Error()}
+	} else if errortype.IsNotFoundErr(err) {
+		return &rest.CustomError{CodeDesc: &errorcode.NOT_FOUND_ERROR, Message: err.Error()}
+	} else {
+		return &rest.CustomError{CodeDesc: &errorcode.FAILED_INTERNAL_ERROR, Message: err.Error()}
+	}
+}
+
+// NewUsecase returns new Usecase implementation
+// client 를 주입하여 로컬 mock 인증 제공자를 대상으로 테스트할 수 있음
+func NewUsecase(repo Repository, users user.Usecase, providers map[string]*Provider, client *http.Client) Usecase {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &usecase{repo: repo, users: users, providers: providers, client: client}
+}
+
+var _ Usecase = &usecase{}
@@ -0,0 +1,175 @@
+package social
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"signupin-api/internal/pkg/user"
+	"signupin-api/internal/pkg/user/memory"
+
+	"github.com/kamva/mgm/v3"
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/errorcode"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryRepository 는 외부 계정 연결만 저장하는 Repository
+type memoryRepository struct {
+	mu         sync.Mutex
+	identities []Identity
+}
+
+var _ Repository = &memoryRepository{}
+
+func (r *memoryRepository) SaveIdentity(ctx context.Context, model *Identity) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	model.ID = primitive.NewObjectID()
+	r.identities = append(r.identities, *model)
+	return model.ID.Hex(), nil
+}
+
+func (r *memoryRepository) SaveState(ctx context.Context, model *LoginState) error { return nil }
+
+func (r *memoryRepository) GetIdentity(ctx context.Context, provider, subject string) (*Identity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.identities {
+		if r.identities[i].Provider == provider && r.identities[i].Subject == subject {
+			found := r.identities[i]
+			return &found, nil
+		}
+	}
+	return nil, errortype.NotFoundError(mgm.CollName(&Identity{}), bson.M{"provider": provider, "subject": subject}, nil, nil)
+}
+
+func (r *memoryRepository) GetIdentities(ctx context.Context, userID string) ([]Identity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result []Identity
+	for _, identity := range r.identities {
+		if identity.UserID == userID {
+			result = append(result, identity)
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryRepository) DeleteIdentity(ctx context.Context, userID, provider string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.identities {
+		if r.identities[i].UserID == userID && r.identities[i].Provider == provider {
+			r.identities = append(r.identities[:i], r.identities[i+1:]...)
+			return nil
+		}
+	}
+	return errortype.NotFoundError(mgm.CollName(&Identity{}), bson.M{"user_id": userID, "provider": provider}, nil, nil)
+}
+
+func (r *memoryRepository) DeleteIdentities(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	identities := r.identities[:0]
+	for _, identity := range r.identities {
+		if identity.UserID != userID {
+			identities = append(identities, identity)
+		}
+	}
+	r.identities = identities
+	return nil
+}
+
+func (r *memoryRepository) ConsumeState(ctx context.Context, state string) (*LoginState, error) {
+	return nil, errortype.NotFoundError(mgm.CollName(&LoginState{}), bson.M{}, nil, nil)
+}
+
+func TestUnlink(t *testing.T) {
+	ctx := context.Background()
+
+	// 소셜 로그인 (google) 으로 가입 후 kakao 계정 연결
+	setup := func(t *testing.T) (*usecase, user.Usecase, string) {
+		users := user.NewUsecase(memory.New(), "test-secret")
+		repo := &memoryRepository{}
+
+		userID, cerr := users.Provision(ctx, "kim@example.com", "김철수")
+		if cerr != nil {
+			t.Fatal(cerr)
+		}
+		repo.SaveIdentity(ctx, newIdentity("google", &Profile{Subject: "g-1"}, userID, true))
+		repo.SaveIdentity(ctx, newIdentity("kakao", &Profile{Subject: "k-1"}, userID, false))
+
+		return &usecase{repo: repo, users: users}, users, userID
+	}
+
+	t.Run("keeps the last sign-in method", func(t *testing.T) {
+		u, _, userID := setup(t)
+
+		if cerr := u.Unlink(ctx, userID, "google"); cerr != nil {
+			t.Fatal(cerr)
+		}
+		cerr := u.Unlink(ctx, userID, "kakao")
+		if cerr == nil || cerr.CodeDesc.Code != errorcode.BAD_REQUEST.Code {
+			t.Fatalf("got %v, want BAD_REQUEST", cerr)
+		}
+
+		identities, _ := u.GetIdentities(ctx, userID)
+		if len(identities) != 1 || identities[0].Provider != "kakao" {
+			t.Fatalf("unexpected identities: %+v", identities)
+		}
+	})
+
+	t.Run("unlinks every identity after password is set", func(t *testing.T) {
+		u, users, userID := setup(t)
+
+		authnumber, cerr := users.UpsertAuthNumber(ctx)
+		if cerr != nil {
+			t.Fatal(cerr)
+		}
+		if _, cerr := users.UpdatePassword(ctx, authnumber, userID, "new-password"); cerr != nil {
+			t.Fatal(cerr)
+		}
+
+		for _, provider := range []string{"google", "kakao"} {
+			if cerr := u.Unlink(ctx, userID, provider); cerr != nil {
+				t.Fatalf("unlink %s: %v", provider, cerr)
+			}
+		}
+	})
+}
+
+func TestSignInAfterUserDeleted(t *testing.T) {
+	ctx := context.Background()
+	users := user.NewUsecase(memory.New(), "test-secret")
+	repo := &memoryRepository{}
+	u := &usecase{repo: repo, users: users}
+
+	// 탈퇴 처리 중 외부 계정 삭제가 실패하여 연결만 남은 경우
+	deletedID := primitive.NewObjectID().Hex()
+	repo.SaveIdentity(ctx, newIdentity("google", &Profile{Subject: "g-1"}, deletedID, true))
+
+	found, err := repo.GetIdentity(ctx, "google", "g-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, cerr := u.signIn(ctx, "google", &Profile{Subject: "g-1", Email: "kim@example.com", EmailVerified: true, Name: "김철수"}, found)
+	if cerr != nil {
+		t.Fatal(cerr)
+	}
+	if !result.Created || result.User.Id == deletedID {
+		t.Fatalf("want new user, got %+v", result)
+	}
+
+	identities, _ := repo.GetIdentities(ctx, deletedID)
+	if len(identities) != 0 {
+		t.Fatalf("identity of deleted user is kept: %+v", identities)
+	}
+}
@@ -0,0 +1,227 @@
+package sqlstore
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations
+var migrationFiles embed.FS
+
+// Migration 은 버전별 스키마 변경 (migrations/<dialect>/<version>_<name>.up.sql, .down.sql)
+type Migration struct {
+	Version   int
+	Name      string
+	Up        string
+	Down      string
+	AppliedAt *time.Time // 적용되지 않았으면 nil
+}
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version    INTEGER PRIMARY KEY,
+    name       TEXT NOT NULL,
+    applied_at TIMESTAMP NOT NULL
+)`
+
+// Migrations 는 dialect 의 마이그레이션 목록과 적용 여부를 버전 순으로 반환
+func (db *DB) Migrations(ctx context.Context) ([]Migration, error) {
+	migrations, err := loadMigrations(db.Dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := db.ExecContext(ctx, createMigrationsTable); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int]time.Time{}
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range migrations {
+		if appliedAt, ok := applied[migrations[i].Version]; ok {
+			migrations[i].AppliedAt = &appliedAt
+		}
+	}
+	return migrations, nil
+}
+
+// Pending 은 아직 적용하지 않은 마이그레이션 수를 반환
+func (db *DB) Pending(ctx context.Context) (int, error) {
+	migrations, err := db.Migrations(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, m := range migrations {
+		if m.AppliedAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// MigrateUp 은 적용하지 않은 마이그레이션을 버전 순으로 하나씩 트랜잭션 안에서 적용
+func (db *DB) MigrateUp(ctx context.Context) ([]Migration, error) {
+	migrations, err := db.Migrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	for _, m := range migrations {
+		if m.AppliedAt != nil {
+			continue
+		}
+
+		insert := db.Rebind("INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)")
+		if err := db.apply(ctx, m.Up, insert, m.Version, m.Name, Now()); err != nil {
+			return applied, fmt.Errorf("migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+		applied = append(applied, m)
+	}
+	return applied, nil
+}
+
+// MigrateDown 은 마지막으로 적용한 마이그레이션 하나를 되돌림
+func (db *DB) MigrateDown(ctx context.Context) (*Migration, error) {
+	migrations, err := db.Migrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(migrations) - 1; i >= 0; i-- {
+		m := migrations[i]
+		if m.AppliedAt == nil {
+			continue
+		}
+		if len(m.Down) == 0 {
+			return nil, fmt.Errorf("migration %04d_%s has no down script", m.Version, m.Name)
+		}
+
+		remove := db.Rebind("DELETE FROM schema_migrations WHERE version = ?")
+		if err := db.apply(ctx, m.Down, remove, m.Version); err != nil {
+			return nil, fmt.Errorf("migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+		return &m, nil
+	}
+	return nil, nil
+}
+
+func (db *DB) apply(ctx context.Context, script, record string, args ...interface{}) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, statement := range splitStatements(script) {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func loadMigrations(dialect string) ([]Migration, error) {
+	dir := path.Join("migrations", dialect)
+	entries, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		name := entry.Name()
+
+		var direction string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+
+		base := strings.TrimSuffix(name, "."+direction+".sql")
+		parts := strings.SplitN(base, "_", 2)
+		version, err := strconv.Atoi(parts[0])
+		if err != nil || len(parts) != 2 {
+			return nil, fmt.Errorf("invalid migration file name %q", name)
+		}
+
+		content, err := fs.ReadFile(migrationFiles, path.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: parts[1]}
+			byVersion[version] = m
+		}
+		if direction == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if len(m.Up) == 0 {
+			return nil, fmt.Errorf("migration %04d_%s has no up script", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// splitStatements 는 세미콜론으로 구분된 SQL 문을 나눔 (주석 줄 제외, 문자열 안의 세미콜론은 지원하지 않음)
+func splitStatements(script string) []string {
+	var lines []string
+	for _, line := range strings.Split(script, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "--") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	var statements []string
+	for _, statement := range strings.Split(strings.Join(lines, "\n"), ";") {
+		if statement = strings.TrimSpace(statement); len(statement) > 0 {
+			statements = append(statements, statement)
+		}
+	}
+	return statements
+}
@@ -0,0 +1,15 @@
+CREATE TABLE outbox (
+    id              VARCHAR(24) PRIMARY KEY,
+    type            TEXT NOT NULL,
+    aggregate_id    TEXT NOT NULL,
+    payload         TEXT NOT NULL,
+    occurred_at     TIMESTAMPTZ NOT NULL,
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    last_error      TEXT NOT NULL DEFAULT '',
+    published_at    TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX outbox_pending_idx ON outbox (next_attempt_at) WHERE published_at IS NULL;
@@ -0,0 +1,2 @@
+DROP TABLE auth_numbers;
+DROP TABLE users;
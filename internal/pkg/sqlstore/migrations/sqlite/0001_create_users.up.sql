@@ -0,0 +1,22 @@
+CREATE TABLE users (
+    id                      VARCHAR(24) PRIMARY KEY,
+    email                   TEXT NOT NULL,
+    name                    TEXT NOT NULL DEFAULT '',
+    nickname                TEXT NOT NULL DEFAULT '',
+    password                TEXT NOT NULL,
+    phone                   TEXT NOT NULL DEFAULT '',
+    password_reset_required BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at              TIMESTAMP NOT NULL,
+    updated_at              TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX users_email_key ON users (email);
+CREATE INDEX users_phone_idx ON users (phone);
+
+-- 인증번호는 하나만 유지 (id = 1)
+CREATE TABLE auth_numbers (
+    id         INTEGER PRIMARY KEY,
+    authnumber TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
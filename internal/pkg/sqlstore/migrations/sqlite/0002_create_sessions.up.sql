@@ -0,0 +1,15 @@
+CREATE TABLE sessions (
+    id           VARCHAR(24) PRIMARY KEY,
+    user_id      VARCHAR(24) NOT NULL,
+    user_agent   TEXT NOT NULL DEFAULT '',
+    ip           TEXT NOT NULL DEFAULT '',
+    device_label TEXT NOT NULL DEFAULT '',
+    restricted   BOOLEAN NOT NULL DEFAULT FALSE,
+    last_seen_at TIMESTAMP NOT NULL,
+    expires_at   TIMESTAMP NOT NULL,
+    revoked_at   TIMESTAMP,
+    created_at   TIMESTAMP NOT NULL,
+    updated_at   TIMESTAMP NOT NULL
+);
+
+CREATE INDEX sessions_user_id_idx ON sessions (user_id, last_seen_at DESC);
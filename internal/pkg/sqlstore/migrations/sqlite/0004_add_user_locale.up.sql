@@ -0,0 +1,2 @@
+-- 회원이 설정한 응답 메시지 언어 (ko, en), 비어있으면 Accept-Language 사용
+ALTER TABLE users ADD COLUMN locale TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- 소셜 로그인으로 가입해 회원이 정한 비밀번호가 없는지 여부, 비밀번호 수정 시 해제
+ALTER TABLE users ADD COLUMN passwordless BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,187 @@
+// Package tracing 은 OpenTelemetry 트레이스 설정과 span 기록
+//
+// 요청 (middleware.Tracing), 회원 usecase (user.Trace), MongoDB 호출 (user/persistence) 마다 span 을 남기고
+// 요청 헤더의 W3C traceparent 를 이어받아 상위 서비스의 트레이스에 연결
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/rest"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const serviceName = "signupin-api"
+
+// 전송 방식 (Options.Exporter)
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+)
+
+// span 속성
+const (
+	AttrUserID  = semconv.EnduserIDKey         // 회원 아이디
+	AttrOutcome = attribute.Key("app.outcome") // success, not_found, failure, error 혹은 응답 코드 (ex. NOT_FOUND_ERROR)
+)
+
+// 결과 (AttrOutcome)
+const (
+	OutcomeSuccess  = "success"
+	OutcomeNotFound = "not_found"
+	OutcomeFailure  = "failure" // 요청 오류 (4xx)
+	OutcomeError    = "error"
+)
+
+// Options 는 트레이스 전송 설정
+type Options struct {
+	Exporter    string  // none, otlp, stdout, file
+	Endpoint    string  // otlp: OTLP/HTTP 수집기 주소 (ex. http://localhost:4318)
+	File        string  // file: 기록할 파일 (없으면 생성, 있으면 이어서 기록)
+	SampleRatio float64 // 상위 서비스가 결정하지 않은 트레이스 중 기록할 비율
+}
+
+// Setup 은 전역 TracerProvider 와 W3C trace-context propagator 설정
+// 반환한 함수는 남은 span 을 전송하고 exporter 를 종료 (서버 종료 시 호출)
+// Exporter 가 none 이면 span 을 기록하지 않지만 traceparent 는 이어받음
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var (
+		exporter sdktrace.SpanExporter
+		closer   func() error
+		err      error
+	)
+
+	switch opts.Exporter {
+	case ExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		exporter, err = newOTLPExporter(ctx, opts.Endpoint)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case ExporterFile:
+		var f *os.File
+		f, err = os.OpenFile(opts.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		closer = f.Close
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", opts.Exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName)))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			if cerr := closer(); err == nil {
+				err = cerr
+			}
+		}
+		return err
+	}, nil
+}
+
+// newOTLPExporter 는 endpoint (http(s)://host:port[/path]) 로 전송하는 OTLP/HTTP exporter 생성
+func newOTLPExporter(ctx context.Context, endpoint string) (sdktrace.SpanExporter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || len(u.Host) == 0 {
+		return nil, errors.New("invalid OTLP endpoint " + endpoint)
+	}
+
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(u.Host)}
+	if u.Scheme == "http" {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if len(u.Path) > 1 {
+		opts = append(opts, otlptracehttp.WithURLPath(u.Path))
+	}
+
+	return otlptracehttp.New(ctx, opts...)
+}
+
+// Tracer 는 API 서버의 tracer (Setup 전에는 기록하지 않는 tracer)
+func Tracer() trace.Tracer {
+	return otel.Tracer(serviceName)
+}
+
+// Start 는 ctx 의 span 아래에 name 으로 새 span 시작
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartMongo 는 MongoDB 호출 span 시작 (ex. "mongo users.findOne")
+func StartMongo(ctx context.Context, database, collection, operation string) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, "mongo "+collection+"."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemMongoDB,
+			semconv.DBNameKey.String(database),
+			semconv.DBMongoDBCollectionKey.String(collection),
+			semconv.DBOperationKey.String(operation),
+		))
+}
+
+// End 는 err 에 따라 결과를 기록하고 span 종료, 없는 데이터 (not found) 는 오류로 표시하지 않음
+func End(span trace.Span, err error) {
+	switch {
+	case err == nil:
+		span.SetAttributes(AttrOutcome.String(OutcomeSuccess))
+	case errortype.IsNotFoundErr(err), errors.Is(err, mongo.ErrNoDocuments):
+		span.SetAttributes(AttrOutcome.String(OutcomeNotFound))
+	default:
+		span.SetAttributes(AttrOutcome.String(OutcomeError))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// EndWithCode 는 usecase 결과를 기록하고 span 종료
+// 실패한 경우 응답 코드를 결과로 남기고, 5xx 응답 코드만 오류로 표시
+func EndWithCode(span trace.Span, err *rest.CustomError) {
+	if err == nil {
+		span.SetAttributes(AttrOutcome.String(OutcomeSuccess))
+		span.End()
+		return
+	}
+
+	span.SetAttributes(AttrOutcome.String(err.CodeDesc.Code))
+	if err.CodeDesc.HttpStatusCode >= 500 {
+		span.SetStatus(codes.Error, err.CodeDesc.Code+": "+err.Message)
+	}
+	span.End()
+}
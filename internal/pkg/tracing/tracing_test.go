@@ -0,0 +1,51 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+)
+
+func TestSetupFileExporter(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	path := filepath.Join(t.TempDir(), "traces.json")
+	shutdown, err := Setup(context.Background(), Options{Exporter: ExporterFile, File: path, SampleRatio: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, span := StartMongo(context.Background(), "kkodecaffeine", "users", "findOne")
+	End(span, errors.New("connection reset"))
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"Name":"mongo users.findOne"`,
+		`{"Key":"db.mongodb.collection","Value":{"Type":"STRING","Value":"users"}}`,
+		`{"Key":"app.outcome","Value":{"Type":"STRING","Value":"error"}}`,
+		`"Status":{"Code":"Error","Description":"connection reset"}`,
+	} {
+		if !strings.Contains(string(raw), want) {
+			t.Errorf("trace file does not contain %s:\n%s", want, raw)
+		}
+	}
+}
+
+func TestSetupRejectsUnknownExporter(t *testing.T) {
+	if _, err := Setup(context.Background(), Options{Exporter: "jaeger"}); err == nil {
+		t.Fatal("want error")
+	}
+}
@@ -1,6 +1,8 @@
 package user
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"signupin-api/internal/app/api/dto"
 	"strings"
@@ -17,6 +19,10 @@ type User struct {
 	NickName         string `json:"nickname" bson:"nickname"` // 닉네임
 	Password         string `json:"password" bson:"password"` // 비밀번호
 	Phone            string `json:"phone" bson:"phone"`       // 전화번혼
+	Locale           string `json:"locale" bson:"locale"`     // 응답 메시지 언어 (ko, en), 비어있으면 Accept-Language
+
+	PasswordResetRequired bool `json:"password_reset_required" bson:"password_reset_required"` // 비밀번호 재설정 전까지 비밀번호 수정 API 만 허용
+	Passwordless          bool `json:"passwordless" bson:"passwordless"`                       // 외부 인증으로 가입해 회원이 정한 비밀번호가 없음 (비밀번호 수정 시 해제)
 }
 
 type AuthNumber struct {
@@ -36,6 +42,36 @@ func newUser(req *dto.PostSignUpRequest, authnumber string) *User {
 		NickName: req.NickName,
 		Password: req.Password,
 		Phone:    req.Phone,
+		Locale:   req.Locale,
+	}
+}
+
+// newProvisionedUser 는 외부 인증으로 가입한 회원을 생성
+// 비밀번호는 임의의 값으로 설정되므로 비밀번호 수정 전까지 비밀번호 로그인은 불가능
+func newProvisionedUser(email, name string) *User {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return &User{
+		Email:    email,
+		Name:     name,
+		NickName: name,
+		Password: hex.EncodeToString(b),
+
+		Passwordless: true,
+	}
+}
+
+func newUserRegistered(user *User, provisioned bool) UserRegistered {
+	return UserRegistered{
+		UserID:      user.ID.Hex(),
+		Email:       user.Email,
+		Name:        user.Name,
+		NickName:    user.NickName,
+		Phone:       user.Phone,
+		Provisioned: provisioned,
 	}
 }
 
@@ -0,0 +1,52 @@
+package user
+
+import "signupin-api/internal/pkg/outbox"
+
+// 회원 도메인 이벤트 종류 (outbox.Message.Type)
+const (
+	EventUserRegistered        = "user.registered"
+	EventPasswordChanged       = "user.password_changed"
+	EventPasswordResetRequired = "user.password_reset_required"
+	EventUserDeleted           = "user.deleted"
+)
+
+// UserRegistered 는 회원 가입 (소셜 로그인 최초 접속 포함)
+type UserRegistered struct {
+	UserID      string `json:"user_id"`
+	Email       string `json:"email"`
+	Name        string `json:"name"`
+	NickName    string `json:"nickname"`
+	Phone       string `json:"phone,omitempty"`
+	Provisioned bool   `json:"provisioned"` // 외부 인증으로 가입 (비밀번호 로그인 불가)
+}
+
+func (UserRegistered) EventType() string { return EventUserRegistered }
+
+// PasswordChanged 는 비밀번호 수정
+type PasswordChanged struct {
+	UserID string `json:"user_id"`
+}
+
+func (PasswordChanged) EventType() string { return EventPasswordChanged }
+
+// PasswordResetRequired 는 "본인이 아닙니다" 신고 등으로 다음 로그인부터 비밀번호 재설정이 필요해진 경우
+type PasswordResetRequired struct {
+	UserID string `json:"user_id"`
+}
+
+func (PasswordResetRequired) EventType() string { return EventPasswordResetRequired }
+
+// UserDeleted 는 회원 탈퇴
+type UserDeleted struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+func (UserDeleted) EventType() string { return EventUserDeleted }
+
+var (
+	_ outbox.Event = UserRegistered{}
+	_ outbox.Event = PasswordChanged{}
+	_ outbox.Event = PasswordResetRequired{}
+	_ outbox.Event = UserDeleted{}
+)
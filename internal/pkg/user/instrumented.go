@@ -0,0 +1,69 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/pkg/metrics"
+	"signupin-api/internal/pkg/outbox"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// instrumentedRepository 는 저장소 호출 시간과 실패를 지표로 기록
+type instrumentedRepository struct {
+	next Repository
+}
+
+func (r *instrumentedRepository) SaveOne(ctx context.Context, model *User, events ...*outbox.Message) (_ string, err error) {
+	defer metrics.ObserveRepository("user", "SaveOne", time.Now(), &err)
+	return r.next.SaveOne(ctx, model, events...)
+}
+
+func (r *instrumentedRepository) GetAuthNumber(ctx context.Context) (_ string, err error) {
+	defer metrics.ObserveRepository("user", "GetAuthNumber", time.Now(), &err)
+	return r.next.GetAuthNumber(ctx)
+}
+
+func (r *instrumentedRepository) GetOne(ctx context.Context, identifier string, password ...string) (_ *dto.GetUserWithTokenResponse, err error) {
+	defer metrics.ObserveRepository("user", "GetOne", time.Now(), &err)
+	return r.next.GetOne(ctx, identifier, password...)
+}
+
+func (r *instrumentedRepository) GetOneByID(ctx context.Context, ID string) (_ *dto.GetUserResponse, err error) {
+	defer metrics.ObserveRepository("user", "GetOneByID", time.Now(), &err)
+	return r.next.GetOneByID(ctx, ID)
+}
+
+func (r *instrumentedRepository) UpdatePassword(ctx context.Context, ID primitive.ObjectID, newpassword string, events ...*outbox.Message) (_ *dto.GetUserResponse, err error) {
+	defer metrics.ObserveRepository("user", "UpdatePassword", time.Now(), &err)
+	return r.next.UpdatePassword(ctx, ID, newpassword, events...)
+}
+
+func (r *instrumentedRepository) RequirePasswordReset(ctx context.Context, ID primitive.ObjectID, events ...*outbox.Message) (err error) {
+	defer metrics.ObserveRepository("user", "RequirePasswordReset", time.Now(), &err)
+	return r.next.RequirePasswordReset(ctx, ID, events...)
+}
+
+func (r *instrumentedRepository) UpdateLocale(ctx context.Context, ID primitive.ObjectID, locale string) (_ *dto.GetUserResponse, err error) {
+	defer metrics.ObserveRepository("user", "UpdateLocale", time.Now(), &err)
+	return r.next.UpdateLocale(ctx, ID, locale)
+}
+
+func (r *instrumentedRepository) UpsertAuthNumber(ctx context.Context, model *AuthNumber) (_ string, err error) {
+	defer metrics.ObserveRepository("user", "UpsertAuthNumber", time.Now(), &err)
+	return r.next.UpsertAuthNumber(ctx, model)
+}
+
+func (r *instrumentedRepository) DeleteOne(ctx context.Context, ID primitive.ObjectID, events ...*outbox.Message) (err error) {
+	defer metrics.ObserveRepository("user", "DeleteOne", time.Now(), &err)
+	return r.next.DeleteOne(ctx, ID, events...)
+}
+
+// Instrument 는 호출 시간과 실패를 지표 (/metrics) 로 기록하는 저장소 반환
+func Instrument(repo Repository) Repository {
+	return &instrumentedRepository{next: repo}
+}
+
+var _ Repository = &instrumentedRepository{}
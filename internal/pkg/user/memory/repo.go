@@ -0,0 +1,350 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/pkg/outbox"
+	"signupin-api/internal/pkg/user"
+
+	"github.com/kamva/mgm/v3"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// userRepo 는 DB 없이 실행하기 위한 user.Repository 구현 (테스트, 로컬 개발용)
+// persistence.userRepo 와 동일하게 조회 결과가 없으면 errortype.IsNotFoundErr 로 확인 가능한 오류 반환
+// 회원 변경과 이벤트는 같은 잠금 안에서 저장하고, outbox.Repository 로 이벤트를 전달
+type userRepo struct {
+	mu         sync.RWMutex
+	users      []user.User // 저장 순서 유지 (MongoDB 의 natural order 와 동일하게 먼저 저장된 회원부터 조회)
+	authnumber *user.AuthNumber
+	messages   []outbox.Message
+}
+
+var (
+	_ user.Repository   = &userRepo{}
+	_ outbox.Repository = &userRepo{}
+)
+
+func (r *userRepo) SaveOne(ctx context.Context, model *user.User, events ...*outbox.Message) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if model.ID.IsZero() {
+		model.ID = primitive.NewObjectID()
+	}
+	now := time.Now().UTC()
+	model.CreatedAt = now
+	model.UpdatedAt = now
+
+	r.users = append(r.users, *model)
+	r.append(events)
+
+	insertedID := utils.MapToStringID(model.ID)
+	return insertedID, nil
+}
+
+func (r *userRepo) GetAuthNumber(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.authnumber == nil {
+		return "", errortype.NotFoundError(mgm.CollName(&user.AuthNumber{}), bson.D{}, nil, nil)
+	}
+
+	return r.authnumber.AuthNumber, nil
+}
+
+func (r *userRepo) GetOne(ctx context.Context, identifier string, password ...string) (*dto.GetUserWithTokenResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i := range r.users {
+		found := &r.users[i]
+		if len(password) == 0 {
+			if found.Email == identifier {
+				return toDomainProps2(found), nil
+			}
+		} else if found.Password == password[0] && (found.Email == identifier || found.Phone == identifier) {
+			return toDomainProps2(found), nil
+		}
+	}
+
+	return nil, errortype.NotFoundError(mgm.CollName(&user.User{}), bson.M{"identifier": identifier}, nil, nil)
+}
+
+func (r *userRepo) GetOneByID(ctx context.Context, ID string) (*dto.GetUserResponse, error) {
+	objectID, err := utils.MapToObjectID(ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	found := r.find(objectID)
+	if found == nil {
+		return nil, errortype.NotFoundError(mgm.CollName(&user.User{}), bson.M{"_id": objectID}, nil, nil)
+	}
+
+	return toDomainProps(found), nil
+}
+
+func (r *userRepo) UpdatePassword(ctx context.Context, ID primitive.ObjectID, newpassword string, events ...*outbox.Message) (*dto.GetUserResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	found := r.find(ID)
+	if found == nil {
+		return nil, errortype.NotFoundError(mgm.CollName(&user.User{}), bson.M{"_id": ID}, nil, nil)
+	}
+
+	found.Password = newpassword
+	found.PasswordResetRequired = false
+	found.Passwordless = false
+	found.UpdatedAt = time.Now().UTC()
+	r.append(events)
+
+	return toDomainProps(found), nil
+}
+
+func (r *userRepo) RequirePasswordReset(ctx context.Context, ID primitive.ObjectID, events ...*outbox.Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	found := r.find(ID)
+	if found == nil {
+		update := bson.M{"$set": bson.M{"password_reset_required": true}}
+		return errortype.NotFoundError(mgm.CollName(&user.User{}), bson.M{"_id": ID}, update, nil)
+	}
+
+	found.PasswordResetRequired = true
+	found.UpdatedAt = time.Now().UTC()
+	r.append(events)
+
+	return nil
+}
+
+func (r *userRepo) UpdateLocale(ctx context.Context, ID primitive.ObjectID, locale string) (*dto.GetUserResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	found := r.find(ID)
+	if found == nil {
+		update := bson.M{"$set": bson.M{"locale": locale}}
+		return nil, errortype.NotFoundError(mgm.CollName(&user.User{}), bson.M{"_id": ID}, update, nil)
+	}
+
+	found.Locale = locale
+	found.UpdatedAt = time.Now().UTC()
+
+	return toDomainProps(found), nil
+}
+
+// UpsertAuthNumber 는 persistence.userRepo 와 동일하게 인증번호를 하나만 유지
+func (r *userRepo) UpsertAuthNumber(ctx context.Context, model *user.AuthNumber) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	saved := *model
+	saved.UpdatedAt = time.Now().UTC()
+	if r.authnumber == nil {
+		saved.CreatedAt = saved.UpdatedAt
+	} else {
+		saved.CreatedAt = r.authnumber.CreatedAt
+	}
+	r.authnumber = &saved
+
+	return model.AuthNumber, nil
+}
+
+func (r *userRepo) DeleteOne(ctx context.Context, ID primitive.ObjectID, events ...*outbox.Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.users {
+		if r.users[i].ID == ID {
+			r.users = append(r.users[:i], r.users[i+1:]...)
+			r.append(events)
+			return nil
+		}
+	}
+
+	return errortype.NotFoundError(mgm.CollName(&user.User{}), bson.M{"_id": ID}, nil, nil)
+}
+
+func (r *userRepo) GetPending(ctx context.Context, now time.Time, limit int64) ([]outbox.Message, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	found := []outbox.Message{}
+	for _, message := range r.messages {
+		if int64(len(found)) == limit {
+			break
+		}
+		if message.PublishedAt == nil && !message.NextAttemptAt.After(now) {
+			found = append(found, message)
+		}
+	}
+	return found, nil
+}
+
+func (r *userRepo) Claim(ctx context.Context, ID string, attempts int, until time.Time) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	message := r.findMessage(ID)
+	if message == nil || message.Attempts != attempts || message.PublishedAt != nil {
+		return false, nil
+	}
+	message.Attempts++
+	message.NextAttemptAt = until
+	message.UpdatedAt = time.Now().UTC()
+	return true, nil
+}
+
+func (r *userRepo) MarkPublished(ctx context.Context, ID string, publishedAt time.Time) error {
+	return r.updateMessage(ctx, ID, func(message *outbox.Message) {
+		message.PublishedAt = &publishedAt
+		message.LastError = ""
+	})
+}
+
+func (r *userRepo) MarkFailed(ctx context.Context, ID string, nextAttemptAt time.Time, reason string) error {
+	return r.updateMessage(ctx, ID, func(message *outbox.Message) {
+		message.NextAttemptAt = nextAttemptAt
+		message.LastError = reason
+	})
+}
+
+func (r *userRepo) updateMessage(ctx context.Context, ID string, update func(message *outbox.Message)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	message := r.findMessage(ID)
+	if message == nil {
+		return errortype.NotFoundError(mgm.CollName(&outbox.Message{}), bson.M{"_id": ID}, nil, nil)
+	}
+	update(message)
+	message.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// append 는 잠금을 잡은 상태에서 회원 변경과 함께 호출
+func (r *userRepo) append(events []*outbox.Message) {
+	now := time.Now().UTC()
+	for _, event := range events {
+		event.CreatedAt = now
+		event.UpdatedAt = now
+		r.messages = append(r.messages, *event)
+	}
+}
+
+// findMessage 는 잠금을 잡은 상태에서 호출
+func (r *userRepo) findMessage(ID string) *outbox.Message {
+	for i := range r.messages {
+		if r.messages[i].ID.Hex() == ID {
+			return &r.messages[i]
+		}
+	}
+	return nil
+}
+
+// find 는 잠금을 잡은 상태에서 호출
+func (r *userRepo) find(ID primitive.ObjectID) *user.User {
+	for i := range r.users {
+		if r.users[i].ID == ID {
+			return &r.users[i]
+		}
+	}
+	return nil
+}
+
+func toDomainProps(model *user.User) *dto.GetUserResponse {
+	return &dto.GetUserResponse{
+		Id:       utils.MapToStringID(model.ID),
+		Email:    model.Email,
+		Name:     model.Name,
+		NickName: model.NickName,
+		Phone:    model.Phone,
+		Locale:   model.Locale,
+
+		Passwordless:          model.Passwordless,
+		PasswordResetRequired: model.PasswordResetRequired,
+	}
+}
+
+func toDomainProps2(model *user.User) *dto.GetUserWithTokenResponse {
+	return &dto.GetUserWithTokenResponse{
+		Id:                    utils.MapToStringID(model.ID),
+		Email:                 model.Email,
+		Name:                  model.Name,
+		NickName:              model.NickName,
+		Phone:                 model.Phone,
+		Locale:                model.Locale,
+		PasswordResetRequired: model.PasswordResetRequired,
+	}
+}
+
+func New() user.Repository {
+	return &userRepo{}
+}
+
+// NewWithOutbox 는 회원 저장소와 같은 저장 공간의 이벤트를 전달하는 outbox.Repository 를 함께 반환
+func NewWithOutbox() (user.Repository, outbox.Repository) {
+	repo := &userRepo{}
+	return repo, repo
+}
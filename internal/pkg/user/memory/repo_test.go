@@ -0,0 +1,22 @@
+package memory_test
+
+import (
+	"testing"
+
+	"signupin-api/internal/pkg/outbox"
+	"signupin-api/internal/pkg/user"
+	"signupin-api/internal/pkg/user/memory"
+	"signupin-api/internal/pkg/user/usertest"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	usertest.RunRepositoryTests(t, func(t *testing.T) user.Repository {
+		return memory.New()
+	})
+}
+
+func TestMemoryOutbox(t *testing.T) {
+	usertest.RunOutboxTests(t, func(t *testing.T) (user.Repository, outbox.Repository) {
+		return memory.NewWithOutbox()
+	})
+}
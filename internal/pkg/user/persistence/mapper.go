@@ -20,6 +20,10 @@ func (e entityMapper) toDomainProps(ID primitive.ObjectID, model *user.User) *dt
 		Name:     model.Name,
 		NickName: model.NickName,
 		Phone:    model.Phone,
+		Locale:   model.Locale,
+
+		Passwordless:          model.Passwordless,
+		PasswordResetRequired: model.PasswordResetRequired,
 	}
 }
 
@@ -28,10 +32,12 @@ func (e entityMapper) toDomainProps2(ID primitive.ObjectID, model *user.User) *d
 	id := utils.MapToStringID(ID)
 
 	return &dto.GetUserWithTokenResponse{
-		Id:       id,
-		Email:    model.Email,
-		Name:     model.Name,
-		NickName: model.NickName,
-		Phone:    model.Phone,
+		Id:                    id,
+		Email:                 model.Email,
+		Name:                  model.Name,
+		NickName:              model.NickName,
+		Phone:                 model.Phone,
+		Locale:                model.Locale,
+		PasswordResetRequired: model.PasswordResetRequired,
 	}
 }
@@ -1,9 +1,15 @@
 package persistence
 
 import (
+	"context"
 	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/pkg/outbox"
+	"signupin-api/internal/pkg/reqctx"
+	"signupin-api/internal/pkg/tracing"
 	"signupin-api/internal/pkg/user"
 
+	outboxrepo "signupin-api/internal/pkg/outbox/persistence"
+
 	"github.com/kamva/mgm/v3"
 
 	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
@@ -21,9 +27,13 @@ type userRepo struct {
 
 var _ user.Repository = &userRepo{}
 
-func (r *userRepo) SaveOne(model *user.User) (string, error) {
+func (r *userRepo) SaveOne(ctx context.Context, model *user.User, events ...*outbox.Message) (string, error) {
 	coll := mgm.Coll(model)
-	err := coll.Create(model)
+	err := withEvents(ctx, events, func(ctx context.Context) error {
+		return traced(ctx, coll, "insertOne", func(ctx context.Context) error {
+			return coll.CreateWithCtx(ctx, model)
+		})
+	})
 	if err != nil {
 		return "", errortype.ParseAndReturnDBError(err, coll.Name(), nil, nil, nil)
 	}
@@ -32,19 +42,22 @@ func (r *userRepo) SaveOne(model *user.User) (string, error) {
 	return insertedID, nil
 }
 
-func (r *userRepo) GetAuthNumber() (string, error) {
+func (r *userRepo) GetAuthNumber(ctx context.Context) (string, error) {
 	found := &user.AuthNumber{}
 	filter := bson.D{}
 
-	err := mgm.Coll(found).FindOne(mgm.Ctx(), filter).Decode(found)
+	coll := mgm.Coll(found)
+	err := traced(ctx, coll, "findOne", func(ctx context.Context) error {
+		return coll.FindOne(ctx, filter, findOneOptions(ctx)).Decode(found)
+	})
 	if err != nil {
-		return "", errortype.ParseAndReturnDBError(err, mgm.CollName(found), filter, nil, nil)
+		return "", errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
 	}
 
 	return found.AuthNumber, nil
 }
 
-func (r *userRepo) GetOne(identifier string, password ...string) (*dto.GetUserWithTokenResponse, error) {
+func (r *userRepo) GetOne(ctx context.Context, identifier string, password ...string) (*dto.GetUserWithTokenResponse, error) {
 	found := &user.User{}
 	var filter primitive.M
 
@@ -63,9 +76,12 @@ func (r *userRepo) GetOne(identifier string, password ...string) (*dto.GetUserWi
 		}
 	}
 
-	err := mgm.Coll(found).FindOne(mgm.Ctx(), filter).Decode(found)
+	coll := mgm.Coll(found)
+	err := traced(ctx, coll, "findOne", func(ctx context.Context) error {
+		return coll.FindOne(ctx, filter, findOneOptions(ctx)).Decode(found)
+	})
 	if err != nil {
-		return nil, errortype.ParseAndReturnDBError(err, mgm.CollName(found), filter, nil, nil)
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
 	}
 
 	result := r.mapper.toDomainProps2(found.ID, found)
@@ -73,7 +89,7 @@ func (r *userRepo) GetOne(identifier string, password ...string) (*dto.GetUserWi
 	return result, nil
 }
 
-func (r *userRepo) GetOneByID(ID string) (*dto.GetUserResponse, error) {
+func (r *userRepo) GetOneByID(ctx context.Context, ID string) (*dto.GetUserResponse, error) {
 	objectID, err := utils.MapToObjectID(ID)
 	if err != nil {
 		return nil, err
@@ -83,7 +99,9 @@ func (r *userRepo) GetOneByID(ID string) (*dto.GetUserResponse, error) {
 	filter := bson.M{"_id": objectID}
 
 	coll := mgm.Coll(found)
-	err = coll.FindOne(mgm.Ctx(), filter).Decode(found)
+	err = traced(ctx, coll, "findOne", func(ctx context.Context) error {
+		return coll.FindOne(ctx, filter, findOneOptions(ctx)).Decode(found)
+	})
 	if err != nil {
 		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
 	}
@@ -93,16 +111,24 @@ func (r *userRepo) GetOneByID(ID string) (*dto.GetUserResponse, error) {
 	return result, nil
 }
 
-func (r *userRepo) UpdatePassword(ID primitive.ObjectID, newpassword string) (*dto.GetUserResponse, error) {
+func (r *userRepo) UpdatePassword(ctx context.Context, ID primitive.ObjectID, newpassword string, events ...*outbox.Message) (*dto.GetUserResponse, error) {
 	found := &user.User{}
 	filter := bson.D{{Key: "_id", Value: ID}}
 
 	coll := mgm.Coll(found)
-	coll.FindOne(mgm.Ctx(), filter).Decode(&found)
+	traced(ctx, coll, "findOne", func(ctx context.Context) error {
+		return coll.FindOne(ctx, filter, findOneOptions(ctx)).Decode(found)
+	})
 
 	found.Password = newpassword
-
-	err := coll.Update(found)
+	found.PasswordResetRequired = false
+	found.Passwordless = false
+
+	err := withEvents(ctx, events, func(ctx context.Context) error {
+		return traced(ctx, coll, "updateOne", func(ctx context.Context) error {
+			return coll.UpdateWithCtx(ctx, found)
+		})
+	})
 	if err != nil {
 		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), nil, nil, nil)
 	}
@@ -112,19 +138,66 @@ func (r *userRepo) UpdatePassword(ID primitive.ObjectID, newpassword string) (*d
 	return result, nil
 }
 
-func (r *userRepo) UpsertAuthNumber(model *user.AuthNumber) (string, error) {
+func (r *userRepo) RequirePasswordReset(ctx context.Context, ID primitive.ObjectID, events ...*outbox.Message) error {
+	filter := bson.M{"_id": ID}
+	update := bson.M{"$set": bson.M{"password_reset_required": true}}
+
+	coll := mgm.Coll(&user.User{})
+
+	err := withEvents(ctx, events, func(ctx context.Context) error {
+		var result *mongo.UpdateResult
+		err := traced(ctx, coll, "updateOne", func(ctx context.Context) (err error) {
+			result, err = coll.UpdateOne(ctx, filter, update)
+			return err
+		})
+		if err == nil && result.MatchedCount == 0 {
+			return mongo.ErrNoDocuments // 이벤트를 저장하지 않도록 트랜잭션 중단
+		}
+		return err
+	})
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), filter, update, nil)
+	}
+
+	return nil
+}
+
+func (r *userRepo) UpdateLocale(ctx context.Context, ID primitive.ObjectID, locale string) (*dto.GetUserResponse, error) {
+	found := &user.User{}
+	filter := bson.M{"_id": ID}
+	update := bson.M{"$set": bson.M{"locale": locale}}
+
+	coll := mgm.Coll(found)
+	err := traced(ctx, coll, "findOneAndUpdate", func(ctx context.Context) error {
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+		return coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(found)
+	})
+	if err != nil {
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, update, nil)
+	}
+
+	result := r.mapper.toDomainProps(found.ID, found)
+
+	return result, nil
+}
+
+func (r *userRepo) UpsertAuthNumber(ctx context.Context, model *user.AuthNumber) (string, error) {
 	found := &user.AuthNumber{}
 	filter := bson.D{}
 
 	coll := mgm.Coll(found)
-	coll.FindOne(mgm.Ctx(), filter).Decode(&found)
+	traced(ctx, coll, "findOne", func(ctx context.Context) error {
+		return coll.FindOne(ctx, filter, findOneOptions(ctx)).Decode(&found)
+	})
 
 	upsert := true
 	opt := options.UpdateOptions{
 		Upsert: &upsert,
 	}
 
-	err := coll.Update(model, &opt)
+	err := traced(ctx, coll, "updateOne", func(ctx context.Context) error {
+		return coll.UpdateWithCtx(ctx, model, &opt)
+	})
 	if err != nil {
 		return "", errortype.ParseAndReturnDBError(err, coll.Name(), nil, nil, nil)
 	}
@@ -132,6 +205,64 @@ func (r *userRepo) UpsertAuthNumber(model *user.AuthNumber) (string, error) {
 	return model.AuthNumber, nil
 }
 
+func (r *userRepo) DeleteOne(ctx context.Context, ID primitive.ObjectID, events ...*outbox.Message) error {
+	filter := bson.M{"_id": ID}
+
+	coll := mgm.Coll(&user.User{})
+
+	err := withEvents(ctx, events, func(ctx context.Context) error {
+		var result *mongo.DeleteResult
+		err := traced(ctx, coll, "deleteOne", func(ctx context.Context) (err error) {
+			result, err = coll.DeleteOne(ctx, filter)
+			return err
+		})
+		if err == nil && result.DeletedCount == 0 {
+			return mongo.ErrNoDocuments
+		}
+		return err
+	})
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return nil
+}
+
+// withEvents 는 write 와 이벤트 저장을 한 트랜잭션으로 실행 (MongoDB 트랜잭션은 replica set 필요)
+// 이벤트가 없으면 트랜잭션 없이 write 만 실행
+func withEvents(ctx context.Context, events []*outbox.Message, write func(ctx context.Context) error) error {
+	if len(events) == 0 {
+		return write(ctx)
+	}
+
+	return mgm.TransactionWithCtx(ctx, func(session mongo.Session, sc mongo.SessionContext) error {
+		if err := write(sc); err != nil {
+			return err
+		}
+		if err := outboxrepo.Insert(sc, events); err != nil {
+			return err
+		}
+		return session.CommitTransaction(sc)
+	})
+}
+
+// traced 는 MongoDB 호출 하나를 span 으로 감쌈 (ex. "mongo users.findOne")
+func traced(ctx context.Context, coll *mgm.Collection, operation string, call func(ctx context.Context) error) error {
+	ctx, span := tracing.StartMongo(ctx, coll.Database().Name(), coll.Name(), operation)
+	err := call(ctx)
+	tracing.End(span, err)
+	return err
+}
+
+// findOneOptions 는 요청 아이디 / 회원 아이디를 쿼리 comment 로 남김
+func findOneOptions(ctx context.Context) *options.FindOneOptions {
+	opts := options.FindOne()
+	if comment := reqctx.Comment(ctx); len(comment) > 0 {
+		opts.SetComment(comment)
+	}
+	return opts
+}
+
 func New(client *mongo.Client) user.Repository {
 	return &userRepo{client, entityMapper{}}
 }
@@ -0,0 +1,28 @@
+package persistence_test
+
+import (
+	"testing"
+
+	"signupin-api/internal/pkg/outbox"
+	"signupin-api/internal/pkg/storetest"
+	"signupin-api/internal/pkg/user"
+	"signupin-api/internal/pkg/user/persistence"
+	"signupin-api/internal/pkg/user/usertest"
+
+	outboxrepo "signupin-api/internal/pkg/outbox/persistence"
+)
+
+func TestMongoRepository(t *testing.T) {
+	usertest.RunRepositoryTests(t, func(t *testing.T) user.Repository {
+		storetest.Mongo(t)
+		return persistence.New(nil)
+	})
+}
+
+// MongoDB 트랜잭션을 사용하므로 MONGO_TEST_URL 은 replica set 이어야 함
+func TestMongoOutbox(t *testing.T) {
+	usertest.RunOutboxTests(t, func(t *testing.T) (user.Repository, outbox.Repository) {
+		storetest.Mongo(t)
+		return persistence.New(nil), outboxrepo.New(nil)
+	})
+}
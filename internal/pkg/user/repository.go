@@ -1,21 +1,29 @@
 package user
 
 import (
+	"context"
 	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/pkg/outbox"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // Repository interface definition
+// 변경 메소드의 events 는 변경과 같은 쓰기 (트랜잭션) 로 outbox 에 저장, 둘 중 하나만 저장되는 경우는 없음
 type Repository interface {
-	SaveOne(model *User) (string, error)
+	SaveOne(ctx context.Context, model *User, events ...*outbox.Message) (string, error)
 
 	// GET
-	GetAuthNumber() (string, error)
-	GetOne(identifier string, password ...string) (*dto.GetUserWithTokenResponse, error)
-	GetOneByID(ID string) (*dto.GetUserResponse, error)
+	GetAuthNumber(ctx context.Context) (string, error)
+	GetOne(ctx context.Context, identifier string, password ...string) (*dto.GetUserWithTokenResponse, error)
+	GetOneByID(ctx context.Context, ID string) (*dto.GetUserResponse, error)
 
 	// UPDATE
-	UpdatePassword(ID primitive.ObjectID, newpassword string) (*dto.GetUserResponse, error)
-	UpsertAuthNumber(model *AuthNumber) (string, error)
+	UpdatePassword(ctx context.Context, ID primitive.ObjectID, newpassword string, events ...*outbox.Message) (*dto.GetUserResponse, error)
+	RequirePasswordReset(ctx context.Context, ID primitive.ObjectID, events ...*outbox.Message) error
+	UpdateLocale(ctx context.Context, ID primitive.ObjectID, locale string) (*dto.GetUserResponse, error)
+	UpsertAuthNumber(ctx context.Context, model *AuthNumber) (string, error)
+
+	// DELETE
+	DeleteOne(ctx context.Context, ID primitive.ObjectID, events ...*outbox.Message) error
 }
@@ -0,0 +1,249 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/pkg/outbox"
+	"signupin-api/internal/pkg/sqlstore"
+	"signupin-api/internal/pkg/user"
+
+	outboxsql "signupin-api/internal/pkg/outbox/sqlrepo"
+
+	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	usersTable       = "users"
+	authNumbersTable = "auth_numbers"
+	authNumberID     = 1 // 인증번호는 하나만 유지
+
+	userColumns = "id, email, name, nickname, password, phone, locale, password_reset_required, passwordless, created_at, updated_at"
+)
+
+// userRepo 는 Postgres / SQLite 에 저장하는 user.Repository 구현
+// 회원 아이디는 MongoDB 저장소와 같은 ObjectID (hex) 형식 사용
+type userRepo struct {
+	db *sqlstore.DB
+}
+
+var _ user.Repository = &userRepo{}
+
+func (r *userRepo) SaveOne(ctx context.Context, model *user.User, events ...*outbox.Message) (string, error) {
+	if model.ID.IsZero() {
+		model.ID = primitive.NewObjectID()
+	}
+	now := sqlstore.Now()
+	model.CreatedAt = now
+	model.UpdatedAt = now
+
+	query := r.db.Rebind("INSERT INTO users (" + userColumns + ") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	err := r.withEvents(ctx, events, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, query,
+			model.ID.Hex(), model.Email, model.Name, model.NickName, model.Password, model.Phone,
+			model.Locale, model.PasswordResetRequired, model.Passwordless, model.CreatedAt, model.UpdatedAt,
+		)
+		return err
+	})
+	if err != nil {
+		return "", sqlstore.MapError(err, usersTable, nil, nil, model)
+	}
+
+	insertedID := utils.MapToStringID(model.ID)
+	return insertedID, nil
+}
+
+func (r *userRepo) GetAuthNumber(ctx context.Context) (string, error) {
+	var authnumber string
+
+	query := r.db.Rebind("SELECT authnumber FROM auth_numbers WHERE id = ?")
+	err := r.db.QueryRowContext(ctx, query, authNumberID).Scan(&authnumber)
+	if err != nil {
+		return "", sqlstore.MapError(err, authNumbersTable, bson.D{}, nil, nil)
+	}
+
+	return authnumber, nil
+}
+
+func (r *userRepo) GetOne(ctx context.Context, identifier string, password ...string) (*dto.GetUserWithTokenResponse, error) {
+	var row *sql.Row
+	var filter bson.M
+
+	if len(password) == 0 {
+		filter = bson.M{"email": identifier}
+		query := r.db.Rebind("SELECT " + userColumns + " FROM users WHERE email = ?")
+		row = r.db.QueryRowContext(ctx, query, identifier)
+	} else {
+		filter = bson.M{"identifier": identifier}
+		query := r.db.Rebind("SELECT " + userColumns + " FROM users WHERE password = ? AND (email = ? OR phone = ?) ORDER BY created_at LIMIT 1")
+		row = r.db.QueryRowContext(ctx, query, password[0], identifier, identifier)
+	}
+
+	found, err := scanUser(row)
+	if err != nil {
+		return nil, sqlstore.MapError(err, usersTable, filter, nil, nil)
+	}
+
+	return toDomainProps2(found), nil
+}
+
+func (r *userRepo) GetOneByID(ctx context.Context, ID string) (*dto.GetUserResponse, error) {
+	objectID, err := utils.MapToObjectID(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := r.findByID(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainProps(found), nil
+}
+
+func (r *userRepo) UpdatePassword(ctx context.Context, ID primitive.ObjectID, newpassword string, events ...*outbox.Message) (*dto.GetUserResponse, error) {
+	query := r.db.Rebind("UPDATE users SET password = ?, password_reset_required = ?, passwordless = ?, updated_at = ? WHERE id = ?")
+	err := r.withEvents(ctx, events, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, query, newpassword, false, false, sqlstore.Now(), ID.Hex())
+		return affected(result, err, bson.M{"_id": ID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := r.findByID(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainProps(found), nil
+}
+
+func (r *userRepo) RequirePasswordReset(ctx context.Context, ID primitive.ObjectID, events ...*outbox.Message) error {
+	query := r.db.Rebind("UPDATE users SET password_reset_required = ?, updated_at = ? WHERE id = ?")
+	return r.withEvents(ctx, events, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, query, true, sqlstore.Now(), ID.Hex())
+		return affected(result, err, bson.M{"_id": ID})
+	})
+}
+
+func (r *userRepo) UpdateLocale(ctx context.Context, ID primitive.ObjectID, locale string) (*dto.GetUserResponse, error) {
+	query := r.db.Rebind("UPDATE users SET locale = ?, updated_at = ? WHERE id = ?")
+	result, err := r.db.ExecContext(ctx, query, locale, sqlstore.Now(), ID.Hex())
+	if err := affected(result, err, bson.M{"_id": ID}); err != nil {
+		return nil, err
+	}
+
+	found, err := r.findByID(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainProps(found), nil
+}
+
+func (r *userRepo) UpsertAuthNumber(ctx context.Context, model *user.AuthNumber) (string, error) {
+	now := sqlstore.Now()
+
+	query := r.db.Rebind(`INSERT INTO auth_numbers (id, authnumber, created_at, updated_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET authnumber = excluded.authnumber, updated_at = excluded.updated_at`)
+	_, err := r.db.ExecContext(ctx, query, authNumberID, model.AuthNumber, now, now)
+	if err != nil {
+		return "", sqlstore.MapError(err, authNumbersTable, nil, nil, model)
+	}
+
+	return model.AuthNumber, nil
+}
+
+func (r *userRepo) DeleteOne(ctx context.Context, ID primitive.ObjectID, events ...*outbox.Message) error {
+	query := r.db.Rebind("DELETE FROM users WHERE id = ?")
+	return r.withEvents(ctx, events, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, query, ID.Hex())
+		return affected(result, err, bson.M{"_id": ID})
+	})
+}
+
+// withEvents 는 write 와 이벤트 저장을 한 트랜잭션으로 실행
+func (r *userRepo) withEvents(ctx context.Context, events []*outbox.Message, write func(tx *sql.Tx) error) error {
+	return r.db.InTx(ctx, func(tx *sql.Tx) error {
+		if err := write(tx); err != nil {
+			return err
+		}
+		return outboxsql.Insert(ctx, r.db, tx, events)
+	})
+}
+
+func (r *userRepo) findByID(ctx context.Context, ID primitive.ObjectID) (*user.User, error) {
+	query := r.db.Rebind("SELECT " + userColumns + " FROM users WHERE id = ?")
+	found, err := scanUser(r.db.QueryRowContext(ctx, query, ID.Hex()))
+	if err != nil {
+		return nil, sqlstore.MapError(err, usersTable, bson.M{"_id": ID}, nil, nil)
+	}
+	return found, nil
+}
+
+func scanUser(row *sql.Row) (*user.User, error) {
+	found := &user.User{}
+	var id string
+
+	err := row.Scan(&id, &found.Email, &found.Name, &found.NickName, &found.Password, &found.Phone,
+		&found.Locale, &found.PasswordResetRequired, &found.Passwordless, &found.CreatedAt, &found.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	found.ID, err = primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// affected 는 UPDATE 결과 변경된 회원이 없으면 not found 오류 반환
+func affected(result sql.Result, err error, filter bson.M) error {
+	if err != nil {
+		return sqlstore.MapError(err, usersTable, filter, nil, nil)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return sqlstore.MapError(err, usersTable, filter, nil, nil)
+	}
+	if count == 0 {
+		return sqlstore.MapError(sql.ErrNoRows, usersTable, filter, nil, nil)
+	}
+	return nil
+}
+
+func toDomainProps(model *user.User) *dto.GetUserResponse {
+	return &dto.GetUserResponse{
+		Id:       utils.MapToStringID(model.ID),
+		Email:    model.Email,
+		Name:     model.Name,
+		NickName: model.NickName,
+		Phone:    model.Phone,
+		Locale:   model.Locale,
+
+		Passwordless:          model.Passwordless,
+		PasswordResetRequired: model.PasswordResetRequired,
+	}
+}
+
+func toDomainProps2(model *user.User) *dto.GetUserWithTokenResponse {
+	return &dto.GetUserWithTokenResponse{
+		Id:                    utils.MapToStringID(model.ID),
+		Email:                 model.Email,
+		Name:                  model.Name,
+		NickName:              model.NickName,
+		Phone:                 model.Phone,
+		Locale:                model.Locale,
+		PasswordResetRequired: model.PasswordResetRequired,
+	}
+}
+
+func New(db *sqlstore.DB) user.Repository {
+	return &userRepo{db}
+}
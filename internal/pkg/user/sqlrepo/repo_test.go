@@ -0,0 +1,40 @@
+package sqlrepo_test
+
+import (
+	"testing"
+
+	"signupin-api/internal/pkg/outbox"
+	"signupin-api/internal/pkg/sqlstore"
+	"signupin-api/internal/pkg/storetest"
+	"signupin-api/internal/pkg/user"
+	"signupin-api/internal/pkg/user/sqlrepo"
+	"signupin-api/internal/pkg/user/usertest"
+
+	outboxsql "signupin-api/internal/pkg/outbox/sqlrepo"
+)
+
+func TestSQLiteRepository(t *testing.T) {
+	usertest.RunRepositoryTests(t, func(t *testing.T) user.Repository {
+		return sqlrepo.New(storetest.SQL(t, sqlstore.SQLite))
+	})
+}
+
+func TestPostgresRepository(t *testing.T) {
+	usertest.RunRepositoryTests(t, func(t *testing.T) user.Repository {
+		return sqlrepo.New(storetest.SQL(t, sqlstore.Postgres))
+	})
+}
+
+func TestSQLiteOutbox(t *testing.T) {
+	usertest.RunOutboxTests(t, func(t *testing.T) (user.Repository, outbox.Repository) {
+		db := storetest.SQL(t, sqlstore.SQLite)
+		return sqlrepo.New(db), outboxsql.New(db)
+	})
+}
+
+func TestPostgresOutbox(t *testing.T) {
+	usertest.RunOutboxTests(t, func(t *testing.T) (user.Repository, outbox.Repository) {
+		db := storetest.SQL(t, sqlstore.Postgres)
+		return sqlrepo.New(db), outboxsql.New(db)
+	})
+}
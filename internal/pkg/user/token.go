@@ -0,0 +1,21 @@
+package user
+
+import (
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+// generateToken 은 회원 아이디만 담은 JWT 를 발급 (go-common utils.GenerateToken 과 같은 claims)
+// 로그인 API 는 이 토큰 대신 세션에 묶인 토큰 (session.Usecase.Start) 으로 응답
+func generateToken(userID string, secret []byte) (string, error) {
+	claims := jwt.MapClaims{}
+
+	claims["authorized"] = true
+	claims["user_id"] = userID
+	claims["exp"] = time.Now().Add(time.Minute * 1).Unix()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	return token.SignedString(secret)
+}
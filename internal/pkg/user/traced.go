@@ -0,0 +1,122 @@
+package user
+
+import (
+	"context"
+
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/pkg/tracing"
+
+	"github.com/kkodecaffeine/go-common/rest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedUsecase 는 usecase 메소드마다 span 을 남김 (회원 아이디, 결과)
+// 이메일 / 전화번호 / 비밀번호 / 인증번호는 span 에 남기지 않음
+type tracedUsecase struct {
+	next Usecase
+}
+
+func startSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "user.Usecase/"+method)
+}
+
+func setUserID(span trace.Span, ID string) {
+	if len(ID) > 0 {
+		span.SetAttributes(tracing.AttrUserID.String(ID))
+	}
+}
+
+func (u *tracedUsecase) SaveOne(ctx context.Context, req *dto.PostSignUpRequest) (string, *rest.CustomError) {
+	ctx, span := startSpan(ctx, "SaveOne")
+	insertedID, err := u.next.SaveOne(ctx, req)
+	setUserID(span, insertedID)
+	tracing.EndWithCode(span, err)
+	return insertedID, err
+}
+
+func (u *tracedUsecase) Provision(ctx context.Context, email, name string) (string, *rest.CustomError) {
+	ctx, span := startSpan(ctx, "Provision")
+	insertedID, err := u.next.Provision(ctx, email, name)
+	setUserID(span, insertedID)
+	tracing.EndWithCode(span, err)
+	return insertedID, err
+}
+
+func (u *tracedUsecase) GetAuthNumber(ctx context.Context) (string, *rest.CustomError) {
+	ctx, span := startSpan(ctx, "GetAuthNumber")
+	authnumber, err := u.next.GetAuthNumber(ctx)
+	tracing.EndWithCode(span, err)
+	return authnumber, err
+}
+
+func (u *tracedUsecase) GetOne(ctx context.Context, identifier string, password ...string) (*dto.GetUserWithTokenResponse, *rest.CustomError) {
+	ctx, span := startSpan(ctx, "GetOne")
+	found, err := u.next.GetOne(ctx, identifier, password...)
+	if found != nil {
+		setUserID(span, found.Id)
+	}
+	tracing.EndWithCode(span, err)
+	return found, err
+}
+
+func (u *tracedUsecase) GetOneByID(ctx context.Context, ID string) (*dto.GetUserResponse, *rest.CustomError) {
+	ctx, span := startSpan(ctx, "GetOneByID")
+	setUserID(span, ID)
+	found, err := u.next.GetOneByID(ctx, ID)
+	tracing.EndWithCode(span, err)
+	return found, err
+}
+
+func (u *tracedUsecase) IssueToken(ctx context.Context, ID string) (*dto.GetUserWithTokenResponse, *rest.CustomError) {
+	ctx, span := startSpan(ctx, "IssueToken")
+	setUserID(span, ID)
+	found, err := u.next.IssueToken(ctx, ID)
+	tracing.EndWithCode(span, err)
+	return found, err
+}
+
+func (u *tracedUsecase) UpdatePassword(ctx context.Context, authnumber, ID, newpassword string) (*dto.GetUserResponse, *rest.CustomError) {
+	ctx, span := startSpan(ctx, "UpdatePassword")
+	setUserID(span, ID)
+	updated, err := u.next.UpdatePassword(ctx, authnumber, ID, newpassword)
+	tracing.EndWithCode(span, err)
+	return updated, err
+}
+
+func (u *tracedUsecase) RequirePasswordReset(ctx context.Context, ID string) *rest.CustomError {
+	ctx, span := startSpan(ctx, "RequirePasswordReset")
+	setUserID(span, ID)
+	err := u.next.RequirePasswordReset(ctx, ID)
+	tracing.EndWithCode(span, err)
+	return err
+}
+
+func (u *tracedUsecase) UpdateLocale(ctx context.Context, ID, locale string) (*dto.GetUserResponse, *rest.CustomError) {
+	ctx, span := startSpan(ctx, "UpdateLocale")
+	setUserID(span, ID)
+	updated, err := u.next.UpdateLocale(ctx, ID, locale)
+	tracing.EndWithCode(span, err)
+	return updated, err
+}
+
+func (u *tracedUsecase) UpsertAuthNumber(ctx context.Context) (string, *rest.CustomError) {
+	ctx, span := startSpan(ctx, "UpsertAuthNumber")
+	authnumber, err := u.next.UpsertAuthNumber(ctx)
+	tracing.EndWithCode(span, err)
+	return authnumber, err
+}
+
+func (u *tracedUsecase) DeleteOne(ctx context.Context, authnumber, ID string) *rest.CustomError {
+	ctx, span := startSpan(ctx, "DeleteOne")
+	setUserID(span, ID)
+	err := u.next.DeleteOne(ctx, authnumber, ID)
+	tracing.EndWithCode(span, err)
+	return err
+}
+
+// Trace 는 메소드마다 span 을 남기는 Usecase 반환
+func Trace(uc Usecase) Usecase {
+	return &tracedUsecase{next: uc}
+}
+
+var _ Usecase = &tracedUsecase{}
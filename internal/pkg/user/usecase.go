@@ -1,40 +1,92 @@
 package user
 
 import (
+	"context"
 	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/pkg/metrics"
+	"signupin-api/internal/pkg/outbox"
+	"time"
 
 	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
 	"github.com/kkodecaffeine/go-common/errorcode"
 	"github.com/kkodecaffeine/go-common/rest"
 	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// MessageAuthNumberMismatch 는 인증번호가 틀린 경우의 오류 메시지 (감사 로그에서 인증번호 확인 실패 구분)
+const MessageAuthNumberMismatch = "auth number mismatch"
+
 // UseCase interface definition
 type Usecase interface {
-	SaveOne(req *dto.PostSignUpRequest) (string, *rest.CustomError)
+	SaveOne(ctx context.Context, req *dto.PostSignUpRequest) (string, *rest.CustomError)
+	Provision(ctx context.Context, email, name string) (string, *rest.CustomError)
 
 	// GET
-	GetAuthNumber() (string, *rest.CustomError)
-	GetOne(identifier string, password ...string) (*dto.GetUserWithTokenResponse, *rest.CustomError)
-	GetOneByID(ID string) (*dto.GetUserResponse, *rest.CustomError)
+	GetAuthNumber(ctx context.Context) (string, *rest.CustomError)
+	GetOne(ctx context.Context, identifier string, password ...string) (*dto.GetUserWithTokenResponse, *rest.CustomError)
+	GetOneByID(ctx context.Context, ID string) (*dto.GetUserResponse, *rest.CustomError)
+	IssueToken(ctx context.Context, ID string) (*dto.GetUserWithTokenResponse, *rest.CustomError)
 
 	// UPDATE
-	UpdatePassword(authnumber, ID, newpassword string) (*dto.GetUserResponse, *rest.CustomError)
-	UpsertAuthNumber() (string, *rest.CustomError)
+	UpdatePassword(ctx context.Context, authnumber, ID, newpassword string) (*dto.GetUserResponse, *rest.CustomError)
+	RequirePasswordReset(ctx context.Context, ID string) *rest.CustomError
+	UpdateLocale(ctx context.Context, ID, locale string) (*dto.GetUserResponse, *rest.CustomError)
+	UpsertAuthNumber(ctx context.Context) (string, *rest.CustomError)
+
+	// DELETE
+	DeleteOne(ctx context.Context, authnumber, ID string) *rest.CustomError
 }
 
 type usecase struct {
-	repo Repository
+	repo   Repository
+	secret []byte // 회원 JWT 서명 키 (API_SECRET)
 }
 
-func (u *usecase) SaveOne(req *dto.PostSignUpRequest) (string, *rest.CustomError) {
-	authnumber, _ := u.repo.GetAuthNumber()
+func (u *usecase) SaveOne(ctx context.Context, req *dto.PostSignUpRequest) (string, *rest.CustomError) {
+	authnumber, _ := u.repo.GetAuthNumber(ctx)
 	user := newUser(req, authnumber)
 	if user == nil {
-		return "", &rest.CustomError{CodeDesc: &errorcode.BAD_REQUEST, Message: "auth number mismatch"}
+		metrics.CodeFailed(metrics.CodeAuthNumber)
+		return "", &rest.CustomError{CodeDesc: &errorcode.BAD_REQUEST, Message: MessageAuthNumberMismatch}
+	}
+	metrics.CodeVerified(metrics.CodeAuthNumber)
+
+	// 회원 아이디를 이벤트에 담기 위해 저장 전에 정함
+	user.ID = primitive.NewObjectID()
+	event, cerr := newEvent(user.ID.Hex(), newUserRegistered(user, false))
+	if cerr != nil {
+		return "", cerr
+	}
+
+	insertedID, err := u.repo.SaveOne(ctx, user, event)
+	if err != nil {
+		if errortype.IsDecodeError(err) {
+			return "", &rest.CustomError{CodeDesc: &errorcode.FAILED_DB_PROCESSING, Message: err.Error()}
+		} else if errortype.IsNotFoundErr(err) {
+			return "", &rest.CustomError{CodeDesc: &errorcode.NOT_FOUND_ERROR, Message: err.Error()}
+		} else {
+			return "", &rest.CustomError{CodeDesc: &errorcode.FAILED_INTERNAL_ERROR, Message: err.Error()}
+		}
+	}
+	return insertedID, nil
+}
+
+// Provision 은 외부 인증 (소셜 로그인) 으로 최초 접속한 회원을 인증번호 확인 없이 가입 처리
+func (u *usecase) Provision(ctx context.Context, email, name string) (string, *rest.CustomError) {
+	exists, _ := u.repo.GetOne(ctx, email) // 이미 가입한 회원인지 확인
+	if exists != nil {
+		return "", &rest.CustomError{CodeDesc: &errorcode.AUTH_EMAIL_ALREADY_EXISTS, Message: email}
+	}
+
+	user := newProvisionedUser(email, name)
+	user.ID = primitive.NewObjectID()
+	event, cerr := newEvent(user.ID.Hex(), newUserRegistered(user, true))
+	if cerr != nil {
+		return "", cerr
 	}
 
-	insertedID, err := u.repo.SaveOne(user)
+	insertedID, err := u.repo.SaveOne(ctx, user, event)
 	if err != nil {
 		if errortype.IsDecodeError(err) {
 			return "", &rest.CustomError{CodeDesc: &errorcode.FAILED_DB_PROCESSING, Message: err.Error()}
@@ -47,8 +99,8 @@ func (u *usecase) SaveOne(req *dto.PostSignUpRequest) (string, *rest.CustomError
 	return insertedID, nil
 }
 
-func (u *usecase) GetAuthNumber() (string, *rest.CustomError) {
-	authnumber, err := u.repo.GetAuthNumber()
+func (u *usecase) GetAuthNumber(ctx context.Context) (string, *rest.CustomError) {
+	authnumber, err := u.repo.GetAuthNumber(ctx)
 	if err != nil {
 		if errortype.IsDecodeError(err) {
 			return "", &rest.CustomError{CodeDesc: &errorcode.FAILED_DB_PROCESSING, Message: err.Error()}
@@ -62,14 +114,14 @@ func (u *usecase) GetAuthNumber() (string, *rest.CustomError) {
 	return authnumber, nil
 }
 
-func (u *usecase) GetOne(identifier string, password ...string) (*dto.GetUserWithTokenResponse, *rest.CustomError) {
+func (u *usecase) GetOne(ctx context.Context, identifier string, password ...string) (*dto.GetUserWithTokenResponse, *rest.CustomError) {
 	var response *dto.GetUserWithTokenResponse
 	var err error
 
 	if len(password) == 0 {
-		response, err = u.repo.GetOne(identifier)
+		response, err = u.repo.GetOne(ctx, identifier)
 	} else {
-		response, err = u.repo.GetOne(identifier, password[0])
+		response, err = u.repo.GetOne(ctx, identifier, password[0])
 	}
 
 	if err != nil {
@@ -85,7 +137,7 @@ func (u *usecase) GetOne(identifier string, password ...string) (*dto.GetUserWit
 	if response == nil {
 		return response, &rest.CustomError{CodeDesc: &errorcode.NOT_FOUND_ERROR, Message: ""}
 	} else {
-		token, err := utils.GenerateToken(response.Id)
+		token, err := generateToken(response.Id, u.secret)
 
 		if err != nil {
 			return response, &rest.CustomError{CodeDesc: &errorcode.ACCESS_DENIED, Message: err.Error()}
@@ -96,8 +148,8 @@ func (u *usecase) GetOne(identifier string, password ...string) (*dto.GetUserWit
 	return response, nil
 }
 
-func (u *usecase) GetOneByID(ID string) (*dto.GetUserResponse, *rest.CustomError) {
-	response, err := u.repo.GetOneByID(ID)
+func (u *usecase) GetOneByID(ctx context.Context, ID string) (*dto.GetUserResponse, *rest.CustomError) {
+	response, err := u.repo.GetOneByID(ctx, ID)
 	if err != nil {
 		if errortype.IsDecodeError(err) {
 			return response, &rest.CustomError{CodeDesc: &errorcode.FAILED_DB_PROCESSING, Message: err.Error()}
@@ -119,15 +171,93 @@ func (u *usecase) GetOneByID(ID string) (*dto.GetUserResponse, *rest.CustomError
 	return response, nil
 }
 
-func (u *usecase) UpdatePassword(reqauth, ID, newpassword string) (*dto.GetUserResponse, *rest.CustomError) {
-	authnumber, _ := u.repo.GetAuthNumber()
+// IssueToken 은 비밀번호 확인 없이 회원 토큰을 발급 (소셜 로그인 등 외부 인증을 마친 경우)
+func (u *usecase) IssueToken(ctx context.Context, ID string) (*dto.GetUserWithTokenResponse, *rest.CustomError) {
+	found, err := u.GetOneByID(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, terr := generateToken(found.Id, u.secret)
+	if terr != nil {
+		return nil, &rest.CustomError{CodeDesc: &errorcode.ACCESS_DENIED, Message: terr.Error()}
+	}
+	metrics.TokenIssued(metrics.TokenUser)
+
+	return &dto.GetUserWithTokenResponse{
+		AccessToken: token,
+		Id:          found.Id,
+		Email:       found.Email,
+		NickName:    found.NickName,
+		Name:        found.Name,
+		Phone:       found.Phone,
+		Locale:      found.Locale,
+
+		PasswordResetRequired: found.PasswordResetRequired,
+	}, nil
+}
+
+func (u *usecase) UpdatePassword(ctx context.Context, reqauth, ID, newpassword string) (*dto.GetUserResponse, *rest.CustomError) {
+	authnumber, _ := u.repo.GetAuthNumber(ctx)
 	if !compareAuthNumber(reqauth, authnumber) {
-		return nil, &rest.CustomError{CodeDesc: &errorcode.BAD_REQUEST, Message: "auth number mismatch"}
+		metrics.CodeFailed(metrics.CodeAuthNumber)
+		return nil, &rest.CustomError{CodeDesc: &errorcode.BAD_REQUEST, Message: MessageAuthNumberMismatch}
 	}
+	metrics.CodeVerified(metrics.CodeAuthNumber)
 
 	objectID, _ := utils.MapToObjectID(ID)
 
-	response, err := u.repo.UpdatePassword(objectID, newpassword)
+	event, cerr := newEvent(ID, PasswordChanged{UserID: ID})
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	response, err := u.repo.UpdatePassword(ctx, objectID, newpassword, event)
+	if err != nil {
+		if errortype.IsDecodeError(err) {
+			return response, &rest.CustomError{CodeDesc: &errorcode.FAILED_DB_PROCESSING, Message: err.Error()}
+		} else if errortype.IsNotFoundErr(err) {
+			return response, &rest.CustomError{CodeDesc: &errorcode.NOT_FOUND_ERROR, Message: err.Error()}
+		} else {
+			return response, &rest.CustomError{CodeDesc: &errorcode.FAILED_INTERNAL_ERROR, Message: err.Error()}
+		}
+	}
+	return response, nil
+}
+
+// RequirePasswordReset 은 다음 로그인부터 비밀번호 수정 전까지 다른 API 를 사용할 수 없도록 함
+func (u *usecase) RequirePasswordReset(ctx context.Context, ID string) *rest.CustomError {
+	objectID, err := utils.MapToObjectID(ID)
+	if err != nil {
+		return &rest.CustomError{CodeDesc: &errorcode.INVALID_PARAMETERS, Message: err.Error()}
+	}
+
+	event, cerr := newEvent(ID, PasswordResetRequired{UserID: ID})
+	if cerr != nil {
+		return cerr
+	}
+
+	err = u.repo.RequirePasswordReset(ctx, objectID, event)
+	if err != nil {
+		if errortype.IsDecodeError(err) {
+			return &rest.CustomError{CodeDesc: &errorcode.FAILED_DB_PROCESSING, Message: err.Error()}
+		} else if errortype.IsNotFoundErr(err) {
+			return &rest.CustomError{CodeDesc: &errorcode.NOT_FOUND_ERROR, Message: err.Error()}
+		} else {
+			return &rest.CustomError{CodeDesc: &errorcode.FAILED_INTERNAL_ERROR, Message: err.Error()}
+		}
+	}
+	return nil
+}
+
+// UpdateLocale 은 응답 메시지 언어를 설정, 이후 로그인해서 발급한 토큰부터 적용
+func (u *usecase) UpdateLocale(ctx context.Context, ID, locale string) (*dto.GetUserResponse, *rest.CustomError) {
+	objectID, err := utils.MapToObjectID(ID)
+	if err != nil {
+		return nil, &rest.CustomError{CodeDesc: &errorcode.INVALID_PARAMETERS, Message: err.Error()}
+	}
+
+	response, err := u.repo.UpdateLocale(ctx, objectID, locale)
 	if err != nil {
 		if errortype.IsDecodeError(err) {
 			return response, &rest.CustomError{CodeDesc: &errorcode.FAILED_DB_PROCESSING, Message: err.Error()}
@@ -140,10 +270,10 @@ func (u *usecase) UpdatePassword(reqauth, ID, newpassword string) (*dto.GetUserR
 	return response, nil
 }
 
-func (u *usecase) UpsertAuthNumber() (string, *rest.CustomError) {
+func (u *usecase) UpsertAuthNumber(ctx context.Context) (string, *rest.CustomError) {
 	authnumber := newAuthNumber()
 
-	response, err := u.repo.UpsertAuthNumber(authnumber)
+	response, err := u.repo.UpsertAuthNumber(ctx, authnumber)
 	if err != nil {
 		if errortype.IsDecodeError(err) {
 			return response, &rest.CustomError{CodeDesc: &errorcode.FAILED_DB_PROCESSING, Message: err.Error()}
@@ -153,13 +283,62 @@ func (u *usecase) UpsertAuthNumber() (string, *rest.CustomError) {
 			return response, &rest.CustomError{CodeDesc: &errorcode.FAILED_INTERNAL_ERROR, Message: err.Error()}
 		}
 	}
+	metrics.CodeIssued(metrics.CodeAuthNumber)
 
 	return response, nil
 }
 
+// DeleteOne 은 인증번호 확인 후 회원 탈퇴 처리
+func (u *usecase) DeleteOne(ctx context.Context, reqauth, ID string) *rest.CustomError {
+	authnumber, _ := u.repo.GetAuthNumber(ctx)
+	if !compareAuthNumber(reqauth, authnumber) {
+		metrics.CodeFailed(metrics.CodeAuthNumber)
+		return &rest.CustomError{CodeDesc: &errorcode.BAD_REQUEST, Message: MessageAuthNumberMismatch}
+	}
+	metrics.CodeVerified(metrics.CodeAuthNumber)
+
+	objectID, err := utils.MapToObjectID(ID)
+	if err != nil {
+		return &rest.CustomError{CodeDesc: &errorcode.INVALID_PARAMETERS, Message: err.Error()}
+	}
+
+	// 다른 서비스가 이메일로 연결한 데이터를 정리할 수 있도록 이벤트에 포함
+	found, cerr := u.GetOneByID(ctx, ID)
+	if cerr != nil {
+		return cerr
+	}
+
+	event, cerr := newEvent(ID, UserDeleted{UserID: ID, Email: found.Email})
+	if cerr != nil {
+		return cerr
+	}
+
+	err = u.repo.DeleteOne(ctx, objectID, event)
+	if err != nil {
+		if errortype.IsDecodeError(err) {
+			return &rest.CustomError{CodeDesc: &errorcode.FAILED_DB_PROCESSING, Message: err.Error()}
+		} else if errortype.IsNotFoundErr(err) {
+			return &rest.CustomError{CodeDesc: &errorcode.NOT_FOUND_ERROR, Message: err.Error()}
+		} else {
+			return &rest.CustomError{CodeDesc: &errorcode.FAILED_INTERNAL_ERROR, Message: err.Error()}
+		}
+	}
+	return nil
+}
+
+// newEvent 는 회원 변경과 함께 outbox 에 저장할 메시지 생성
+func newEvent(userID string, event outbox.Event) (*outbox.Message, *rest.CustomError) {
+	message, err := outbox.NewMessage(userID, event, time.Now())
+	if err != nil {
+		return nil, &rest.CustomError{CodeDesc: &errorcode.FAILED_INTERNAL_ERROR, Message: err.Error()}
+	}
+	return message, nil
+}
+
 // NewUsecase returns new Usecase implementation
-func NewUsecase(userRepo Repository) Usecase {
-	return &usecase{repo: userRepo}
+// secret 은 회원 JWT 서명 키 (API_SECRET)
+func NewUsecase(userRepo Repository, secret string) Usecase {
+	return &usecase{repo: userRepo, secret: []byte(secret)}
 }
 
 var _ Usecase = &usecase{}
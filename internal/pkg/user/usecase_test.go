@@ -0,0 +1,295 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/pkg/outbox"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/errorcode"
+	"github.com/kkodecaffeine/go-common/rest"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeRepository 는 usecase 가 저장소 결과를 어떤 오류 코드로 바꾸는지 확인하기 위한 Repository
+// 필드에 반환값을 지정하고, 호출된 인자는 saved / updated 에 기록
+type fakeRepository struct {
+	authNumber    string
+	authNumberErr error
+
+	saveID  string
+	saveErr error
+	saved   *User
+
+	found    *dto.GetUserWithTokenResponse
+	foundErr error
+	lookups  [][]string
+
+	updated    *dto.GetUserResponse
+	updateErr  error
+	updatedID  primitive.ObjectID
+	updatedPwd string
+	locale     string
+
+	events []*outbox.Message
+}
+
+var _ Repository = &fakeRepository{}
+
+func (f *fakeRepository) SaveOne(ctx context.Context, model *User, events ...*outbox.Message) (string, error) {
+	f.saved = model
+	f.events = append(f.events, events...)
+	return f.saveID, f.saveErr
+}
+
+func (f *fakeRepository) GetAuthNumber(ctx context.Context) (string, error) {
+	return f.authNumber, f.authNumberErr
+}
+
+func (f *fakeRepository) GetOne(ctx context.Context, identifier string, password ...string) (*dto.GetUserWithTokenResponse, error) {
+	f.lookups = append(f.lookups, append([]string{identifier}, password...))
+	return f.found, f.foundErr
+}
+
+func (f *fakeRepository) GetOneByID(ctx context.Context, ID string) (*dto.GetUserResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRepository) UpdatePassword(ctx context.Context, ID primitive.ObjectID, newpassword string, events ...*outbox.Message) (*dto.GetUserResponse, error) {
+	f.updatedID, f.updatedPwd = ID, newpassword
+	f.events = append(f.events, events...)
+	return f.updated, f.updateErr
+}
+
+func (f *fakeRepository) RequirePasswordReset(ctx context.Context, ID primitive.ObjectID, events ...*outbox.Message) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeRepository) UpdateLocale(ctx context.Context, ID primitive.ObjectID, locale string) (*dto.GetUserResponse, error) {
+	f.updatedID, f.locale = ID, locale
+	return f.updated, f.updateErr
+}
+
+func (f *fakeRepository) UpsertAuthNumber(ctx context.Context, model *AuthNumber) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (f *fakeRepository) DeleteOne(ctx context.Context, ID primitive.ObjectID, events ...*outbox.Message) error {
+	return errors.New("not implemented")
+}
+
+var errNotFound = errortype.NotFoundError("users", nil, nil, nil)
+
+func signUpRequest(authnumber string) *dto.PostSignUpRequest {
+	return &dto.PostSignUpRequest{
+		AuthNumber: authnumber,
+		Email:      "kim@example.com",
+		Name:       "김회원",
+		NickName:   "kim",
+		Password:   "password1234",
+		Phone:      "01012345678",
+	}
+}
+
+func assertCode(t *testing.T, got *rest.CustomError, want *errorcode.CodeDescription) {
+	t.Helper()
+
+	if want == nil {
+		if got != nil {
+			t.Fatalf("unexpected error: %s %s", got.CodeDesc.Code, got.Message)
+		}
+		return
+	}
+	if got == nil {
+		t.Fatalf("got no error, want %s", want.Code)
+	}
+	if got.CodeDesc.Code != want.Code {
+		t.Fatalf("got %s (%s), want %s", got.CodeDesc.Code, got.Message, want.Code)
+	}
+}
+
+// assertEvent 는 저장소에 이벤트 하나가 함께 전달되었는지 확인
+func assertEvent(t *testing.T, repo *fakeRepository, eventType, userID string) {
+	t.Helper()
+
+	if len(repo.events) != 1 {
+		t.Fatalf("got %d events, want 1", len(repo.events))
+	}
+	event := repo.events[0]
+	if event.Type != eventType || event.AggregateID != userID || event.ID.IsZero() {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+
+	var payload struct {
+		UserID string `json:"user_id"`
+	}
+	if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil || payload.UserID != userID {
+		t.Fatalf("unexpected payload: %s", event.Payload)
+	}
+}
+
+func TestUsecaseSaveOne(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("saves user when auth number matches", func(t *testing.T) {
+		repo := &fakeRepository{authNumber: "123456", saveID: "63a1f0c2e4b0a1b2c3d4e5f6"}
+
+		insertedID, err := NewUsecase(repo, "test-secret").SaveOne(ctx, signUpRequest("123456"))
+		assertCode(t, err, nil)
+		if insertedID != repo.saveID {
+			t.Fatalf("got %s, want %s", insertedID, repo.saveID)
+		}
+		if repo.saved == nil || repo.saved.Email != "kim@example.com" || repo.saved.Phone != "01012345678" || repo.saved.Password != "password1234" {
+			t.Fatalf("unexpected saved user: %+v", repo.saved)
+		}
+		assertEvent(t, repo, EventUserRegistered, repo.saved.ID.Hex())
+		if strings.Contains(repo.events[0].Payload, "password1234") {
+			t.Fatalf("event contains password: %s", repo.events[0].Payload)
+		}
+	})
+
+	t.Run("rejects auth number mismatch without saving", func(t *testing.T) {
+		repo := &fakeRepository{authNumber: "123456"}
+
+		_, err := NewUsecase(repo, "test-secret").SaveOne(ctx, signUpRequest("654321"))
+		assertCode(t, err, &errorcode.BAD_REQUEST)
+		if repo.saved != nil || len(repo.events) > 0 {
+			t.Fatal("user saved despite auth number mismatch")
+		}
+	})
+
+	t.Run("rejects sign up when auth number was never issued", func(t *testing.T) {
+		repo := &fakeRepository{authNumberErr: errNotFound}
+
+		_, err := NewUsecase(repo, "test-secret").SaveOne(ctx, signUpRequest("123456"))
+		assertCode(t, err, &errorcode.BAD_REQUEST)
+	})
+
+	t.Run("maps unexpected repository error", func(t *testing.T) {
+		repo := &fakeRepository{authNumber: "123456", saveErr: errors.New("connection refused")}
+
+		_, err := NewUsecase(repo, "test-secret").SaveOne(ctx, signUpRequest("123456"))
+		assertCode(t, err, &errorcode.FAILED_INTERNAL_ERROR)
+	})
+}
+
+func TestUsecaseGetOne(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns user with access token", func(t *testing.T) {
+		repo := &fakeRepository{found: &dto.GetUserWithTokenResponse{Id: "63a1f0c2e4b0a1b2c3d4e5f6", Email: "kim@example.com"}}
+
+		found, err := NewUsecase(repo, "test-secret").GetOne(ctx, "kim@example.com", "password1234")
+		assertCode(t, err, nil)
+		if found.Id != repo.found.Id || len(found.AccessToken) == 0 {
+			t.Fatalf("unexpected user: %+v", found)
+		}
+		if len(repo.lookups) != 1 || len(repo.lookups[0]) != 2 || repo.lookups[0][1] != "password1234" {
+			t.Fatalf("password not passed to repository: %v", repo.lookups)
+		}
+	})
+
+	t.Run("looks up without password", func(t *testing.T) {
+		repo := &fakeRepository{found: &dto.GetUserWithTokenResponse{Id: "63a1f0c2e4b0a1b2c3d4e5f6"}}
+
+		_, err := NewUsecase(repo, "test-secret").GetOne(ctx, "kim@example.com")
+		assertCode(t, err, nil)
+		if len(repo.lookups) != 1 || len(repo.lookups[0]) != 1 {
+			t.Fatalf("unexpected lookup: %v", repo.lookups)
+		}
+	})
+
+	t.Run("maps not found", func(t *testing.T) {
+		repo := &fakeRepository{foundErr: errNotFound}
+
+		_, err := NewUsecase(repo, "test-secret").GetOne(ctx, "kim@example.com", "wrong-password")
+		assertCode(t, err, &errorcode.NOT_FOUND_ERROR)
+	})
+
+	t.Run("treats empty result as not found", func(t *testing.T) {
+		_, err := NewUsecase(&fakeRepository{}, "test-secret").GetOne(ctx, "kim@example.com")
+		assertCode(t, err, &errorcode.NOT_FOUND_ERROR)
+	})
+
+	t.Run("maps unexpected repository error", func(t *testing.T) {
+		repo := &fakeRepository{foundErr: errors.New("connection refused")}
+
+		_, err := NewUsecase(repo, "test-secret").GetOne(ctx, "kim@example.com")
+		assertCode(t, err, &errorcode.FAILED_INTERNAL_ERROR)
+	})
+}
+
+func TestUsecaseUpdatePassword(t *testing.T) {
+	ctx := context.Background()
+	ID := primitive.NewObjectID()
+
+	t.Run("updates password when auth number matches", func(t *testing.T) {
+		repo := &fakeRepository{authNumber: "123456", updated: &dto.GetUserResponse{Id: ID.Hex()}}
+
+		updated, err := NewUsecase(repo, "test-secret").UpdatePassword(ctx, "123456", ID.Hex(), "new-password")
+		assertCode(t, err, nil)
+		if updated.Id != ID.Hex() {
+			t.Fatalf("got %s, want %s", updated.Id, ID.Hex())
+		}
+		if repo.updatedID != ID || repo.updatedPwd != "new-password" {
+			t.Fatalf("unexpected update: %s %s", repo.updatedID.Hex(), repo.updatedPwd)
+		}
+		assertEvent(t, repo, EventPasswordChanged, ID.Hex())
+	})
+
+	t.Run("rejects auth number mismatch without updating", func(t *testing.T) {
+		repo := &fakeRepository{authNumber: "123456"}
+
+		_, err := NewUsecase(repo, "test-secret").UpdatePassword(ctx, "654321", ID.Hex(), "new-password")
+		assertCode(t, err, &errorcode.BAD_REQUEST)
+		if len(repo.updatedPwd) > 0 {
+			t.Fatal("password updated despite auth number mismatch")
+		}
+	})
+
+	t.Run("maps not found", func(t *testing.T) {
+		repo := &fakeRepository{authNumber: "123456", updateErr: errNotFound}
+
+		_, err := NewUsecase(repo, "test-secret").UpdatePassword(ctx, "123456", ID.Hex(), "new-password")
+		assertCode(t, err, &errorcode.NOT_FOUND_ERROR)
+	})
+
+	t.Run("maps unexpected repository error", func(t *testing.T) {
+		repo := &fakeRepository{authNumber: "123456", updateErr: errors.New("connection refused")}
+
+		_, err := NewUsecase(repo, "test-secret").UpdatePassword(ctx, "123456", ID.Hex(), "new-password")
+		assertCode(t, err, &errorcode.FAILED_INTERNAL_ERROR)
+	})
+}
+
+func TestUsecaseUpdateLocale(t *testing.T) {
+	ctx := context.Background()
+	ID := primitive.NewObjectID()
+
+	t.Run("updates locale", func(t *testing.T) {
+		repo := &fakeRepository{updated: &dto.GetUserResponse{Id: ID.Hex(), Locale: "en"}}
+
+		updated, err := NewUsecase(repo, "test-secret").UpdateLocale(ctx, ID.Hex(), "en")
+		assertCode(t, err, nil)
+		if updated.Locale != "en" || repo.updatedID != ID || repo.locale != "en" {
+			t.Fatalf("unexpected update: %+v %s %s", updated, repo.updatedID.Hex(), repo.locale)
+		}
+	})
+
+	t.Run("rejects invalid id", func(t *testing.T) {
+		_, err := NewUsecase(&fakeRepository{}, "test-secret").UpdateLocale(ctx, "not-an-id", "en")
+		assertCode(t, err, &errorcode.INVALID_PARAMETERS)
+	})
+
+	t.Run("maps not found", func(t *testing.T) {
+		repo := &fakeRepository{updateErr: errNotFound}
+
+		_, err := NewUsecase(repo, "test-secret").UpdateLocale(ctx, ID.Hex(), "en")
+		assertCode(t, err, &errorcode.NOT_FOUND_ERROR)
+	})
+}
@@ -0,0 +1,154 @@
+package usertest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"signupin-api/internal/pkg/outbox"
+	"signupin-api/internal/pkg/user"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NewRepositoryWithOutbox 는 테스트마다 비어있는 회원 저장소와 같은 DB 의 outbox 저장소 반환
+type NewRepositoryWithOutbox func(t *testing.T) (user.Repository, outbox.Repository)
+
+// RunOutboxTests 는 회원 변경과 이벤트가 함께 저장되고 (하나만 저장되는 경우 없음) Relay 가 이벤트를 가져갈 수 있는지 확인
+func RunOutboxTests(t *testing.T, newRepos NewRepositoryWithOutbox) {
+	ctx := context.Background()
+
+	t.Run("SaveOne stores event with user", func(t *testing.T) {
+		users, events := newRepos(t)
+		model := NewUser("kim@example.com", "01012345678")
+		model.ID = primitive.NewObjectID()
+		message := newMessage(t, model.ID.Hex())
+
+		if _, err := users.SaveOne(ctx, model, message); err != nil {
+			t.Fatal(err)
+		}
+
+		pending := getPending(t, events)
+		if len(pending) != 1 || pending[0].ID != message.ID || pending[0].Type != user.EventUserRegistered || pending[0].Payload != message.Payload {
+			t.Fatalf("unexpected pending events: %+v", pending)
+		}
+		if !pending[0].OccurredAt.Equal(message.OccurredAt) {
+			t.Fatalf("occurred at %s, want %s", pending[0].OccurredAt, message.OccurredAt)
+		}
+	})
+
+	t.Run("failed writes store no event", func(t *testing.T) {
+		users, events := newRepos(t)
+		save(t, users, NewUser("kim@example.com", "01012345678"))
+
+		if err := users.RequirePasswordReset(ctx, primitive.NewObjectID(), newMessage(t, "unknown")); !errortype.IsNotFoundErr(err) {
+			t.Fatalf("got %v, want not found", err)
+		}
+		if err := users.DeleteOne(ctx, primitive.NewObjectID(), newMessage(t, "unknown")); !errortype.IsNotFoundErr(err) {
+			t.Fatalf("got %v, want not found", err)
+		}
+
+		if pending := getPending(t, events); len(pending) != 0 {
+			t.Fatalf("got %d events for failed writes: %+v", len(pending), pending)
+		}
+	})
+
+	t.Run("updates and delete store events in order", func(t *testing.T) {
+		users, events := newRepos(t)
+		insertedID := save(t, users, NewUser("kim@example.com", "01012345678"))
+		objectID, _ := primitive.ObjectIDFromHex(insertedID)
+
+		if _, err := users.UpdatePassword(ctx, objectID, "new-password", newMessage(t, insertedID)); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(2 * time.Millisecond)
+		if err := users.RequirePasswordReset(ctx, objectID, newMessage(t, insertedID)); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(2 * time.Millisecond)
+		if err := users.DeleteOne(ctx, objectID, newMessage(t, insertedID)); err != nil {
+			t.Fatal(err)
+		}
+
+		pending := getPending(t, events)
+		if len(pending) != 3 {
+			t.Fatalf("got %d events, want 3", len(pending))
+		}
+		for i := 1; i < len(pending); i++ {
+			if pending[i].OccurredAt.Before(pending[i-1].OccurredAt) {
+				t.Fatalf("events are not ordered by occurred at: %+v", pending)
+			}
+		}
+	})
+
+	t.Run("Claim, MarkFailed and MarkPublished", func(t *testing.T) {
+		users, events := newRepos(t)
+		model := NewUser("kim@example.com", "01012345678")
+		model.ID = primitive.NewObjectID()
+		message := newMessage(t, model.ID.Hex())
+		if _, err := users.SaveOne(ctx, model, message); err != nil {
+			t.Fatal(err)
+		}
+		ID := message.ID.Hex()
+		now := time.Now()
+
+		claimed, err := events.Claim(ctx, ID, 0, now.Add(time.Minute))
+		if err != nil || !claimed {
+			t.Fatalf("first claim: got %v %v, want claimed", claimed, err)
+		}
+		// 다른 Relay 는 같은 시도 횟수로 가져갈 수 없음
+		if claimed, err := events.Claim(ctx, ID, 0, now.Add(time.Minute)); err != nil || claimed {
+			t.Fatalf("second claim: got %v %v, want not claimed", claimed, err)
+		}
+		if pending := getPending(t, events); len(pending) != 0 {
+			t.Fatalf("claimed event is pending: %+v", pending)
+		}
+
+		// 실패한 이벤트는 다음 시도 시각이 되면 다시 전달
+		if err := events.MarkFailed(ctx, ID, now.Add(-time.Second), "sink unavailable"); err != nil {
+			t.Fatal(err)
+		}
+		pending := getPending(t, events)
+		if len(pending) != 1 || pending[0].Attempts != 1 || pending[0].LastError != "sink unavailable" {
+			t.Fatalf("unexpected pending events after failure: %+v", pending)
+		}
+
+		if claimed, err := events.Claim(ctx, ID, 1, now.Add(time.Minute)); err != nil || !claimed {
+			t.Fatalf("retry claim: got %v %v, want claimed", claimed, err)
+		}
+		if err := events.MarkPublished(ctx, ID, now); err != nil {
+			t.Fatal(err)
+		}
+		if pending, err := events.GetPending(ctx, now.Add(time.Hour), 10); err != nil || len(pending) != 0 {
+			t.Fatalf("published event is pending: %+v %v", pending, err)
+		}
+		if claimed, err := events.Claim(ctx, ID, 2, now.Add(time.Minute)); err != nil || claimed {
+			t.Fatalf("claim after publish: got %v %v, want not claimed", claimed, err)
+		}
+
+		if err := events.MarkPublished(ctx, primitive.NewObjectID().Hex(), now); !errortype.IsNotFoundErr(err) {
+			t.Fatalf("unknown event: got %v, want not found", err)
+		}
+	})
+}
+
+func newMessage(t *testing.T, userID string) *outbox.Message {
+	t.Helper()
+
+	message, err := outbox.NewMessage(userID, user.UserRegistered{UserID: userID, Email: "kim@example.com"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return message
+}
+
+func getPending(t *testing.T, events outbox.Repository) []outbox.Message {
+	t.Helper()
+
+	pending, err := events.GetPending(context.Background(), time.Now(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pending
+}
@@ -0,0 +1,254 @@
+// Package usertest 는 user.Repository 구현체가 공통으로 통과해야 하는 테스트
+// 새로운 저장소를 추가하면 해당 패키지의 테스트에서 RunRepositoryTests 를 호출
+package usertest
+
+import (
+	"context"
+	"testing"
+
+	"signupin-api/internal/pkg/user"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NewRepository 는 테스트마다 비어있는 저장소 반환
+type NewRepository func(t *testing.T) user.Repository
+
+// RunRepositoryTests 는 저장소가 persistence.userRepo (MongoDB) 와 같은 동작을 하는지 확인
+func RunRepositoryTests(t *testing.T, newRepo NewRepository) {
+	ctx := context.Background()
+
+	t.Run("SaveOne returns ID usable by GetOneByID", func(t *testing.T) {
+		repo := newRepo(t)
+
+		insertedID := save(t, repo, NewUser("kim@example.com", "01012345678"))
+		if _, err := primitive.ObjectIDFromHex(insertedID); err != nil {
+			t.Fatalf("inserted ID %q is not an ObjectID: %v", insertedID, err)
+		}
+
+		found, err := repo.GetOneByID(ctx, insertedID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found.Id != insertedID || found.Email != "kim@example.com" || found.Phone != "01012345678" || found.NickName != "kim" || found.Name != "김회원" {
+			t.Fatalf("unexpected user: %+v", found)
+		}
+	})
+
+	t.Run("GetOne without password looks up by email", func(t *testing.T) {
+		repo := newRepo(t)
+		insertedID := save(t, repo, NewUser("kim@example.com", "01012345678"))
+
+		found, err := repo.GetOne(ctx, "kim@example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found.Id != insertedID {
+			t.Fatalf("got %s, want %s", found.Id, insertedID)
+		}
+
+		if _, err := repo.GetOne(ctx, "01012345678"); !errortype.IsNotFoundErr(err) {
+			t.Fatalf("lookup by phone without password: got %v, want not found", err)
+		}
+	})
+
+	t.Run("GetOne with password looks up by email or phone", func(t *testing.T) {
+		repo := newRepo(t)
+		insertedID := save(t, repo, NewUser("kim@example.com", "01012345678"))
+
+		for _, identifier := range []string{"kim@example.com", "01012345678"} {
+			found, err := repo.GetOne(ctx, identifier, Password)
+			if err != nil {
+				t.Fatalf("%s: %v", identifier, err)
+			}
+			if found.Id != insertedID || found.PasswordResetRequired {
+				t.Fatalf("%s: unexpected user %+v", identifier, found)
+			}
+		}
+
+		if _, err := repo.GetOne(ctx, "kim@example.com", "wrong-password"); !errortype.IsNotFoundErr(err) {
+			t.Fatalf("wrong password: got %v, want not found", err)
+		}
+		if _, err := repo.GetOne(ctx, "lee@example.com", Password); !errortype.IsNotFoundErr(err) {
+			t.Fatalf("unknown email: got %v, want not found", err)
+		}
+	})
+
+	t.Run("GetOneByID returns not found for unknown ID", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.GetOneByID(ctx, primitive.NewObjectID().Hex())
+		if !errortype.IsNotFoundErr(err) {
+			t.Fatalf("got %v, want not found", err)
+		}
+
+		if _, err := repo.GetOneByID(ctx, "not-an-object-id"); err == nil {
+			t.Fatal("invalid ID: want error")
+		}
+	})
+
+	t.Run("UpdatePassword replaces password and clears reset flag", func(t *testing.T) {
+		repo := newRepo(t)
+		insertedID := save(t, repo, NewUser("kim@example.com", "01012345678"))
+		objectID, _ := utils.MapToObjectID(insertedID)
+
+		if err := repo.RequirePasswordReset(ctx, objectID); err != nil {
+			t.Fatal(err)
+		}
+		found, err := repo.GetOne(ctx, "kim@example.com", Password)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !found.PasswordResetRequired {
+			t.Fatal("RequirePasswordReset did not set the flag")
+		}
+
+		updated, err := repo.UpdatePassword(ctx, objectID, "new-password")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated.Id != insertedID {
+			t.Fatalf("got %s, want %s", updated.Id, insertedID)
+		}
+
+		if _, err := repo.GetOne(ctx, "kim@example.com", Password); !errortype.IsNotFoundErr(err) {
+			t.Fatalf("old password: got %v, want not found", err)
+		}
+		found, err = repo.GetOne(ctx, "kim@example.com", "new-password")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found.PasswordResetRequired {
+			t.Fatal("UpdatePassword did not clear the reset flag")
+		}
+	})
+
+	t.Run("UpdatePassword clears passwordless flag", func(t *testing.T) {
+		repo := newRepo(t)
+		model := NewUser("kim@example.com", "01012345678")
+		model.Passwordless = true
+		insertedID := save(t, repo, model)
+		objectID, _ := utils.MapToObjectID(insertedID)
+
+		found, err := repo.GetOneByID(ctx, insertedID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !found.Passwordless {
+			t.Fatal("SaveOne did not store the passwordless flag")
+		}
+
+		updated, err := repo.UpdatePassword(ctx, objectID, "new-password")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated.Passwordless {
+			t.Fatal("UpdatePassword did not clear the passwordless flag")
+		}
+	})
+
+	t.Run("UpdateLocale stores the preference", func(t *testing.T) {
+		repo := newRepo(t)
+		insertedID := save(t, repo, NewUser("kim@example.com", "01012345678"))
+		objectID, _ := utils.MapToObjectID(insertedID)
+
+		updated, err := repo.UpdateLocale(ctx, objectID, "en")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated.Id != insertedID || updated.Locale != "en" {
+			t.Fatalf("unexpected update: %+v", updated)
+		}
+
+		found, err := repo.GetOne(ctx, "kim@example.com", Password)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found.Locale != "en" {
+			t.Fatalf("got %q, want en", found.Locale)
+		}
+
+		if _, err := repo.UpdateLocale(ctx, primitive.NewObjectID(), "en"); !errortype.IsNotFoundErr(err) {
+			t.Fatalf("unknown ID: got %v, want not found", err)
+		}
+	})
+
+	t.Run("RequirePasswordReset returns not found for unknown ID", func(t *testing.T) {
+		repo := newRepo(t)
+
+		if err := repo.RequirePasswordReset(ctx, primitive.NewObjectID()); !errortype.IsNotFoundErr(err) {
+			t.Fatalf("got %v, want not found", err)
+		}
+	})
+
+	t.Run("DeleteOne removes user", func(t *testing.T) {
+		repo := newRepo(t)
+		insertedID := save(t, repo, NewUser("kim@example.com", "01012345678"))
+		objectID, _ := primitive.ObjectIDFromHex(insertedID)
+
+		if err := repo.DeleteOne(ctx, objectID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.GetOneByID(ctx, insertedID); !errortype.IsNotFoundErr(err) {
+			t.Fatalf("got %v, want not found", err)
+		}
+		if err := repo.DeleteOne(ctx, objectID); !errortype.IsNotFoundErr(err) {
+			t.Fatalf("second delete: got %v, want not found", err)
+		}
+
+		// 탈퇴한 이메일로 다시 가입 가능
+		save(t, repo, NewUser("kim@example.com", "01012345678"))
+	})
+
+	t.Run("UpsertAuthNumber keeps only the latest number", func(t *testing.T) {
+		repo := newRepo(t)
+
+		if _, err := repo.GetAuthNumber(ctx); !errortype.IsNotFoundErr(err) {
+			t.Fatalf("empty store: got %v, want not found", err)
+		}
+
+		for _, number := range []string{"123456", "654321"} {
+			saved, err := repo.UpsertAuthNumber(ctx, &user.AuthNumber{AuthNumber: number})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if saved != number {
+				t.Fatalf("upsert returned %s, want %s", saved, number)
+			}
+
+			found, err := repo.GetAuthNumber(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if found != number {
+				t.Fatalf("got %s, want %s", found, number)
+			}
+		}
+	})
+}
+
+// Password 는 NewUser 로 만든 회원의 비밀번호
+const Password = "password1234"
+
+// NewUser 는 테스트용 회원
+func NewUser(email, phone string) *user.User {
+	return &user.User{
+		Email:    email,
+		Name:     "김회원",
+		NickName: "kim",
+		Password: Password,
+		Phone:    phone,
+	}
+}
+
+func save(t *testing.T, repo user.Repository, model *user.User) string {
+	t.Helper()
+
+	insertedID, err := repo.SaveOne(context.Background(), model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return insertedID
+}
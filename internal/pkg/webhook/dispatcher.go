@@ -0,0 +1,222 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"signupin-api/internal/pkg/metrics"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"golang.org/x/exp/slog"
+)
+
+// 기본 전달 설정 (Options 의 빈 값)
+const (
+	defaultInterval      = time.Second
+	defaultBatchSize     = 100
+	defaultTimeout       = 10 * time.Second
+	defaultMaxAttempts   = 8
+	defaultRetryInterval = 30 * time.Second
+	defaultMaxBackoff    = time.Hour
+)
+
+const (
+	userAgent       = "signupin-webhook/1"
+	maxErrorLength  = 256                // 로그에 남기는 응답 본문 길이
+	endpointDeleted = "endpoint deleted" // 삭제한 수신 주소의 Delivery 는 바로 dead
+)
+
+// Options 는 Dispatcher 의 전달 설정
+type Options struct {
+	Interval      time.Duration // 전달할 Delivery 를 찾는 주기
+	BatchSize     int64         // 한번에 가져올 Delivery 수
+	Timeout       time.Duration // 요청 하나의 제한 시간
+	MaxAttempts   int           // 이 횟수만큼 실패하면 dead
+	RetryInterval time.Duration // 첫 실패 후 대기 시간, 이후 두배씩 늘림
+	MaxBackoff    time.Duration // 재시도 대기 시간의 최대값
+}
+
+// Dispatcher 는 Delivery 를 수신 주소로 전달
+// 여러 인스턴스에서 실행해도 Claim 으로 Delivery 하나는 한 Dispatcher 만 가져감
+type Dispatcher struct {
+	repo   Repository
+	client *http.Client
+	opts   Options
+	now    func() time.Time
+}
+
+// NewDispatcher 는 repo 의 Delivery 를 client 로 전달하는 Dispatcher 반환 (client 가 nil 이면 http.DefaultClient)
+func NewDispatcher(repo Repository, client *http.Client, opts Options) *Dispatcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = defaultInterval
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaultMaxAttempts
+	}
+	if opts.RetryInterval <= 0 {
+		opts.RetryInterval = defaultRetryInterval
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = defaultMaxBackoff
+	}
+	return &Dispatcher{repo: repo, client: client, opts: opts, now: time.Now}
+}
+
+// Run 은 ctx 가 취소될 때까지 Interval 마다 Flush
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.Flush(ctx); err != nil && ctx.Err() == nil {
+			slog.WarnCtx(ctx, "dispatching webhooks failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush 는 지금 전달할 차례인 Delivery 를 한번씩 전달하고 성공한 수 반환
+// 전달 실패는 오류가 아니라 Delivery 의 상태 / 로그로 기록
+func (d *Dispatcher) Flush(ctx context.Context) (int, error) {
+	now := d.now()
+
+	due, err := d.repo.GetDue(ctx, now, d.opts.BatchSize)
+	if err != nil && !errortype.IsNotFoundErr(err) {
+		return 0, err
+	}
+
+	succeeded := 0
+	for i := range due {
+		delivery := &due[i]
+		ID := delivery.ID.Hex()
+
+		// 요청 제한 시간이 지나도록 결과를 기록하지 못하면 (ex. 인스턴스 종료) 다른 Dispatcher 가 다시 가져감
+		claimed, err := d.repo.Claim(ctx, ID, delivery.Attempts, now.Add(2*d.opts.Timeout))
+		if err != nil {
+			return succeeded, err
+		}
+		if !claimed {
+			continue
+		}
+		delivery.Attempts++
+
+		attempt := d.deliver(ctx, delivery)
+		status, next := d.result(delivery, attempt)
+		metrics.WebhookDelivered(delivery.EventType, status)
+
+		if status != StatusSucceeded {
+			slog.WarnCtx(ctx, "delivering webhook failed", "delivery_id", ID, "endpoint_id", delivery.EndpointID,
+				"event_type", delivery.EventType, "attempts", delivery.Attempts, "status", status, "error", attempt.Error)
+		}
+
+		if err := d.repo.RecordAttempt(ctx, ID, attempt, status, next); err != nil {
+			return succeeded, err
+		}
+		if status == StatusSucceeded {
+			succeeded++
+		}
+	}
+	return succeeded, nil
+}
+
+// deliver 는 수신 주소로 서명한 요청을 보내고 결과 반환
+func (d *Dispatcher) deliver(ctx context.Context, delivery *Delivery) *Attempt {
+	started := d.now()
+	attempt := &Attempt{At: started.UTC()}
+
+	endpoint, err := d.repo.GetEndpoint(ctx, delivery.EndpointID)
+	if err != nil {
+		if errortype.IsNotFoundErr(err) {
+			attempt.Error = endpointDeleted
+		} else {
+			attempt.Error = err.Error()
+		}
+		return attempt
+	}
+
+	code, err := d.post(ctx, endpoint, delivery)
+	attempt.StatusCode = code
+	attempt.Duration = d.now().Sub(started).Milliseconds()
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+	return attempt
+}
+
+func (d *Dispatcher) post(ctx context.Context, endpoint *Endpoint, delivery *Delivery) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.opts.Timeout)
+	defer cancel()
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderEventID, delivery.EventID)
+	req.Header.Set(HeaderEventType, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.ID.Hex())
+	req.Header.Set(HeaderSignature, Sign(endpoint.Secret, d.now(), body))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		io.Copy(io.Discard, io.LimitReader(res.Body, maxErrorLength))
+		return res.StatusCode, nil
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorLength))
+	if len(message) == 0 {
+		return res.StatusCode, errors.New(res.Status)
+	}
+	return res.StatusCode, fmt.Errorf("%s: %s", res.Status, bytes.TrimSpace(message))
+}
+
+// result 는 시도 결과에 따른 상태와 다음 시도 시각
+func (d *Dispatcher) result(delivery *Delivery, attempt *Attempt) (string, time.Time) {
+	now := d.now()
+	switch {
+	case len(attempt.Error) == 0:
+		return StatusSucceeded, now
+	case delivery.Attempts >= d.opts.MaxAttempts, attempt.Error == endpointDeleted:
+		return StatusDead, now
+	default:
+		return StatusFailed, now.Add(d.backoff(delivery.Attempts))
+	}
+}
+
+// backoff 는 attempts 번째 실패 후 다시 전달하기까지의 대기 시간
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.opts.RetryInterval
+	for i := 1; i < attempts && wait < d.opts.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > d.opts.MaxBackoff {
+		wait = d.opts.MaxBackoff
+	}
+	return wait
+}
@@ -0,0 +1,269 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/pkg/outbox"
+	"signupin-api/internal/pkg/user"
+	"signupin-api/internal/pkg/webhook"
+	"signupin-api/internal/pkg/webhook/memory"
+)
+
+const secret = "0123456789abcdef"
+
+// receiver 는 서명을 확인하고 요청을 기록하는 수신 서버, fail 이 남아있으면 503 응답
+type receiver struct {
+	mu       sync.Mutex
+	fail     int
+	received []*http.Request
+	bodies   [][]byte
+	invalid  []error
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	if err := webhook.Verify(secret, r.Header.Get(webhook.HeaderSignature), body, time.Now(), time.Minute); err != nil {
+		rc.invalid = append(rc.invalid, err)
+	}
+	rc.received = append(rc.received, r)
+	rc.bodies = append(rc.bodies, body)
+
+	if rc.fail > 0 {
+		rc.fail--
+		http.Error(w, "maintenance", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (rc *receiver) count() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return len(rc.received)
+}
+
+type fixture struct {
+	repo     webhook.Repository
+	uc       webhook.Usecase
+	receiver *receiver
+	endpoint string
+	url      string
+}
+
+func newFixture(t *testing.T, events ...string) *fixture {
+	t.Helper()
+
+	rc := &receiver{}
+	server := httptest.NewServer(rc)
+	t.Cleanup(server.Close)
+
+	repo := memory.New()
+	uc := webhook.NewUsecase(repo)
+	endpoint, err := uc.SaveEndpoint(context.Background(), "admin", &dto.PostWebhookRequest{URL: server.URL, Secret: secret, EventTypes: events})
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	return &fixture{repo: repo, uc: uc, receiver: rc, endpoint: endpoint.Id, url: server.URL}
+}
+
+// publish 는 outbox Relay 와 같이 메시지를 webhook.Sink 로 전달
+func (f *fixture) publish(t *testing.T, event outbox.Event) *outbox.Message {
+	t.Helper()
+
+	message, err := outbox.NewMessage("user-1", event, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := webhook.NewSink(f.repo).Publish(context.Background(), message); err != nil {
+		t.Fatal(err)
+	}
+	return message
+}
+
+func (f *fixture) deliveries(t *testing.T) []dto.GetWebhookDeliveryResponse {
+	t.Helper()
+
+	result, err := f.uc.GetDeliveries(context.Background(), &dto.GetWebhookDeliveriesRequest{EndpointID: f.endpoint})
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	return result.Deliveries
+}
+
+func newDispatcher(repo webhook.Repository) *webhook.Dispatcher {
+	return webhook.NewDispatcher(repo, nil, webhook.Options{
+		Timeout:       time.Second,
+		MaxAttempts:   3,
+		RetryInterval: time.Millisecond,
+		MaxBackoff:    2 * time.Millisecond,
+	})
+}
+
+func TestDispatcherDeliversSignedEvents(t *testing.T) {
+	f := newFixture(t, user.EventUserRegistered)
+	message := f.publish(t, user.UserRegistered{UserID: "user-1", Email: "kim@example.com"})
+	f.publish(t, user.PasswordChanged{UserID: "user-1"}) // 구독하지 않은 이벤트
+
+	// Relay 가 같은 메시지를 다시 전달해도 Delivery 는 하나
+	if err := webhook.NewSink(f.repo).Publish(context.Background(), message); err != nil {
+		t.Fatal(err)
+	}
+
+	delivered, err := newDispatcher(f.repo).Flush(context.Background())
+	if err != nil || delivered != 1 {
+		t.Fatalf("delivered %d, err %v", delivered, err)
+	}
+	if f.receiver.count() != 1 || len(f.receiver.invalid) != 0 {
+		t.Fatalf("received %d requests, invalid signatures %v", f.receiver.count(), f.receiver.invalid)
+	}
+
+	req := f.receiver.received[0]
+	if req.Header.Get(webhook.HeaderEventID) != message.ID.Hex() || req.Header.Get(webhook.HeaderEventType) != user.EventUserRegistered {
+		t.Fatalf("unexpected headers: %v", req.Header)
+	}
+	var envelope outbox.Envelope
+	if err := json.Unmarshal(f.receiver.bodies[0], &envelope); err != nil || envelope.ID != message.ID.Hex() {
+		t.Fatalf("unexpected body %s: %v", f.receiver.bodies[0], err)
+	}
+
+	deliveries := f.deliveries(t)
+	if len(deliveries) != 1 || deliveries[0].Status != webhook.StatusSucceeded || len(deliveries[0].Logs) != 1 || deliveries[0].Logs[0].StatusCode != http.StatusNoContent {
+		t.Fatalf("unexpected deliveries: %+v", deliveries)
+	}
+}
+
+func TestDispatcherRetriesThenDeadLetters(t *testing.T) {
+	f := newFixture(t, user.EventUserDeleted)
+	f.receiver.fail = 3
+	f.publish(t, user.UserDeleted{UserID: "user-1"})
+	dispatcher := newDispatcher(f.repo)
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		if _, err := dispatcher.Flush(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	deliveries := f.deliveries(t)
+	if len(deliveries) != 1 {
+		t.Fatalf("got %d deliveries", len(deliveries))
+	}
+	dead := deliveries[0]
+	if dead.Status != webhook.StatusDead || dead.Attempts != 3 || len(dead.Logs) != 3 || dead.Logs[2].Error != "503 Service Unavailable: maintenance" {
+		t.Fatalf("unexpected delivery after 3 failures: %+v", dead)
+	}
+
+	// dead 는 더 이상 전달하지 않음
+	if delivered, _ := dispatcher.Flush(context.Background()); delivered != 0 || f.receiver.count() != 3 {
+		t.Fatalf("dead delivery was retried")
+	}
+
+	replayed, err := f.uc.Replay(context.Background(), dead.Id)
+	if err != nil || replayed.Status != webhook.StatusPending || replayed.Attempts != 0 {
+		t.Fatalf("replay: %+v %v", replayed, err)
+	}
+	if delivered, err := dispatcher.Flush(context.Background()); err != nil || delivered != 1 {
+		t.Fatalf("delivered %d after replay, err %v", delivered, err)
+	}
+	if logs := f.deliveries(t)[0].Logs; len(logs) != 4 || logs[3].Error != "" {
+		t.Fatalf("unexpected logs after replay: %+v", logs)
+	}
+}
+
+func TestReplayRejectsPendingDelivery(t *testing.T) {
+	f := newFixture(t, user.EventUserDeleted)
+	f.publish(t, user.UserDeleted{UserID: "user-1"})
+
+	if _, err := f.uc.Replay(context.Background(), f.deliveries(t)[0].Id); err == nil || err.CodeDesc.HttpStatusCode != http.StatusBadRequest {
+		t.Fatalf("got %v, want bad request", err)
+	}
+	if _, err := f.uc.Replay(context.Background(), "unknown"); err == nil || err.CodeDesc.HttpStatusCode != http.StatusNotFound {
+		t.Fatalf("got %v, want not found", err)
+	}
+}
+
+func TestTestDeliveryIgnoresSubscriptions(t *testing.T) {
+	f := newFixture(t, user.EventUserDeleted)
+
+	delivery, err := f.uc.Test(context.Background(), f.endpoint)
+	if err != nil || delivery.EventType != webhook.EventTest {
+		t.Fatalf("test delivery: %+v %v", delivery, err)
+	}
+	if delivered, err := newDispatcher(f.repo).Flush(context.Background()); err != nil || delivered != 1 {
+		t.Fatalf("delivered %d, err %v", delivered, err)
+	}
+	if got := f.receiver.received[0].Header.Get(webhook.HeaderEventType); got != webhook.EventTest {
+		t.Fatalf("got event type %q", got)
+	}
+}
+
+func TestDeletedEndpointIsDeadLettered(t *testing.T) {
+	f := newFixture(t, user.EventUserDeleted)
+	f.publish(t, user.UserDeleted{UserID: "user-1"})
+
+	if err := f.uc.DeleteEndpoint(context.Background(), f.endpoint); err != nil {
+		t.Fatal(err.Message)
+	}
+	if _, err := newDispatcher(f.repo).Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	deliveries := f.deliveries(t)
+	if deliveries[0].Status != webhook.StatusDead || f.receiver.count() != 0 {
+		t.Fatalf("unexpected delivery: %+v", deliveries[0])
+	}
+}
+
+func TestSaveEndpointValidates(t *testing.T) {
+	uc := webhook.NewUsecase(memory.New())
+
+	tests := []*dto.PostWebhookRequest{
+		{URL: "ftp://example.com/hook", EventTypes: []string{user.EventUserDeleted}},
+		{URL: "https://example.com/hook", EventTypes: []string{"user.unknown"}},
+	}
+	for _, req := range tests {
+		if _, err := uc.SaveEndpoint(context.Background(), "admin", req); err == nil || err.CodeDesc.HttpStatusCode != http.StatusBadRequest {
+			t.Errorf("%+v: got %v, want bad request", req, err)
+		}
+	}
+
+	// 서명 키를 등록하지 않으면 생성해서 1회 반환
+	created, err := uc.SaveEndpoint(context.Background(), "admin", &dto.PostWebhookRequest{URL: "https://example.com/hook", EventTypes: []string{user.EventUserDeleted}})
+	if err != nil || len(created.Secret) == 0 {
+		t.Fatalf("created %+v, err %v", created, err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	now := time.Now()
+	header := webhook.Sign(secret, now, body)
+
+	if err := webhook.Verify(secret, header, body, now, time.Minute); err != nil {
+		t.Fatalf("valid signature: %v", err)
+	}
+	if err := webhook.Verify("other-secret-value", header, body, now, time.Minute); err == nil {
+		t.Fatal("accepted wrong secret")
+	}
+	if err := webhook.Verify(secret, header, []byte(`{"id":"2"}`), now, time.Minute); err == nil {
+		t.Fatal("accepted modified body")
+	}
+	if err := webhook.Verify(secret, header, body, now.Add(10*time.Minute), time.Minute); err == nil {
+		t.Fatal("accepted expired signature")
+	}
+	if err := webhook.Verify(secret, "v1=abc", body, now, time.Minute); err == nil {
+		t.Fatal("accepted malformed header")
+	}
+}
@@ -0,0 +1,118 @@
+// Package webhook 은 연동 서비스가 등록한 주소로 회원 이벤트를 서명한 POST 요청으로 전달
+//
+// outbox Relay 가 Sink 로 이벤트를 넘기면 구독한 주소마다 Delivery 를 만들고,
+// Dispatcher 가 Delivery 를 전달하면서 실패하면 간격을 늘려 재시도, MaxAttempts 를 넘기면 dead 로 처리
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"signupin-api/internal/pkg/user"
+
+	"github.com/kamva/mgm/v3"
+)
+
+// 전달 상태 (Delivery.Status)
+const (
+	StatusPending   = "pending"   // 전달 전
+	StatusFailed    = "failed"    // 실패, NextAttemptAt 에 다시 전달
+	StatusSucceeded = "succeeded" // 2xx 응답
+	StatusDead      = "dead"      // MaxAttempts 를 넘겨 더 이상 전달하지 않음 (관리자 API 로 다시 전달 가능)
+)
+
+// EventTest 는 관리자 API 로 보내는 테스트 이벤트 (구독 여부와 관계없이 전달)
+const EventTest = "webhook.test"
+
+const secretLength = 32 // 생성하는 서명 키 길이 (bytes)
+
+// Events 는 구독할 수 있는 이벤트 종류
+var Events = []string{
+	user.EventUserRegistered,
+	user.EventPasswordChanged,
+	user.EventPasswordResetRequired,
+	user.EventUserDeleted,
+}
+
+// Endpoint 는 연동 서비스가 등록한 이벤트 수신 주소
+type Endpoint struct {
+	mgm.DefaultModel `bson:",inline"`
+	URL              string   `json:"url" bson:"url"`                 // 수신 주소 (http, https)
+	Secret           string   `json:"-" bson:"secret"`                // 서명 키
+	EventTypes       []string `json:"event_types" bson:"event_types"` // 구독하는 이벤트 종류
+	Description      string   `json:"description" bson:"description"` // 설명
+	CreatedBy        string   `json:"created_by" bson:"created_by"`   // 등록한 관리자 (회원 / 클라이언트 아이디)
+}
+
+// CollectionName 은 MongoDB 컬렉션 이름 (mgm 기본값은 endpoints)
+func (e *Endpoint) CollectionName() string {
+	return "webhook_endpoints"
+}
+
+// Subscribes 는 eventType 을 구독하는지 확인
+func (e *Endpoint) Subscribes(eventType string) bool {
+	for _, subscribed := range e.EventTypes {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Delivery 는 수신 주소 하나로 이벤트 하나를 전달하는 작업
+// 같은 주소로 같은 이벤트는 한번만 만들어짐 (EndpointID, EventID unique)
+type Delivery struct {
+	mgm.DefaultModel `bson:",inline"`
+	EndpointID       string    `json:"endpoint_id" bson:"endpoint_id"`
+	EventID          string    `json:"event_id" bson:"event_id"`     // outbox 메시지 아이디 (수신 측 중복 확인용)
+	EventType        string    `json:"event_type" bson:"event_type"` // 이벤트 종류
+	Payload          string    `json:"payload" bson:"payload"`       // 전달할 본문 (outbox.Envelope JSON)
+	Status           string    `json:"status" bson:"status"`
+	Attempts         int       `json:"attempts" bson:"attempts"`               // 시도 횟수 (다시 전달하면 0 부터)
+	NextAttemptAt    time.Time `json:"next_attempt_at" bson:"next_attempt_at"` // 다음 시도 시각
+	Logs             []Attempt `json:"logs" bson:"logs"`                       // 시도별 결과 (다시 전달해도 유지)
+}
+
+// CollectionName 은 MongoDB 컬렉션 이름 (mgm 기본값은 deliveries)
+func (d *Delivery) CollectionName() string {
+	return "webhook_deliveries"
+}
+
+// Attempt 는 전달 시도 한번의 결과
+type Attempt struct {
+	StatusCode int       `json:"status_code" bson:"status_code"` // 응답 코드 (연결 실패는 0)
+	Error      string    `json:"error" bson:"error"`             // 실패 이유
+	Duration   int64     `json:"duration_ms" bson:"duration_ms"` // 응답까지 걸린 시간 (ms)
+	At         time.Time `json:"at" bson:"at"`
+}
+
+func newDelivery(endpointID, eventID, eventType, payload string, now time.Time) *Delivery {
+	return &Delivery{
+		EndpointID:    endpointID,
+		EventID:       eventID,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        StatusPending,
+		NextAttemptAt: now.UTC(),
+		Logs:          []Attempt{},
+	}
+}
+
+func isEvent(eventType string) bool {
+	for _, event := range Events {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// newSecret 은 서명 키를 등록하지 않은 경우 사용할 임의의 키
+func newSecret() string {
+	b := make([]byte, secretLength)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return "whsec_" + hex.EncodeToString(b)
+}
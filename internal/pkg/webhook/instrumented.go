@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"signupin-api/internal/pkg/metrics"
+)
+
+// instrumentedRepository 는 저장소 호출 시간과 실패를 지표로 기록
+type instrumentedRepository struct {
+	next Repository
+}
+
+func (r *instrumentedRepository) SaveEndpoint(ctx context.Context, model *Endpoint) (_ string, err error) {
+	defer metrics.ObserveRepository("webhook", "SaveEndpoint", time.Now(), &err)
+	return r.next.SaveEndpoint(ctx, model)
+}
+
+func (r *instrumentedRepository) SaveDelivery(ctx context.Context, model *Delivery) (_ string, err error) {
+	defer metrics.ObserveRepository("webhook", "SaveDelivery", time.Now(), &err)
+	return r.next.SaveDelivery(ctx, model)
+}
+
+func (r *instrumentedRepository) GetEndpoint(ctx context.Context, ID string) (_ *Endpoint, err error) {
+	defer metrics.ObserveRepository("webhook", "GetEndpoint", time.Now(), &err)
+	return r.next.GetEndpoint(ctx, ID)
+}
+
+func (r *instrumentedRepository) GetEndpoints(ctx context.Context) (_ []Endpoint, err error) {
+	defer metrics.ObserveRepository("webhook", "GetEndpoints", time.Now(), &err)
+	return r.next.GetEndpoints(ctx)
+}
+
+func (r *instrumentedRepository) GetDelivery(ctx context.Context, ID string) (_ *Delivery, err error) {
+	defer metrics.ObserveRepository("webhook", "GetDelivery", time.Now(), &err)
+	return r.next.GetDelivery(ctx, ID)
+}
+
+func (r *instrumentedRepository) FindDeliveries(ctx context.Context, filter *DeliveryFilter, skip, limit int64) (_ []Delivery, _ int64, err error) {
+	defer metrics.ObserveRepository("webhook", "FindDeliveries", time.Now(), &err)
+	return r.next.FindDeliveries(ctx, filter, skip, limit)
+}
+
+func (r *instrumentedRepository) GetDue(ctx context.Context, now time.Time, limit int64) (_ []Delivery, err error) {
+	defer metrics.ObserveRepository("webhook", "GetDue", time.Now(), &err)
+	return r.next.GetDue(ctx, now, limit)
+}
+
+func (r *instrumentedRepository) Claim(ctx context.Context, ID string, attempts int, until time.Time) (_ bool, err error) {
+	defer metrics.ObserveRepository("webhook", "Claim", time.Now(), &err)
+	return r.next.Claim(ctx, ID, attempts, until)
+}
+
+func (r *instrumentedRepository) RecordAttempt(ctx context.Context, ID string, attempt *Attempt, status string, nextAttemptAt time.Time) (err error) {
+	defer metrics.ObserveRepository("webhook", "RecordAttempt", time.Now(), &err)
+	return r.next.RecordAttempt(ctx, ID, attempt, status, nextAttemptAt)
+}
+
+func (r *instrumentedRepository) Replay(ctx context.Context, ID string, now time.Time) (err error) {
+	defer metrics.ObserveRepository("webhook", "Replay", time.Now(), &err)
+	return r.next.Replay(ctx, ID, now)
+}
+
+func (r *instrumentedRepository) DeleteEndpoint(ctx context.Context, ID string) (err error) {
+	defer metrics.ObserveRepository("webhook", "DeleteEndpoint", time.Now(), &err)
+	return r.next.DeleteEndpoint(ctx, ID)
+}
+
+// Instrument 는 호출 시간과 실패를 지표 (/metrics) 로 기록하는 저장소 반환
+func Instrument(repo Repository) Repository {
+	return &instrumentedRepository{next: repo}
+}
+
+var _ Repository = &instrumentedRepository{}
@@ -0,0 +1,228 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"signupin-api/internal/pkg/webhook"
+
+	"github.com/kamva/mgm/v3"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var errDuplicatedDelivery = errors.New("duplicate key: endpoint_id, event_id")
+
+// webhookRepo 는 DB 없이 실행하기 위한 webhook.Repository 구현 (테스트, 로컬 개발용)
+// persistence.webhookRepo 와 동일하게 조회 결과가 없으면 errortype.IsNotFoundErr 로 확인 가능한 오류 반환
+type webhookRepo struct {
+	mu         sync.Mutex
+	endpoints  []webhook.Endpoint
+	deliveries []webhook.Delivery // 저장 순서 유지
+}
+
+var _ webhook.Repository = &webhookRepo{}
+
+func (r *webhookRepo) SaveEndpoint(ctx context.Context, model *webhook.Endpoint) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prepare(&model.DefaultModel)
+	r.endpoints = append(r.endpoints, *model)
+	return utils.MapToStringID(model.ID), nil
+}
+
+func (r *webhookRepo) SaveDelivery(ctx context.Context, model *webhook.Delivery) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// persistence.EnsureIndexes 의 unique index 와 동일하게 같은 주소로 같은 이벤트는 한번만 저장
+	for i := range r.deliveries {
+		if r.deliveries[i].EndpointID == model.EndpointID && r.deliveries[i].EventID == model.EventID {
+			return "", errortype.DuplicatedKeyError(mgm.CollName(model), nil, nil, model, errDuplicatedDelivery)
+		}
+	}
+
+	prepare(&model.DefaultModel)
+	delivery := *model
+	delivery.Logs = append([]webhook.Attempt{}, model.Logs...)
+	r.deliveries = append(r.deliveries, delivery)
+	return utils.MapToStringID(model.ID), nil
+}
+
+func (r *webhookRepo) GetEndpoint(ctx context.Context, ID string) (*webhook.Endpoint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.endpoints {
+		if r.endpoints[i].ID.Hex() == ID {
+			found := r.endpoints[i]
+			return &found, nil
+		}
+	}
+	return nil, notFound(&webhook.Endpoint{}, ID)
+}
+
+func (r *webhookRepo) GetEndpoints(ctx context.Context) ([]webhook.Endpoint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// persistence.webhookRepo 와 동일하게 최신순
+	found := []webhook.Endpoint{}
+	for i := len(r.endpoints) - 1; i >= 0; i-- {
+		found = append(found, r.endpoints[i])
+	}
+	return found, nil
+}
+
+func (r *webhookRepo) GetDelivery(ctx context.Context, ID string) (*webhook.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	found := r.find(ID)
+	if found == nil {
+		return nil, notFound(&webhook.Delivery{}, ID)
+	}
+	return copyDelivery(found), nil
+}
+
+func (r *webhookRepo) FindDeliveries(ctx context.Context, filter *webhook.DeliveryFilter, skip, limit int64) ([]webhook.Delivery, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var matched []webhook.Delivery
+	for i := len(r.deliveries) - 1; i >= 0; i-- {
+		d := &r.deliveries[i]
+		if (filter.EndpointID != "" && d.EndpointID != filter.EndpointID) ||
+			(filter.EventID != "" && d.EventID != filter.EventID) ||
+			(filter.Status != "" && d.Status != filter.Status) {
+			continue
+		}
+		matched = append(matched, *copyDelivery(d))
+	}
+
+	total := int64(len(matched))
+	if skip >= total {
+		return []webhook.Delivery{}, total, nil
+	}
+	end := skip + limit
+	if end > total {
+		end = total
+	}
+	return matched[skip:end], total, nil
+}
+
+func (r *webhookRepo) GetDue(ctx context.Context, now time.Time, limit int64) ([]webhook.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	found := []webhook.Delivery{}
+	for i := range r.deliveries {
+		if int64(len(found)) == limit {
+			break
+		}
+		if d := &r.deliveries[i]; isDue(d) && !d.NextAttemptAt.After(now) {
+			found = append(found, *copyDelivery(d))
+		}
+	}
+	return found, nil
+}
+
+func (r *webhookRepo) Claim(ctx context.Context, ID string, attempts int, until time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d := r.find(ID)
+	if d == nil || d.Attempts != attempts || !isDue(d) {
+		return false, nil
+	}
+	d.Attempts++
+	d.NextAttemptAt = until
+	d.UpdatedAt = time.Now().UTC()
+	return true, nil
+}
+
+func (r *webhookRepo) RecordAttempt(ctx context.Context, ID string, attempt *webhook.Attempt, status string, nextAttemptAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d := r.find(ID)
+	if d == nil {
+		return notFound(&webhook.Delivery{}, ID)
+	}
+	d.Logs = append(d.Logs, *attempt)
+	d.Status = status
+	d.NextAttemptAt = nextAttemptAt
+	d.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func (r *webhookRepo) Replay(ctx context.Context, ID string, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d := r.find(ID)
+	if d == nil || (d.Status != webhook.StatusSucceeded && d.Status != webhook.StatusDead) {
+		return notFound(&webhook.Delivery{}, ID)
+	}
+	d.Status = webhook.StatusPending
+	d.Attempts = 0
+	d.NextAttemptAt = now
+	d.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func (r *webhookRepo) DeleteEndpoint(ctx context.Context, ID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.endpoints {
+		if r.endpoints[i].ID.Hex() == ID {
+			r.endpoints = append(r.endpoints[:i], r.endpoints[i+1:]...)
+			return nil
+		}
+	}
+	return notFound(&webhook.Endpoint{}, ID)
+}
+
+func (r *webhookRepo) find(ID string) *webhook.Delivery {
+	for i := range r.deliveries {
+		if r.deliveries[i].ID.Hex() == ID {
+			return &r.deliveries[i]
+		}
+	}
+	return nil
+}
+
+func isDue(d *webhook.Delivery) bool {
+	return d.Status == webhook.StatusPending || d.Status == webhook.StatusFailed
+}
+
+// copyDelivery 는 잠금 밖에서 로그를 추가해도 저장된 값이 바뀌지 않도록 복사
+func copyDelivery(d *webhook.Delivery) *webhook.Delivery {
+	found := *d
+	found.Logs = append([]webhook.Attempt{}, d.Logs...)
+	return &found
+}
+
+func prepare(model *mgm.DefaultModel) {
+	if model.ID.IsZero() {
+		model.ID = primitive.NewObjectID()
+	}
+	now := time.Now().UTC()
+	model.CreatedAt = now
+	model.UpdatedAt = now
+}
+
+func notFound(model mgm.Model, ID string) error {
+	return errortype.NotFoundError(mgm.CollName(model), bson.M{"_id": ID}, nil, nil)
+}
+
+func New() webhook.Repository {
+	return &webhookRepo{}
+}
@@ -0,0 +1,246 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"signupin-api/internal/pkg/webhook"
+
+	"github.com/kamva/mgm/v3"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type webhookRepo struct {
+	client *mongo.Client
+}
+
+var _ webhook.Repository = &webhookRepo{}
+
+func (r *webhookRepo) SaveEndpoint(ctx context.Context, model *webhook.Endpoint) (string, error) {
+	coll := mgm.Coll(model)
+	err := coll.CreateWithCtx(ctx, model)
+	if err != nil {
+		return "", errortype.ParseAndReturnDBError(err, coll.Name(), nil, nil, nil)
+	}
+
+	return utils.MapToStringID(model.ID), nil
+}
+
+func (r *webhookRepo) SaveDelivery(ctx context.Context, model *webhook.Delivery) (string, error) {
+	coll := mgm.Coll(model)
+	err := coll.CreateWithCtx(ctx, model)
+	if err != nil {
+		return "", errortype.ParseAndReturnDBError(err, coll.Name(), nil, nil, nil)
+	}
+
+	return utils.MapToStringID(model.ID), nil
+}
+
+func (r *webhookRepo) GetEndpoint(ctx context.Context, ID string) (*webhook.Endpoint, error) {
+	found := &webhook.Endpoint{}
+	coll := mgm.Coll(found)
+
+	objectID, err := toObjectID(coll, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": objectID}
+	err = coll.FindOne(ctx, filter).Decode(found)
+	if err != nil {
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return found, nil
+}
+
+func (r *webhookRepo) GetEndpoints(ctx context.Context) ([]webhook.Endpoint, error) {
+	found := []webhook.Endpoint{}
+	filter := bson.M{}
+
+	coll := mgm.Coll(&webhook.Endpoint{})
+	err := coll.SimpleFindWithCtx(ctx, &found, filter, options.Find().SetSort(bson.M{"created_at": -1}))
+	if err != nil {
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return found, nil
+}
+
+func (r *webhookRepo) GetDelivery(ctx context.Context, ID string) (*webhook.Delivery, error) {
+	found := &webhook.Delivery{}
+	coll := mgm.Coll(found)
+
+	objectID, err := toObjectID(coll, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": objectID}
+	err = coll.FindOne(ctx, filter).Decode(found)
+	if err != nil {
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return found, nil
+}
+
+func (r *webhookRepo) FindDeliveries(ctx context.Context, filter *webhook.DeliveryFilter, skip, limit int64) ([]webhook.Delivery, int64, error) {
+	query := bson.M{}
+	for key, value := range map[string]string{
+		"endpoint_id": filter.EndpointID,
+		"event_id":    filter.EventID,
+		"status":      filter.Status,
+	} {
+		if len(value) > 0 {
+			query[key] = value
+		}
+	}
+
+	coll := mgm.Coll(&webhook.Delivery{})
+	total, err := coll.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, errortype.ParseAndReturnDBError(err, coll.Name(), query, nil, nil)
+	}
+
+	found := []webhook.Delivery{}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}}).SetSkip(skip).SetLimit(limit)
+	err = coll.SimpleFindWithCtx(ctx, &found, query, opts)
+	if err != nil {
+		return nil, 0, errortype.ParseAndReturnDBError(err, coll.Name(), query, nil, nil)
+	}
+
+	return found, total, nil
+}
+
+func (r *webhookRepo) GetDue(ctx context.Context, now time.Time, limit int64) ([]webhook.Delivery, error) {
+	filter := bson.M{
+		"status":          bson.M{"$in": bson.A{webhook.StatusPending, webhook.StatusFailed}},
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).SetLimit(limit)
+
+	found := []webhook.Delivery{}
+	coll := mgm.Coll(&webhook.Delivery{})
+	err := coll.SimpleFindWithCtx(ctx, &found, filter, opts)
+	if err != nil {
+		return nil, errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+
+	return found, nil
+}
+
+func (r *webhookRepo) Claim(ctx context.Context, ID string, attempts int, until time.Time) (bool, error) {
+	coll := mgm.Coll(&webhook.Delivery{})
+	objectID, err := toObjectID(coll, ID)
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{
+		"_id":      objectID,
+		"attempts": attempts,
+		"status":   bson.M{"$in": bson.A{webhook.StatusPending, webhook.StatusFailed}},
+	}
+	update := bson.M{
+		"$inc": bson.M{"attempts": 1},
+		"$set": bson.M{"next_attempt_at": until, "updated_at": time.Now().UTC()},
+	}
+
+	result, err := coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, errortype.ParseAndReturnDBError(err, coll.Name(), filter, update, nil)
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *webhookRepo) RecordAttempt(ctx context.Context, ID string, attempt *webhook.Attempt, status string, nextAttemptAt time.Time) error {
+	update := bson.M{
+		"$push": bson.M{"logs": attempt},
+		"$set":  bson.M{"status": status, "next_attempt_at": nextAttemptAt, "updated_at": time.Now().UTC()},
+	}
+	return r.updateDelivery(ctx, ID, bson.M{}, update)
+}
+
+func (r *webhookRepo) Replay(ctx context.Context, ID string, now time.Time) error {
+	filter := bson.M{"status": bson.M{"$in": bson.A{webhook.StatusSucceeded, webhook.StatusDead}}}
+	update := bson.M{
+		"$set": bson.M{"status": webhook.StatusPending, "attempts": 0, "next_attempt_at": now, "updated_at": time.Now().UTC()},
+	}
+	return r.updateDelivery(ctx, ID, filter, update)
+}
+
+// updateDelivery 는 filter 에 아이디를 더해 Delivery 하나를 수정, 없으면 not found 오류 반환
+func (r *webhookRepo) updateDelivery(ctx context.Context, ID string, filter, update bson.M) error {
+	coll := mgm.Coll(&webhook.Delivery{})
+	objectID, err := toObjectID(coll, ID)
+	if err != nil {
+		return err
+	}
+
+	filter["_id"] = objectID
+	result, err := coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), filter, update, nil)
+	}
+	if result.MatchedCount == 0 {
+		return errortype.NotFoundError(coll.Name(), filter, update, nil)
+	}
+
+	return nil
+}
+
+func (r *webhookRepo) DeleteEndpoint(ctx context.Context, ID string) error {
+	coll := mgm.Coll(&webhook.Endpoint{})
+	objectID, err := toObjectID(coll, ID)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": objectID}
+	result, err := coll.DeleteOne(ctx, filter)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), filter, nil, nil)
+	}
+	if result.DeletedCount == 0 {
+		return errortype.NotFoundError(coll.Name(), filter, nil, nil)
+	}
+
+	return nil
+}
+
+// toObjectID 는 아이디 형식이 잘못된 경우 not found 오류 반환 (존재하지 않는 아이디와 같게 응답)
+func toObjectID(coll *mgm.Collection, ID string) (primitive.ObjectID, error) {
+	objectID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		return objectID, errortype.NotFoundError(coll.Name(), bson.M{"_id": ID}, nil, nil)
+	}
+	return objectID, nil
+}
+
+// EnsureIndexes 는 같은 주소로 같은 이벤트를 두번 저장하지 않기 위한 unique index 와 조회용 index 생성 (이미 있으면 무시)
+func EnsureIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "endpoint_id", Value: 1}, {Key: "event_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+	}
+
+	coll := mgm.Coll(&webhook.Delivery{})
+	_, err := coll.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		return errortype.ParseAndReturnDBError(err, coll.Name(), nil, nil, nil)
+	}
+
+	return nil
+}
+
+func New(client *mongo.Client) webhook.Repository {
+	return &webhookRepo{client}
+}
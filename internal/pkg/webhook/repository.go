@@ -0,0 +1,38 @@
+package webhook
+
+import (
+	"context"
+	"time"
+)
+
+// Repository interface definition
+type Repository interface {
+	SaveEndpoint(ctx context.Context, model *Endpoint) (string, error)
+	// 같은 주소로 같은 이벤트의 Delivery 가 이미 있으면 errortype.IsDuplicatedKeyErr 로 확인 가능한 오류 반환
+	SaveDelivery(ctx context.Context, model *Delivery) (string, error)
+
+	// GET
+	GetEndpoint(ctx context.Context, ID string) (*Endpoint, error)
+	GetEndpoints(ctx context.Context) ([]Endpoint, error)
+	GetDelivery(ctx context.Context, ID string) (*Delivery, error)
+	FindDeliveries(ctx context.Context, filter *DeliveryFilter, skip, limit int64) ([]Delivery, int64, error) // 최신순, 전체 건수
+	GetDue(ctx context.Context, now time.Time, limit int64) ([]Delivery, error)                               // 전달할 차례인 pending / failed
+
+	// UPDATE
+	// Claim 은 시도 횟수가 attempts 인 경우에만 하나 늘리고 until 까지 다른 Dispatcher 가 가져가지 못하게 함
+	Claim(ctx context.Context, ID string, attempts int, until time.Time) (bool, error)
+	// RecordAttempt 는 시도 결과를 로그에 추가하고 상태와 다음 시도 시각 변경
+	RecordAttempt(ctx context.Context, ID string, attempt *Attempt, status string, nextAttemptAt time.Time) error
+	// Replay 는 succeeded / dead 인 Delivery 를 시도 횟수 0 의 pending 으로 되돌림 (로그는 유지)
+	Replay(ctx context.Context, ID string, now time.Time) error
+
+	// DELETE
+	DeleteEndpoint(ctx context.Context, ID string) error
+}
+
+// DeliveryFilter 는 Delivery 조회 조건 (빈 값은 조건 없음)
+type DeliveryFilter struct {
+	EndpointID string
+	EventID    string
+	Status     string
+}
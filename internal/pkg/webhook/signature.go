@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 요청 헤더
+const (
+	HeaderSignature = "X-Webhook-Signature" // t=<unix 초>,v1=<HMAC-SHA256 hex>
+	HeaderEventID   = "X-Webhook-Event-ID"  // 이벤트 아이디 (재시도 / 다시 전달해도 같음)
+	HeaderEventType = "X-Webhook-Event"     // 이벤트 종류
+	HeaderDelivery  = "X-Webhook-Delivery"  // 전달 아이디 (관리자 API 로 조회 / 다시 전달)
+)
+
+// Sign 은 "<timestamp>.<body>" 를 secret 으로 서명한 HeaderSignature 값 반환
+// timestamp 를 함께 서명하므로 수신 측은 오래된 요청의 재전송을 거부할 수 있음
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", ts, computeSignature(secret, ts, body))
+}
+
+// Verify 는 수신한 요청의 HeaderSignature 를 확인 (수신 측 예시, 테스트)
+// 서명 시각이 now 와 tolerance 이상 차이 나면 거부
+func Verify(secret, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var ts, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			signature = value
+		}
+	}
+	if len(ts) == 0 || len(signature) == 0 {
+		return errors.New("malformed signature header")
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return errors.New("malformed signature timestamp")
+	}
+	if diff := now.Sub(time.Unix(unix, 0)); diff > tolerance || diff < -tolerance {
+		return errors.New("signature timestamp out of tolerance")
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(computeSignature(secret, ts, body))) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+func computeSignature(secret, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"signupin-api/internal/pkg/outbox"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+)
+
+// Sink 는 outbox 의 이벤트를 구독한 수신 주소마다 Delivery 로 저장하는 outbox.Sink
+// 실제 전달은 Dispatcher 가 수신 주소별로 재시도하므로 한 주소의 장애가 다른 주소나 outbox 를 막지 않음
+type Sink struct {
+	repo Repository
+	now  func() time.Time
+}
+
+// NewSink 는 OUTBOX_SINKS 의 webhook
+func NewSink(repo Repository) *Sink {
+	return &Sink{repo: repo, now: time.Now}
+}
+
+func (s *Sink) Name() string {
+	return "webhook"
+}
+
+// Publish 는 저장에 실패하면 오류를 반환하여 Relay 가 다시 전달하게 함
+// 이미 저장한 Delivery 는 unique index 로 다시 만들지 않음
+func (s *Sink) Publish(ctx context.Context, message *outbox.Message) error {
+	endpoints, err := s.repo.GetEndpoints(ctx)
+	if err != nil && !errortype.IsNotFoundErr(err) {
+		return err
+	}
+
+	var payload []byte
+	for i := range endpoints {
+		endpoint := &endpoints[i]
+		if !endpoint.Subscribes(message.Type) {
+			continue
+		}
+
+		if payload == nil {
+			if payload, err = json.Marshal(message.Envelope()); err != nil {
+				return err
+			}
+		}
+
+		delivery := newDelivery(endpoint.ID.Hex(), message.ID.Hex(), message.Type, string(payload), s.now())
+		if _, err := s.repo.SaveDelivery(ctx, delivery); err != nil && !errortype.IsDuplicatedKeyErr(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+var _ outbox.Sink = &Sink{}
@@ -0,0 +1,220 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"signupin-api/internal/app/api/dto"
+	"signupin-api/internal/pkg/outbox"
+
+	"github.com/kkodecaffeine/go-common/core/database/mongo/errortype"
+	"github.com/kkodecaffeine/go-common/errorcode"
+	"github.com/kkodecaffeine/go-common/rest"
+)
+
+const defaultSize = 20 // 조회 페이지 크기 기본값
+
+// testEvent 는 EventTest 의 내용
+type testEvent struct {
+	EndpointID string `json:"endpoint_id"`
+}
+
+func (testEvent) EventType() string { return EventTest }
+
+// UseCase interface definition
+type Usecase interface {
+	SaveEndpoint(ctx context.Context, createdBy string, req *dto.PostWebhookRequest) (*dto.PostWebhookResponse, *rest.CustomError)
+
+	// GET
+	GetEndpoints(ctx context.Context) ([]dto.GetWebhookResponse, *rest.CustomError)
+	GetDeliveries(ctx context.Context, req *dto.GetWebhookDeliveriesRequest) (*dto.GetWebhookDeliveriesResponse, *rest.CustomError)
+
+	// 수신 주소로 테스트 이벤트 전달 (Dispatcher 가 전달)
+	Test(ctx context.Context, ID string) (*dto.GetWebhookDeliveryResponse, *rest.CustomError)
+	// 전달을 마쳤거나 (succeeded) 포기한 (dead) Delivery 를 다시 전달
+	Replay(ctx context.Context, ID string) (*dto.GetWebhookDeliveryResponse, *rest.CustomError)
+
+	// DELETE
+	DeleteEndpoint(ctx context.Context, ID string) *rest.CustomError
+}
+
+type usecase struct {
+	repo Repository
+	now  func() time.Time
+}
+
+func (u *usecase) SaveEndpoint(ctx context.Context, createdBy string, req *dto.PostWebhookRequest) (*dto.PostWebhookResponse, *rest.CustomError) {
+	if parsed, err := url.Parse(req.URL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || len(parsed.Host) == 0 {
+		return nil, &rest.CustomError{CodeDesc: &errorcode.INVALID_PARAMETERS, Message: "url must be an http(s) URL"}
+	}
+	for _, eventType := range req.EventTypes {
+		if !isEvent(eventType) {
+			return nil, &rest.CustomError{CodeDesc: &errorcode.INVALID_PARAMETERS, Message: "event_types: " + eventType}
+		}
+	}
+
+	secret := req.Secret
+	if len(secret) == 0 {
+		secret = newSecret()
+	}
+
+	model := &Endpoint{
+		URL:         req.URL,
+		Secret:      secret,
+		EventTypes:  req.EventTypes,
+		Description: req.Description,
+		CreatedBy:   createdBy,
+	}
+	if _, err := u.repo.SaveEndpoint(ctx, model); err != nil {
+		return nil, toCustomError(err)
+	}
+
+	return &dto.PostWebhookResponse{Secret: secret, GetWebhookResponse: toEndpointResponse(model)}, nil
+}
+
+func (u *usecase) GetEndpoints(ctx context.Context) ([]dto.GetWebhookResponse, *rest.CustomError) {
+	found, err := u.repo.GetEndpoints(ctx)
+	if err != nil && !errortype.IsNotFoundErr(err) {
+		return nil, toCustomError(err)
+	}
+
+	result := make([]dto.GetWebhookResponse, 0, len(found))
+	for i := range found {
+		result = append(result, toEndpointResponse(&found[i]))
+	}
+	return result, nil
+}
+
+func (u *usecase) GetDeliveries(ctx context.Context, req *dto.GetWebhookDeliveriesRequest) (*dto.GetWebhookDeliveriesResponse, *rest.CustomError) {
+	page, size := req.Page, req.Size
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = defaultSize
+	}
+
+	filter := &DeliveryFilter{EndpointID: req.EndpointID, EventID: req.EventID, Status: req.Status}
+	found, total, err := u.repo.FindDeliveries(ctx, filter, int64((page-1)*size), int64(size))
+	if err != nil && !errortype.IsNotFoundErr(err) {
+		return nil, toCustomError(err)
+	}
+
+	deliveries := make([]dto.GetWebhookDeliveryResponse, 0, len(found))
+	for i := range found {
+		deliveries = append(deliveries, toDeliveryResponse(&found[i]))
+	}
+
+	return &dto.GetWebhookDeliveriesResponse{Deliveries: deliveries, Page: page, Size: size, Total: total}, nil
+}
+
+// Test 는 구독 여부와 관계없이 수신 주소 하나로 EventTest 를 전달하는 Delivery 생성
+func (u *usecase) Test(ctx context.Context, ID string) (*dto.GetWebhookDeliveryResponse, *rest.CustomError) {
+	endpoint, err := u.repo.GetEndpoint(ctx, ID)
+	if err != nil {
+		return nil, toCustomError(err)
+	}
+
+	now := u.now()
+	message, err := outbox.NewMessage(ID, testEvent{EndpointID: ID}, now)
+	if err != nil {
+		return nil, &rest.CustomError{CodeDesc: &errorcode.FAILED_INTERNAL_ERROR, Message: err.Error()}
+	}
+	payload, err := json.Marshal(message.Envelope())
+	if err != nil {
+		return nil, &rest.CustomError{CodeDesc: &errorcode.FAILED_INTERNAL_ERROR, Message: err.Error()}
+	}
+
+	delivery := newDelivery(endpoint.ID.Hex(), message.ID.Hex(), EventTest, string(payload), now)
+	if _, err := u.repo.SaveDelivery(ctx, delivery); err != nil {
+		return nil, toCustomError(err)
+	}
+
+	result := toDeliveryResponse(delivery)
+	return &result, nil
+}
+
+func (u *usecase) Replay(ctx context.Context, ID string) (*dto.GetWebhookDeliveryResponse, *rest.CustomError) {
+	found, err := u.repo.GetDelivery(ctx, ID)
+	if err != nil {
+		return nil, toCustomError(err)
+	}
+
+	// 재시도 중인 Delivery 는 진행 중인 시도와 겹칠 수 있으므로 다시 전달하지 않음
+	if found.Status != StatusSucceeded && found.Status != StatusDead {
+		return nil, &rest.CustomError{CodeDesc: &errorcode.BAD_REQUEST, Message: "delivery is still " + found.Status}
+	}
+
+	if err := u.repo.Replay(ctx, ID, u.now()); err != nil {
+		return nil, toCustomError(err)
+	}
+
+	found, err = u.repo.GetDelivery(ctx, ID)
+	if err != nil {
+		return nil, toCustomError(err)
+	}
+	result := toDeliveryResponse(found)
+	return &result, nil
+}
+
+// DeleteEndpoint 는 수신 주소 삭제, 남은 Delivery 는 전달하지 않고 dead 로 처리
+func (u *usecase) DeleteEndpoint(ctx context.Context, ID string) *rest.CustomError {
+	if err := u.repo.DeleteEndpoint(ctx, ID); err != nil {
+		return toCustomError(err)
+	}
+	return nil
+}
+
+func toEndpointResponse(model *Endpoint) dto.GetWebhookResponse {
+	return dto.GetWebhookResponse{
+		Id:          model.ID.Hex(),
+		URL:         model.URL,
+		EventTypes:  model.EventTypes,
+		Description: model.Description,
+		CreatedBy:   model.CreatedBy,
+		CreatedAt:   model.CreatedAt,
+	}
+}
+
+func toDeliveryResponse(model *Delivery) dto.GetWebhookDeliveryResponse {
+	logs := make([]dto.GetWebhookAttemptResponse, 0, len(model.Logs))
+	for _, log := range model.Logs {
+		logs = append(logs, dto.GetWebhookAttemptResponse{
+			StatusCode: log.StatusCode,
+			Error:      log.Error,
+			DurationMs: log.Duration,
+			At:         log.At,
+		})
+	}
+
+	return dto.GetWebhookDeliveryResponse{
+		Id:            model.ID.Hex(),
+		EndpointID:    model.EndpointID,
+		EventID:       model.EventID,
+		EventType:     model.EventType,
+		Status:        model.Status,
+		Attempts:      model.Attempts,
+		NextAttemptAt: model.NextAttemptAt,
+		CreatedAt:     model.CreatedAt,
+		Logs:          logs,
+	}
+}
+
+func toCustomError(err error) *rest.CustomError {
+	if errortype.IsDecodeError(err) {
+		return &rest.CustomError{CodeDesc: &errorcode.FAILED_DB_PROCESSING, Message: err.Error()}
+	} else if errortype.IsNotFoundErr(err) {
+		return &rest.CustomError{CodeDesc: &errorcode.NOT_FOUND_ERROR, Message: err.Error()}
+	} else {
+		return &rest.CustomError{CodeDesc: &errorcode.FAILED_INTERNAL_ERROR, Message: err.Error()}
+	}
+}
+
+// NewUsecase returns new Usecase implementation
+func NewUsecase(repo Repository) Usecase {
+	return &usecase{repo: repo, now: time.Now}
+}
+
+var _ Usecase = &usecase{}